@@ -2,27 +2,30 @@ package client
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/codedellemc/libstorage/api/types"
 )
 
 // Client is the libStorage API client.
 type client struct {
-	http.Client
-	host         string
-	logRequests  bool
-	logResponses bool
-	serverName   string
+	endpoints           []*endpoint
+	loadBalance         bool
+	healthCheckInterval time.Duration
+	retries             int
+	retryDelay          time.Duration
+	active              int32
+	next                uint32
+	pins                *txPins
+	logRequests         bool
+	logResponses        bool
+	serverName          string
 }
 
 // New returns a new API client.
 func New(host string, transport *http.Transport) types.APIClient {
-	return &client{
-		Client: http.Client{
-			Transport: transport,
-		},
-		host: host,
-	}
+	return NewWithEndpoints(
+		[]*Endpoint{{Host: host, Transport: transport}}, nil)
 }
 
 func (c *client) ServerName() string {
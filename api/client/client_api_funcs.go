@@ -3,6 +3,8 @@ package client
 import (
 	"bytes"
 	"fmt"
+	neturl "net/url"
+	"strings"
 
 	"github.com/codedellemc/libstorage/api/types"
 )
@@ -82,10 +84,12 @@ func (c *client) ServiceInspect(
 
 func (c *client) Volumes(
 	ctx types.Context,
-	attachments types.VolumeAttachmentsTypes) (types.ServiceVolumeMap, error) {
+	attachments types.VolumeAttachmentsTypes,
+	filter string) (types.ServiceVolumeMap, error) {
 
 	reply := types.ServiceVolumeMap{}
-	url := fmt.Sprintf("/volumes?attachments=%v", attachments)
+	url := withFilter(
+		fmt.Sprintf("/volumes?attachments=%v", attachments), filter)
 	if _, err := c.httpGet(ctx, url, &reply); err != nil {
 		return nil, err
 	}
@@ -95,16 +99,67 @@ func (c *client) Volumes(
 func (c *client) VolumesByService(
 	ctx types.Context,
 	service string,
-	attachments types.VolumeAttachmentsTypes) (types.VolumeMap, error) {
+	attachments types.VolumeAttachmentsTypes,
+	filter string) (types.VolumeMap, error) {
 
 	reply := types.VolumeMap{}
-	url := fmt.Sprintf("/volumes/%s?attachments=%v", service, attachments)
+	url := withFilter(
+		fmt.Sprintf("/volumes/%s?attachments=%v", service, attachments),
+		filter)
 	if _, err := c.httpGet(ctx, url, &reply); err != nil {
 		return nil, err
 	}
 	return reply, nil
 }
 
+func (c *client) VolumesPage(
+	ctx types.Context,
+	attachments types.VolumeAttachmentsTypes,
+	filter string,
+	page *types.PageOpts) (types.ServiceVolumeMap, string, error) {
+
+	reply := types.ServiceVolumeMap{}
+	url := withPage(withFilter(
+		fmt.Sprintf("/volumes?attachments=%v", attachments), filter), page)
+	res, err := c.httpGet(ctx, url, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	return reply, res.Header.Get(types.ContinueHeader), nil
+}
+
+func (c *client) VolumesByServicePage(
+	ctx types.Context,
+	service string,
+	attachments types.VolumeAttachmentsTypes,
+	filter string,
+	page *types.PageOpts) (types.VolumeMap, string, error) {
+
+	reply := types.VolumeMap{}
+	url := withPage(withFilter(
+		fmt.Sprintf("/volumes/%s?attachments=%v", service, attachments),
+		filter), page)
+	res, err := c.httpGet(ctx, url, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	return reply, res.Header.Get(types.ContinueHeader), nil
+}
+
+func (c *client) VolumePages(
+	ctx types.Context,
+	attachments types.VolumeAttachmentsTypes,
+	filter string,
+	limit int) *types.VolumePageIterator {
+
+	return types.NewVolumePageIterator(
+		func(token string) (types.ServiceVolumeMap, string, error) {
+			return c.VolumesPage(
+				ctx, attachments, filter,
+				&types.PageOpts{Limit: limit, Continue: token})
+		}, "")
+}
+
 func (c *client) VolumeInspect(
 	ctx types.Context,
 	service, volumeID string,
@@ -247,6 +302,21 @@ func (c *client) VolumeDetachAllForService(
 	return reply, nil
 }
 
+func (c *client) VolumeResize(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeResizeRequest) (*types.Volume, error) {
+
+	reply := types.Volume{}
+	if _, err := c.httpPost(ctx,
+		fmt.Sprintf("/volumes/%s/%s?resize",
+			service, volumeID), request, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
 func (c *client) VolumeSnapshot(
 	ctx types.Context,
 	service string,
@@ -263,26 +333,68 @@ func (c *client) VolumeSnapshot(
 }
 
 func (c *client) Snapshots(
-	ctx types.Context) (types.ServiceSnapshotMap, error) {
+	ctx types.Context, filter string) (types.ServiceSnapshotMap, error) {
 
 	reply := types.ServiceSnapshotMap{}
-	if _, err := c.httpGet(ctx, "/snapshots", &reply); err != nil {
+	url := withFilter("/snapshots", filter)
+	if _, err := c.httpGet(ctx, url, &reply); err != nil {
 		return nil, err
 	}
 	return reply, nil
 }
 
 func (c *client) SnapshotsByService(
-	ctx types.Context, service string) (types.SnapshotMap, error) {
+	ctx types.Context, service, filter string) (types.SnapshotMap, error) {
 
 	reply := types.SnapshotMap{}
-	if _, err := c.httpGet(ctx,
-		fmt.Sprintf("/snapshots/%s", service), &reply); err != nil {
+	url := withFilter(fmt.Sprintf("/snapshots/%s", service), filter)
+	if _, err := c.httpGet(ctx, url, &reply); err != nil {
 		return nil, err
 	}
 	return reply, nil
 }
 
+func (c *client) SnapshotsPage(
+	ctx types.Context,
+	filter string,
+	page *types.PageOpts) (types.ServiceSnapshotMap, string, error) {
+
+	reply := types.ServiceSnapshotMap{}
+	url := withPage(withFilter("/snapshots", filter), page)
+	res, err := c.httpGet(ctx, url, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	return reply, res.Header.Get(types.ContinueHeader), nil
+}
+
+func (c *client) SnapshotsByServicePage(
+	ctx types.Context,
+	service, filter string,
+	page *types.PageOpts) (types.SnapshotMap, string, error) {
+
+	reply := types.SnapshotMap{}
+	url := withPage(
+		withFilter(fmt.Sprintf("/snapshots/%s", service), filter), page)
+	res, err := c.httpGet(ctx, url, &reply)
+	if err != nil {
+		return nil, "", err
+	}
+	return reply, res.Header.Get(types.ContinueHeader), nil
+}
+
+func (c *client) SnapshotPages(
+	ctx types.Context,
+	filter string,
+	limit int) *types.SnapshotPageIterator {
+
+	return types.NewSnapshotPageIterator(
+		func(token string) (types.ServiceSnapshotMap, string, error) {
+			return c.SnapshotsPage(
+				ctx, filter, &types.PageOpts{Limit: limit, Continue: token})
+		}, "")
+}
+
 func (c *client) SnapshotInspect(
 	ctx types.Context,
 	service, snapshotID string) (*types.Snapshot, error) {
@@ -320,3 +432,39 @@ func (c *client) SnapshotCopy(
 	}
 	return &reply, nil
 }
+
+// withFilter appends the filter query parameter to a URL if the filter is not
+// empty.
+func withFilter(u, filter string) string {
+	if filter == "" {
+		return u
+	}
+	sep := "?"
+	if strings.Contains(u, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%sfilter=%s", u, sep, neturl.QueryEscape(filter))
+}
+
+// withPage appends the limit and continue query parameters to a URL.
+func withPage(u string, page *types.PageOpts) string {
+	if page == nil {
+		return u
+	}
+	var q []string
+	if page.Limit > 0 {
+		q = append(q, fmt.Sprintf("limit=%d", page.Limit))
+	}
+	if page.Continue != "" {
+		q = append(q, fmt.Sprintf(
+			"continue=%s", neturl.QueryEscape(page.Continue)))
+	}
+	if len(q) == 0 {
+		return u
+	}
+	sep := "?"
+	if strings.Contains(u, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%s%s", u, sep, strings.Join(q, "&"))
+}
@@ -0,0 +1,318 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// waitTaskMinInterval is the initial interval between task inspections
+	// when waiting for a task to complete.
+	waitTaskMinInterval = 100 * time.Millisecond
+
+	// waitTaskMaxInterval is the maximum interval between task inspections
+	// when waiting for a task to complete.
+	waitTaskMaxInterval = 5 * time.Second
+)
+
+// taskReply is the wire format of a task. The result and error are kept as
+// raw JSON so the result may be decoded into the caller's model type and the
+// error into an error value.
+type taskReply struct {
+	*types.Task
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+func newTaskReply() *taskReply {
+	return &taskReply{Task: &types.Task{}}
+}
+
+// task returns the reply's task with its result and error decoded. The
+// result is decoded generically; use WaitTask to decode the result into a
+// model type.
+func (r *taskReply) task() *types.Task {
+	if len(r.Result) > 0 && string(r.Result) != "null" {
+		var result interface{}
+		if err := json.Unmarshal(r.Result, &result); err == nil {
+			r.Task.Result = result
+		}
+	}
+	if len(r.Error) > 0 && string(r.Error) != "null" {
+		r.Task.Error = decTaskError(r.Error)
+	}
+	return r.Task
+}
+
+// decTaskError decodes a task's error. A task's error is marshaled as either
+// a string or an object with the error's message and fields.
+func decTaskError(buf json.RawMessage) error {
+	var msg string
+	if err := json.Unmarshal(buf, &msg); err == nil {
+		return goof.New(msg)
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(buf, &obj); err == nil {
+		for _, k := range []string{"message", "msg", "error"} {
+			if v, ok := obj[k].(string); ok && v != "" {
+				return goof.New(v)
+			}
+		}
+	}
+	return goof.New(string(buf))
+}
+
+// withAsync appends the async query parameter to a URL.
+func withAsync(u string) string {
+	if strings.Contains(u, "?") {
+		return u + "&async"
+	}
+	return u + "?async"
+}
+
+func (c *client) httpPostAsync(
+	ctx types.Context,
+	path string,
+	payload interface{}) (*types.Task, error) {
+
+	reply := newTaskReply()
+	if _, err := c.httpPost(ctx, withAsync(path), payload, reply); err != nil {
+		return nil, err
+	}
+	return reply.task(), nil
+}
+
+func (c *client) httpDeleteAsync(
+	ctx types.Context, path string) (*types.Task, error) {
+
+	reply := newTaskReply()
+	if _, err := c.httpDelete(ctx, withAsync(path), reply); err != nil {
+		return nil, err
+	}
+	return reply.task(), nil
+}
+
+func (c *client) Tasks(ctx types.Context) (map[int]*types.Task, error) {
+
+	reply := map[string]*taskReply{}
+	if _, err := c.httpGet(ctx, "/tasks", &reply); err != nil {
+		return nil, err
+	}
+	tasks := map[int]*types.Task{}
+	for k, v := range reply {
+		id, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, goof.WithFieldE("taskID", k, "invalid task id", err)
+		}
+		if v.Task == nil {
+			v.Task = &types.Task{}
+		}
+		tasks[id] = v.task()
+	}
+	return tasks, nil
+}
+
+func (c *client) TaskInspect(
+	ctx types.Context, taskID int) (*types.Task, error) {
+
+	reply, err := c.taskInspect(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return reply.task(), nil
+}
+
+func (c *client) taskInspect(
+	ctx types.Context, taskID int) (*taskReply, error) {
+
+	reply := newTaskReply()
+	if _, err := c.httpGet(
+		ctx, fmt.Sprintf("/tasks/%d", taskID), reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *client) TaskCancel(
+	ctx types.Context, taskID int) (*types.Task, error) {
+
+	reply := newTaskReply()
+	if _, err := c.httpDelete(
+		ctx, fmt.Sprintf("/tasks/%d", taskID), reply); err != nil {
+		return nil, err
+	}
+	return reply.task(), nil
+}
+
+func (c *client) WaitTask(
+	ctx types.Context,
+	taskID int,
+	reply interface{}) (*types.Task, error) {
+
+	interval := waitTaskMinInterval
+	for {
+		tr, err := c.taskInspect(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+
+		task := tr.task()
+		switch task.State {
+		case types.TaskStateSuccess:
+			if reply != nil && len(tr.Result) > 0 {
+				if err := json.NewDecoder(
+					bytes.NewReader(tr.Result)).Decode(reply); err != nil {
+					return task, err
+				}
+			}
+			return task, nil
+		case types.TaskStateError:
+			if task.Error == nil {
+				return task, goof.WithField("taskID", taskID, "task failed")
+			}
+			return task, task.Error
+		case types.TaskStateCanceled:
+			return task, types.ErrTaskCanceled
+		}
+
+		ctx.WithFields(log.Fields{
+			"taskID":   taskID,
+			"state":    task.State,
+			"interval": interval,
+		}).Debug("waiting for task")
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return task, ctx.Err()
+		}
+
+		if interval *= 2; interval > waitTaskMaxInterval {
+			interval = waitTaskMaxInterval
+		}
+	}
+}
+
+func (c *client) VolumeCreateAsync(
+	ctx types.Context,
+	service string,
+	request *types.VolumeCreateRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(
+		ctx, fmt.Sprintf("/volumes/%s", service), request)
+}
+
+func (c *client) VolumeCreateFromSnapshotAsync(
+	ctx types.Context,
+	service, snapshotID string,
+	request *types.VolumeCreateRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/snapshots/%s/%s?create", service, snapshotID), request)
+}
+
+func (c *client) VolumeCopyAsync(
+	ctx types.Context,
+	service, volumeID string,
+	request *types.VolumeCopyRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/volumes/%s/%s?copy", service, volumeID), request)
+}
+
+func (c *client) VolumeRemoveAsync(
+	ctx types.Context,
+	service, volumeID string,
+	force bool) (*types.Task, error) {
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "/volumes/%s/%s", service, volumeID)
+	if force {
+		fmt.Fprintf(buf, "?force")
+	}
+	return c.httpDeleteAsync(ctx, buf.String())
+}
+
+func (c *client) VolumeAttachAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeAttachRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/volumes/%s/%s?attach", service, volumeID), request)
+}
+
+func (c *client) VolumeDetachAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeDetachRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/volumes/%s/%s?detach", service, volumeID), request)
+}
+
+func (c *client) VolumeDetachAllAsync(
+	ctx types.Context,
+	request *types.VolumeDetachRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx, "/volumes?detach", request)
+}
+
+func (c *client) VolumeDetachAllForServiceAsync(
+	ctx types.Context,
+	service string,
+	request *types.VolumeDetachRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/volumes/%s?detach", service), request)
+}
+
+func (c *client) VolumeResizeAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeResizeRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/volumes/%s/%s?resize", service, volumeID), request)
+}
+
+func (c *client) VolumeSnapshotAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeSnapshotRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/volumes/%s/%s?snapshot", service, volumeID), request)
+}
+
+func (c *client) SnapshotRemoveAsync(
+	ctx types.Context,
+	service, snapshotID string) (*types.Task, error) {
+
+	return c.httpDeleteAsync(
+		ctx, fmt.Sprintf("/snapshots/%s/%s", service, snapshotID))
+}
+
+func (c *client) SnapshotCopyAsync(
+	ctx types.Context,
+	service, snapshotID string,
+	request *types.SnapshotCopyRequest) (*types.Task, error) {
+
+	return c.httpPostAsync(ctx,
+		fmt.Sprintf("/snapshots/%s/%s?copy", service, snapshotID), request)
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/codedellemc/libstorage/api/rpc"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// dialRPC returns the endpoint's gRPC connection, which is dialed the first
+// time it is needed. The connection is established with the dialer of the
+// endpoint's transport, which also performs the TLS handshake if TLS is
+// configured.
+func (e *endpoint) dialRPC() (*grpc.ClientConn, error) {
+	e.rpcOnce.Do(func() {
+		var dial func(string, string) (net.Conn, error)
+		if t, ok := e.client.Transport.(*http.Transport); ok && t != nil {
+			dial = t.Dial
+		}
+		e.rpcConn, e.rpcErr = grpc.Dial(
+			e.host,
+			grpc.WithInsecure(),
+			grpc.WithDialer(
+				func(addr string, timeout time.Duration) (net.Conn, error) {
+					if dial != nil {
+						return dial("tcp", addr)
+					}
+					return net.DialTimeout("tcp", addr, timeout)
+				}))
+	})
+	return e.rpcConn, e.rpcErr
+}
+
+// rpcDo sends the request as a call to the gRPC method to which the request
+// maps and returns the call's reply as an HTTP response whose body is the
+// JSON encoding of the reply's model. The request's headers are sent as the
+// call's metadata, and the error a server returns for a call becomes a
+// response with the error's HTTP status.
+func (e *endpoint) rpcDo(
+	ctx types.Context, req *http.Request) (*http.Response, error) {
+
+	conn, err := e.dialRPC()
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if req.Body != nil {
+		defer req.Body.Close()
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	method, rpcReq, err := rpc.NewRequest(
+		req.Method, req.URL.Path, req.URL.RawQuery, body)
+	if err != nil {
+		return nil, err
+	}
+
+	md := metadata.MD{}
+	for k, v := range req.Header {
+		md[strings.ToLower(k)] = v
+	}
+
+	var (
+		header metadata.MD
+		reply  = method.NewReply()
+	)
+	err = grpc.Invoke(
+		metadata.NewOutgoingContext(ctx, md),
+		method.FullMethod(), rpcReq, reply, conn, grpc.Header(&header))
+
+	res := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+		Header:     http.Header{},
+		Request:    req,
+	}
+	for k, v := range header {
+		res.Header[http.CanonicalHeaderKey(k)] = v
+	}
+
+	if err != nil {
+		status, errBody, ok := rpc.HTTPError(err)
+		if !ok {
+			return nil, err
+		}
+		res.StatusCode = status
+		res.Status = fmt.Sprintf("%d %s", status, http.StatusText(status))
+		res.Body = ioutil.NopCloser(bytes.NewReader(errBody))
+		return res, nil
+	}
+
+	resBody, err := rpc.EncodeReply(reply, res.Header)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+	return res, nil
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gocontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/rpc"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const testHeader = "Libstorage-Test"
+
+// testServer implements the methods of the API used by the tests.
+type testServer struct {
+	rpc.APIServer
+}
+
+func (s *testServer) VolumeInspect(
+	ctx gocontext.Context,
+	req *rpc.VolumeInspectRequest) (*rpc.Volume, error) {
+
+	if req.VolumeId == "missing" {
+		return nil, rpc.NewError(http.StatusNotFound,
+			[]byte(`{"message":"resource not found","status":404}`))
+	}
+
+	// the request's headers are the call's metadata
+	md, _ := metadata.FromIncomingContext(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs("libstorage-test", "ok"))
+	return &rpc.Volume{
+		Id:   req.VolumeId,
+		Name: req.Service,
+		Size: 10,
+		Fields: map[string]string{
+			"tx":     strings.Join(md["libstorage-tx"], ","),
+			"byName": map[bool]string{true: "true"}[req.ByName],
+		},
+	}, nil
+}
+
+func (s *testServer) VolumesByService(
+	ctx gocontext.Context,
+	req *rpc.VolumesByServiceRequest) (*rpc.VolumesByServiceReply, error) {
+
+	return &rpc.VolumesByServiceReply{
+		Volumes: map[string]*rpc.Volume{
+			"vfs-000": {Id: "vfs-000", Name: req.Filter},
+		},
+		Continue: "next",
+	}, nil
+}
+
+func (s *testServer) VolumeCreateAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeCreateRequest) (*rpc.Task, error) {
+
+	return &rpc.Task{
+		Id:    int32(req.Size.Value),
+		State: string(types.TaskStateQueued),
+		User:  req.Name,
+	}, nil
+}
+
+func newTestEndpoint(t *testing.T) (*endpoint, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	rpc.RegisterAPIServer(s, &testServer{})
+	go s.Serve(l)
+
+	e := &endpoint{host: l.Addr().String(), protocol: rpc.Protocol}
+	return e, func() {
+		if e.rpcConn != nil {
+			e.rpcConn.Close()
+		}
+		s.Stop()
+	}
+}
+
+func TestRPCDo(t *testing.T) {
+	e, closeEndpoint := newTestEndpoint(t)
+	defer closeEndpoint()
+
+	req, err := http.NewRequest(
+		"GET", "http://"+e.host+"/volumes/vfs/vfs-000?byName", nil)
+	assert.NoError(t, err)
+	req.Header.Set(types.TransactionHeader, "txid=1")
+
+	res, err := e.do(context.Background(), req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "ok", res.Header.Get(testHeader))
+
+	vol := &types.Volume{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(vol))
+	assert.Equal(t, "vfs-000", vol.ID)
+	assert.Equal(t, "vfs", vol.Name)
+	assert.Equal(t, int64(10), vol.Size)
+	assert.Equal(t, "txid=1", vol.Fields["tx"])
+	assert.Equal(t, "true", vol.Fields["byName"])
+}
+
+func TestRPCDoPage(t *testing.T) {
+	e, closeEndpoint := newTestEndpoint(t)
+	defer closeEndpoint()
+
+	req, err := http.NewRequest(
+		"GET", "http://"+e.host+"/volumes/vfs?filter=a&limit=1", nil)
+	assert.NoError(t, err)
+
+	res, err := e.rpcDo(context.Background(), req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "next", res.Header.Get(types.ContinueHeader))
+	vm := types.VolumeMap{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&vm))
+	assert.Equal(t, "a", vm["vfs-000"].Name)
+}
+
+func TestRPCDoPayload(t *testing.T) {
+	e, closeEndpoint := newTestEndpoint(t)
+	defer closeEndpoint()
+
+	body := []byte(`{"name":"a","size":7}`)
+	req, err := http.NewRequest(
+		"POST", "http://"+e.host+"/volumes/vfs?async",
+		bytes.NewReader(body))
+	assert.NoError(t, err)
+
+	res, err := e.rpcDo(context.Background(), req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	tr := newTaskReply()
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(tr))
+	task := tr.task()
+	assert.Equal(t, 7, task.ID)
+	assert.Equal(t, "a", task.User)
+	assert.Equal(t, types.TaskStateQueued, task.State)
+}
+
+func TestRPCDoError(t *testing.T) {
+	e, closeEndpoint := newTestEndpoint(t)
+	defer closeEndpoint()
+
+	req, err := http.NewRequest(
+		"GET", "http://"+e.host+"/volumes/vfs/missing", nil)
+	assert.NoError(t, err)
+
+	// the error a server returns for a call is a response with the error's
+	// HTTP status and body
+	res, err := e.rpcDo(context.Background(), req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	buf, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"message":"resource not found","status":404}`,
+		string(buf))
+
+	// a request that does not map to a method is an error
+	req, err = http.NewRequest("GET", "http://"+e.host+"/metrics", nil)
+	assert.NoError(t, err)
+	_, err = e.rpcDo(context.Background(), req)
+	assert.Error(t, err)
+}
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+	"google.golang.org/grpc"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/rpc"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default duration for which a host
+	// that could not be reached is skipped before it is health checked.
+	DefaultHealthCheckInterval = 10 * time.Second
+
+	// DefaultRetryDelay is the default duration to wait before a request is
+	// sent again.
+	DefaultRetryDelay = time.Second
+
+	// txPinTTL is how long a transaction remains pinned to a host after the
+	// transaction's last request.
+	txPinTTL = 10 * time.Minute
+)
+
+// Endpoint is a libStorage server to which the client sends requests.
+type Endpoint struct {
+
+	// Host is the value of the requests' Host header, ex. the server's
+	// address or TLS server name.
+	Host string
+
+	// Transport is the transport used to connect to the server.
+	Transport *http.Transport
+
+	// Protocol is the protocol of the API the server serves, either "http"
+	// or "grpc". The HTTP API is used if the value is empty.
+	Protocol string
+}
+
+// Options are the options for a client with multiple endpoints.
+type Options struct {
+
+	// LoadBalance distributes the requests that do not modify any state
+	// across the available endpoints. Otherwise all requests are sent to
+	// the first available endpoint.
+	LoadBalance bool
+
+	// HealthCheckInterval is the duration for which an endpoint that could
+	// not be reached is skipped before it is health checked with a Root
+	// request. DefaultHealthCheckInterval is used if the value is zero.
+	HealthCheckInterval time.Duration
+
+	// Retries is the number of times a request is sent again if it could not
+	// be sent or if the server responded that it is unavailable. Requests
+	// that modify state are sent with an idempotency key so the server does
+	// not handle a request that is sent again more than once.
+	Retries int
+
+	// RetryDelay is the duration to wait before a request is sent again. The
+	// delay doubles with each retry. DefaultRetryDelay is used if the value
+	// is zero.
+	RetryDelay time.Duration
+}
+
+type endpoint struct {
+	sync.RWMutex
+	host      string
+	protocol  string
+	client    http.Client
+	downUntil time.Time
+
+	rpcOnce sync.Once
+	rpcConn *grpc.ClientConn
+	rpcErr  error
+}
+
+// do sends the request to the endpoint with the endpoint's protocol.
+func (e *endpoint) do(
+	ctx types.Context, req *http.Request) (*http.Response, error) {
+
+	if e.protocol == rpc.Protocol {
+		return e.rpcDo(ctx, req)
+	}
+	return ctxhttp.Do(ctx, &e.client, req)
+}
+
+// down returns a flag indicating whether the endpoint could not be reached
+// and, if so, whether it is due to be health checked.
+func (e *endpoint) down() (bool, bool) {
+	e.RLock()
+	defer e.RUnlock()
+	if e.downUntil.IsZero() {
+		return false, false
+	}
+	return true, time.Now().After(e.downUntil)
+}
+
+func (e *endpoint) markDown(d time.Duration) {
+	e.Lock()
+	defer e.Unlock()
+	e.downUntil = time.Now().Add(d)
+}
+
+func (e *endpoint) markUp() {
+	e.Lock()
+	defer e.Unlock()
+	e.downUntil = time.Time{}
+}
+
+// NewWithEndpoints returns a new API client that sends its requests to
+// multiple libStorage servers.
+//
+// A request that cannot be sent because an endpoint cannot be reached is
+// sent to the next endpoint, and the unreachable endpoint is skipped until it
+// passes a health check. Requests that modify state are only sent to another
+// endpoint if the connection to the first one could not be established. Once
+// a request that modifies state has been sent for a transaction, the
+// transaction's remaining requests are sent to the same endpoint.
+func NewWithEndpoints(
+	endpoints []*Endpoint, opts *Options) types.APIClient {
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	c := &client{
+		loadBalance:         opts.LoadBalance,
+		healthCheckInterval: opts.HealthCheckInterval,
+		retries:             opts.Retries,
+		retryDelay:          opts.RetryDelay,
+		pins:                &txPins{m: map[string]*txPin{}},
+	}
+	if c.healthCheckInterval <= 0 {
+		c.healthCheckInterval = DefaultHealthCheckInterval
+	}
+	if c.retries < 0 {
+		c.retries = 0
+	}
+	if c.retryDelay <= 0 {
+		c.retryDelay = DefaultRetryDelay
+	}
+
+	for _, ep := range endpoints {
+		c.endpoints = append(c.endpoints, &endpoint{
+			host:     ep.Host,
+			protocol: ep.Protocol,
+			client:   http.Client{Transport: ep.Transport},
+		})
+	}
+
+	return c
+}
+
+// withEndpoint invokes the function with an endpoint, failing over to the
+// next endpoint if the function returns an error that indicates the request
+// may be safely sent again.
+func (c *client) withEndpoint(
+	ctx types.Context,
+	method string,
+	f func(*endpoint) error) error {
+
+	if len(c.endpoints) == 1 {
+		return f(c.endpoints[0])
+	}
+
+	var (
+		readOnly = method == http.MethodGet || method == http.MethodHead
+		txID     = transactionID(ctx)
+		order    = c.order(readOnly, txID)
+		tried    = map[int]bool{}
+		lastErr  error
+	)
+
+	try := func(i int) (bool, error) {
+		tried[i] = true
+		ep := c.endpoints[i]
+		err := f(ep)
+		if err == nil {
+			ep.markUp()
+			if !readOnly && txID != "" {
+				c.pins.set(txID, i)
+			}
+			if !readOnly || !c.loadBalance {
+				atomic.StoreInt32(&c.active, int32(i))
+			}
+			return true, nil
+		}
+		if ctx.Err() != nil || !isFailoverError(err, readOnly) {
+			return true, err
+		}
+		ctx.WithError(err).WithField("host", ep.host).Warn(
+			"error connecting to host; failing over")
+		ep.markDown(c.healthCheckInterval)
+		return false, err
+	}
+
+	// the endpoints that are available are tried first, and if none of them
+	// could be tried then the unavailable ones are tried as a last resort
+	for _, i := range order {
+		if !c.available(ctx, c.endpoints[i]) {
+			continue
+		}
+		if done, err := try(i); done {
+			return err
+		} else if err != nil {
+			lastErr = err
+		}
+	}
+	for _, i := range order {
+		if tried[i] {
+			continue
+		}
+		if done, err := try(i); done {
+			return err
+		} else if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// order returns the indices of the endpoints in the order in which they are
+// tried for a request.
+func (c *client) order(readOnly bool, txID string) []int {
+	n := len(c.endpoints)
+
+	var start int
+	if i, ok := c.pins.get(txID); ok {
+		start = i
+	} else if readOnly && c.loadBalance {
+		start = int(atomic.AddUint32(&c.next, 1) % uint32(n))
+	} else {
+		start = int(atomic.LoadInt32(&c.active))
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = (start + i) % n
+	}
+	return order
+}
+
+// available returns a flag indicating whether the endpoint may be sent a
+// request. An endpoint that could not be reached is available again once it
+// passes a health check.
+func (c *client) available(ctx types.Context, ep *endpoint) bool {
+	down, due := ep.down()
+	if !down {
+		return true
+	}
+	if !due {
+		return false
+	}
+	if err := c.healthCheck(ctx, ep); err != nil {
+		ctx.WithError(err).WithField("host", ep.host).Debug(
+			"host failed health check")
+		ep.markDown(c.healthCheckInterval)
+		return false
+	}
+	ctx.WithField("host", ep.host).Info("host passed health check")
+	ep.markUp()
+	return true
+}
+
+// healthCheck sends a Root request to the endpoint. Any response that is not
+// a server error means the endpoint is healthy.
+func (c *client) healthCheck(ctx types.Context, ep *endpoint) error {
+	req, err := http.NewRequest(
+		http.MethodGet, fmt.Sprintf("http://%s/", ep.host), nil)
+	if err != nil {
+		return err
+	}
+	res, err := ep.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode >= 500 {
+		return fmt.Errorf("health check status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// isFailoverError returns a flag indicating whether a request that failed
+// with the error may be sent to another endpoint. Requests that modify state
+// are only sent again if the connection could not be established, as
+// otherwise the server may have received the request.
+func isFailoverError(err error, readOnly bool) bool {
+	if ue, ok := err.(*neturl.Error); ok {
+		err = ue.Err
+	}
+	if oe, ok := err.(*net.OpError); ok && oe.Op == "dial" {
+		return true
+	}
+	return readOnly
+}
+
+func transactionID(ctx types.Context) string {
+	if tx, ok := context.Transaction(ctx); ok && tx.ID != nil {
+		return tx.ID.String()
+	}
+	return ""
+}
+
+// txPins are the endpoints to which transactions are pinned.
+type txPins struct {
+	sync.Mutex
+	m map[string]*txPin
+}
+
+type txPin struct {
+	index    int
+	lastUsed time.Time
+}
+
+func (p *txPins) get(txID string) (int, bool) {
+	if txID == "" {
+		return 0, false
+	}
+	p.Lock()
+	defer p.Unlock()
+	pin, ok := p.m[txID]
+	if !ok || time.Since(pin.lastUsed) > txPinTTL {
+		return 0, false
+	}
+	pin.lastUsed = time.Now()
+	return pin.index, true
+}
+
+func (p *txPins) set(txID string, index int) {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	for k, pin := range p.m {
+		if now.Sub(pin.lastUsed) > txPinTTL {
+			delete(p.m, k)
+		}
+	}
+	p.m[txID] = &txPin{index: index, lastUsed: now}
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/goof"
-	"golang.org/x/net/context/ctxhttp"
 
 	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/types"
@@ -64,11 +66,7 @@ func (c *client) httpDo(
 		return nil, err
 	}
 
-	url := fmt.Sprintf("http://%s%s", c.host, path)
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, err
-	}
+	header := http.Header{}
 
 	ctx = context.RequireTX(ctx)
 	tx := context.MustTransaction(ctx)
@@ -139,27 +137,80 @@ func (c *client) httpDo(
 		val := ctx.Value(key)
 		switch tv := val.(type) {
 		case string:
-			req.Header.Add(headerName, tv)
+			header.Add(headerName, tv)
 		case fmt.Stringer:
-			req.Header.Add(headerName, tv.String())
+			header.Add(headerName, tv.String())
 		case []string:
 			for _, sv := range tv {
-				req.Header.Add(headerName, sv)
+				header.Add(headerName, sv)
 			}
 		case []fmt.Stringer:
 			for _, sv := range tv {
-				req.Header.Add(headerName, sv.String())
+				header.Add(headerName, sv.String())
 			}
 		default:
 			if val != nil {
-				req.Header.Add(headerName, fmt.Sprintf("%v", val))
+				header.Add(headerName, fmt.Sprintf("%v", val))
 			}
 		}
 	}
 
-	c.logRequest(req)
+	// the idempotency key is generated once per call and reused only when
+	// the request is sent again below
+	if method != http.MethodGet && method != http.MethodHead {
+		key, err := types.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		header.Set(types.IdempotencyKeyHeader, key.String())
+	}
 
-	res, err := ctxhttp.Do(ctx, &c.Client, req)
+	var (
+		req   *http.Request
+		res   *http.Response
+		delay = c.retryDelay
+	)
+	for attempt := 0; ; attempt++ {
+		err = c.withEndpoint(ctx, method, func(ep *endpoint) error {
+			var body io.Reader
+			if reqBody != nil {
+				body = bytes.NewReader(reqBody)
+			}
+			url := fmt.Sprintf("http://%s%s", ep.host, path)
+			if req, err = http.NewRequest(method, url, body); err != nil {
+				return err
+			}
+			for k, v := range header {
+				req.Header[k] = v
+			}
+			c.logRequest(req)
+			res, err = ep.do(ctx, req)
+			return err
+		})
+		if attempt >= c.retries || ctx.Err() != nil || !isRetryable(res, err) {
+			break
+		}
+		fields := log.Fields{"attempt": attempt + 1, "delay": delay}
+		if err != nil {
+			ctx.WithError(err).WithFields(fields).Warn(
+				"error sending request; retrying")
+		} else {
+			fields["status"] = res.StatusCode
+			ctx.WithFields(fields).Warn("server unavailable; retrying")
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		wait := delay
+		if ra := retryAfter(res); ra > wait {
+			wait = ra
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		delay = delay * 2
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -220,17 +271,42 @@ func (c *client) httpDelete(
 	return c.httpDo(ctx, "DELETE", path, nil, reply)
 }
 
-func encPayload(payload interface{}) (io.Reader, error) {
-	if payload == nil {
-		return nil, nil
+// isRetryable returns a flag indicating whether a request that resulted in
+// the response or error may be sent again.
+func isRetryable(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
 	}
+	return false
+}
 
-	buf, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
+// retryAfter returns the duration the server asked the client to wait with
+// the response's Retry-After header before the request is sent again. Zero
+// is returned if the response does not have the header or if the header's
+// value is not a number of seconds.
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
 	}
+	secs, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
 
-	return bytes.NewReader(buf), nil
+func encPayload(payload interface{}) ([]byte, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	return json.Marshal(payload)
 }
 
 func decRes(body io.Reader, reply interface{}) error {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	gcontext "github.com/gorilla/context"
@@ -114,6 +115,44 @@ func WithRequestRoute(
 	return newContext(parent, RouteKey, route, req, nil)
 }
 
+// WithCancel returns a copy of parent with a new Done channel. The returned
+// context's Done channel is closed when the returned cancel function is called
+// or when the parent context's Done channel is closed, whichever happens
+// first.
+func WithCancel(parent context.Context) (types.Context, context.CancelFunc) {
+	cctx, cancel := context.WithCancel(parent)
+	ctx := newContext(cctx, nil, nil, nil, nil)
+
+	// preserve the parent's logger and path config since the cancel context
+	// that sits between this context and its parent hides them
+	if pctx, ok := parent.(*lsc); ok {
+		ctx.logger = pctx.logger
+		ctx.pathConfig = pctx.pathConfig
+	}
+
+	return ctx, cancel
+}
+
+// WithTimeout returns a copy of parent whose Done channel is closed when the
+// timeout elapses, when the returned cancel function is called, or when the
+// parent context's Done channel is closed, whichever happens first.
+func WithTimeout(
+	parent context.Context,
+	timeout time.Duration) (types.Context, context.CancelFunc) {
+
+	cctx, cancel := context.WithTimeout(parent, timeout)
+	ctx := newContext(cctx, nil, nil, nil, nil)
+
+	// preserve the parent's logger and path config since the timeout context
+	// that sits between this context and its parent hides them
+	if pctx, ok := parent.(*lsc); ok {
+		ctx.logger = pctx.logger
+		ctx.pathConfig = pctx.pathConfig
+	}
+
+	return ctx, cancel
+}
+
 // WithStorageService returns a new context with the StorageService as the
 // value and attempts to assign the service's associated InstanceID and
 // LocalDevices (by way of the service's StorageDriver) to the context as well.
@@ -151,7 +190,12 @@ func WithStorageSession(parent context.Context) (types.Context, error) {
 
 	pctx := New(parent)
 
-	d, ok := MustDriver(parent).(types.StorageDriverWithLogin)
+	sd := MustDriver(parent)
+	if m, ok := sd.(types.StorageDriverManager); ok {
+		sd = m.Driver()
+	}
+
+	d, ok := sd.(types.StorageDriverWithLogin)
 	if !ok {
 		pctx.Debug("driver is not StorageDriverWithLogin")
 		return pctx, nil
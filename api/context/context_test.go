@@ -87,6 +87,28 @@ func TestJoin(t *testing.T) {
 	assert.Equal(t, serviceName, v)
 }
 
+func TestWithCancel(t *testing.T) {
+
+	ctx1 := Background().WithValue(ServerKey, serverName)
+	ctx2, cancel := WithCancel(ctx1)
+
+	v, ok := Server(ctx2)
+	assert.True(t, ok)
+	assert.Equal(t, serverName, v)
+	assert.Equal(t, ctx1.Value(LoggerKey), ctx2.Value(LoggerKey))
+
+	select {
+	case <-ctx2.Done():
+		assert.Fail(t, "ctx2 done before cancel")
+	default:
+	}
+
+	cancel()
+	<-ctx2.Done()
+	assert.Error(t, ctx2.Err())
+	assert.NoError(t, ctx1.Err())
+}
+
 type driver struct {
 }
 
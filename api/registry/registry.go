@@ -25,6 +25,15 @@ var (
 	intDriverCtors    = map[string]types.NewIntegrationDriver{}
 	intDriverCtorsRWL = &sync.RWMutex{}
 
+	taskStoreCtors    = map[string]types.NewTaskStore{}
+	taskStoreCtorsRWL = &sync.RWMutex{}
+
+	eventSinkCtors    = map[string]types.NewEventSink{}
+	eventSinkCtorsRWL = &sync.RWMutex{}
+
+	lockMgrCtors    = map[string]types.NewLockManager{}
+	lockMgrCtorsRWL = &sync.RWMutex{}
+
 	cfgRegs    = []*cregW{}
 	cfgRegsRWL = &sync.RWMutex{}
 
@@ -80,6 +89,27 @@ func RegisterIntegrationDriver(name string, ctor types.NewIntegrationDriver) {
 	intDriverCtors[strings.ToLower(name)] = ctor
 }
 
+// RegisterTaskStore registers a TaskStore.
+func RegisterTaskStore(name string, ctor types.NewTaskStore) {
+	taskStoreCtorsRWL.Lock()
+	defer taskStoreCtorsRWL.Unlock()
+	taskStoreCtors[strings.ToLower(name)] = ctor
+}
+
+// RegisterLockManager registers a LockManager.
+func RegisterLockManager(name string, ctor types.NewLockManager) {
+	lockMgrCtorsRWL.Lock()
+	defer lockMgrCtorsRWL.Unlock()
+	lockMgrCtors[strings.ToLower(name)] = ctor
+}
+
+// RegisterEventSink registers an EventSink.
+func RegisterEventSink(name string, ctor types.NewEventSink) {
+	eventSinkCtorsRWL.Lock()
+	defer eventSinkCtorsRWL.Unlock()
+	eventSinkCtors[strings.ToLower(name)] = ctor
+}
+
 // NewStorageExecutor returns a new instance of the executor specified by the
 // executor name.
 func NewStorageExecutor(name string) (types.StorageExecutor, error) {
@@ -160,6 +190,66 @@ func NewIntegrationDriver(name string) (types.IntegrationDriver, error) {
 	return NewIntegrationDriverManager(ctor()), nil
 }
 
+// NewTaskStore returns a new instance of the task store specified by the
+// store name.
+func NewTaskStore(name string) (types.TaskStore, error) {
+
+	var ok bool
+	var ctor types.NewTaskStore
+
+	func() {
+		taskStoreCtorsRWL.RLock()
+		defer taskStoreCtorsRWL.RUnlock()
+		ctor, ok = taskStoreCtors[strings.ToLower(name)]
+	}()
+
+	if !ok {
+		return nil, goof.WithField("store", name, "invalid task store name")
+	}
+
+	return ctor(), nil
+}
+
+// NewLockManager returns a new instance of the lock manager specified by the
+// lock manager name.
+func NewLockManager(name string) (types.LockManager, error) {
+
+	var ok bool
+	var ctor types.NewLockManager
+
+	func() {
+		lockMgrCtorsRWL.RLock()
+		defer lockMgrCtorsRWL.RUnlock()
+		ctor, ok = lockMgrCtors[strings.ToLower(name)]
+	}()
+
+	if !ok {
+		return nil, goof.WithField("type", name, "invalid lock manager name")
+	}
+
+	return ctor(), nil
+}
+
+// NewEventSink returns a new instance of the event sink specified by the
+// sink name.
+func NewEventSink(name string) (types.EventSink, error) {
+
+	var ok bool
+	var ctor types.NewEventSink
+
+	func() {
+		eventSinkCtorsRWL.RLock()
+		defer eventSinkCtorsRWL.RUnlock()
+		ctor, ok = eventSinkCtors[strings.ToLower(name)]
+	}()
+
+	if !ok {
+		return nil, goof.WithField("sink", name, "invalid event sink name")
+	}
+
+	return ctor(), nil
+}
+
 // ConfigRegs returns a channel on which all registered configuration
 // registrations are returned.
 func ConfigRegs(ctx types.Context) <-chan gofig.ConfigRegistration {
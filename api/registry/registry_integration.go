@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -148,6 +149,26 @@ func (d *idm) Mount(
 
 	opts.Preempt = d.preempt()
 
+	config := d.serviceConfig(ctx.Join(d.ctx))
+	if opts.MountOptions == "" {
+		opts.MountOptions = config.GetString(types.ConfigIgVolOpsMountOptions)
+	}
+	if opts.MountLabel == "" {
+		opts.MountLabel = config.GetString(types.ConfigIgVolOpsMountLabel)
+	}
+	if !opts.ReadOnly {
+		opts.ReadOnly = config.GetBool(types.ConfigIgVolOpsMountReadOnly)
+	}
+	if opts.NewFSLabel == "" && opts.Opts != nil {
+		opts.NewFSLabel = opts.Opts.GetString("fsLabel")
+	}
+	if opts.NewFSLabel == "" {
+		opts.NewFSLabel = config.GetString(types.ConfigIgVolOpsMountFSLabel)
+	}
+	if opts.NewFSUUID == "" && opts.Opts != nil {
+		opts.NewFSUUID = opts.Opts.GetString("fsUUID")
+	}
+
 	fields := log.Fields{
 		"volumeName": volumeName,
 		"volumeID":   volumeID,
@@ -292,6 +313,25 @@ func (d *idm) Detach(
 
 }
 
+func (d *idm) Resize(
+	ctx types.Context,
+	volumeID, volumeName string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	fields := log.Fields{
+		"volumeName": volumeName,
+		"volumeID":   volumeID,
+		"opts":       opts}
+	ctx.WithFields(fields).Debug("resizing volume")
+
+	id, ok := d.IntegrationDriver.(types.IntegrationDriverWithResize)
+	if !ok {
+		return nil, types.ErrNotImplemented
+	}
+
+	return id.Resize(ctx.Join(d.ctx), volumeID, volumeName, opts)
+}
+
 func (d *idm) initCount(volumeName string) {
 	d.Lock()
 	defer d.Unlock()
@@ -348,6 +388,15 @@ func (d *idm) decCount(volumeName string) {
 	d.addCount(volumeName, -1)
 }
 
+// serviceConfig returns the configuration scoped to the service in the
+// context so that the service's mount defaults override the global ones.
+func (d *idm) serviceConfig(ctx types.Context) gofig.Config {
+	if name, ok := context.ServiceName(ctx); ok && name != "" {
+		return d.config.Scope(fmt.Sprintf("libstorage.server.services.%s", name))
+	}
+	return d.config
+}
+
 func (d *idm) preempt() bool {
 	return d.config.GetBool(types.ConfigIgVolOpsMountPreempt)
 }
@@ -69,3 +69,37 @@ func (d *odm) Format(
 
 	return d.OSDriver.Format(ctx, deviceName, opts)
 }
+
+func (d *odm) ResizeFS(
+	ctx types.Context,
+	deviceName string,
+	opts *types.DeviceResizeOpts) error {
+
+	od, ok := d.OSDriver.(types.OSDriverWithResizeFS)
+	if !ok {
+		return types.ErrNotImplemented
+	}
+
+	return od.ResizeFS(ctx.Join(d.Context), deviceName, opts)
+}
+
+func (d *odm) Flush(
+	ctx types.Context,
+	deviceName string,
+	opts types.Store) error {
+
+	od, ok := d.OSDriver.(types.OSDriverWithFlush)
+	if !ok {
+		return types.ErrNotImplemented
+	}
+
+	if !path.IsAbs(deviceName) {
+		return nil
+	}
+
+	if _, err := os.Stat(deviceName); os.IsNotExist(err) {
+		return nil
+	}
+
+	return od.Flush(ctx.Join(d.Context), deviceName, opts)
+}
@@ -0,0 +1,505 @@
+/*
+Package rpc defines the libStorage gRPC API. The API has a method for each of
+the operations of types.APIClient, and each method maps to one of the routes
+of the HTTP API so the server handles a call with the same handlers and
+middleware it uses for the HTTP request.
+
+The API's messages and the client and server interfaces are generated from
+libstorage.proto with protoc-gen-go. The messages mirror the models of the
+types package, and this package converts them to and from the requests and
+JSON objects of the HTTP API.
+*/
+package rpc
+
+//go:generate protoc --go_out=plugins=grpc:. libstorage.proto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/akutz/goof"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// Protocol is the name of the protocol used to configure a gRPC
+	// endpoint.
+	Protocol = "grpc"
+
+	// ServiceName is the name of the gRPC service.
+	ServiceName = "libstorage.API"
+)
+
+// Request is a request message of the API.
+type Request interface {
+	proto.Message
+
+	// toHTTP sets the route params to the request's fields and returns the
+	// request's HTTP payload.
+	toHTTP(p *params) (interface{}, error)
+
+	// fromHTTP sets the request's fields to the route params and the HTTP
+	// payload.
+	fromHTTP(p *params, body []byte) error
+}
+
+// Reply is a reply message of the API.
+type Reply interface {
+	proto.Message
+
+	// toHTTP returns the HTTP reply for the message and sets the reply's
+	// headers, ex. the token of a page's continuation.
+	toHTTP(header http.Header) (interface{}, error)
+
+	// fromHTTP sets the message's fields to the HTTP reply.
+	fromHTTP(header http.Header, body []byte) error
+}
+
+// params are the path variables and query params of a route.
+type params struct {
+	service     string
+	volumeID    string
+	snapshotID  string
+	taskID      int
+	attachments types.VolumeAttachmentsTypes
+	filter      string
+	limit       int
+	continueTok string
+	byName      bool
+	instance    bool
+	force       bool
+}
+
+// Method is a method of the API and the HTTP route to which it maps.
+type Method struct {
+	name       string
+	httpMethod string
+	path       []string
+	flag       string
+	async      bool
+	newRequest func() Request
+	newReply   func() Reply
+}
+
+const (
+	volumesPath   = "/volumes/{service}"
+	volumePath    = "/volumes/{service}/{volumeID}"
+	snapshotsPath = "/snapshots/{service}"
+	snapshotPath  = "/snapshots/{service}/{snapshotID}"
+)
+
+// methods are the API's methods. The methods whose routes are distinguished
+// by a query flag precede the method with the same HTTP method and path and
+// no flag.
+var methods = []*Method{
+	{
+		name: "Root", httpMethod: "GET", path: splitPath("/"),
+		newRequest: func() Request { return &RootRequest{} },
+		newReply:   func() Reply { return &RootReply{} },
+	},
+	{
+		name: "Services", httpMethod: "GET", path: splitPath("/services"),
+		newRequest: func() Request { return &ServicesRequest{} },
+		newReply:   func() Reply { return &ServicesReply{} },
+	},
+	{
+		name: "ServiceInspect", httpMethod: "GET",
+		path:       splitPath("/services/{service}"),
+		newRequest: func() Request { return &ServiceInspectRequest{} },
+		newReply:   func() Reply { return &ServiceInfo{} },
+	},
+	{
+		name: "Volumes", httpMethod: "GET", path: splitPath("/volumes"),
+		newRequest: func() Request { return &VolumesRequest{} },
+		newReply:   func() Reply { return &VolumesReply{} },
+	},
+	{
+		name: "VolumeDetachAll", httpMethod: "POST",
+		path: splitPath("/volumes"), flag: "detach",
+		newRequest: func() Request { return &VolumeDetachAllRequest{} },
+		newReply:   func() Reply { return &VolumesReply{} },
+	},
+	{
+		name: "VolumesByService", httpMethod: "GET",
+		path:       splitPath(volumesPath),
+		newRequest: func() Request { return &VolumesByServiceRequest{} },
+		newReply:   func() Reply { return &VolumesByServiceReply{} },
+	},
+	{
+		name: "VolumeDetachAllForService", httpMethod: "POST",
+		path: splitPath(volumesPath), flag: "detach",
+		newRequest: func() Request {
+			return &VolumeDetachAllForServiceRequest{}
+		},
+		newReply: func() Reply { return &VolumeMap{} },
+	},
+	{
+		name: "VolumeCreate", httpMethod: "POST",
+		path:       splitPath(volumesPath),
+		newRequest: func() Request { return &VolumeCreateRequest{} },
+		newReply:   func() Reply { return &Volume{} },
+	},
+	{
+		name: "VolumeInspect", httpMethod: "GET",
+		path:       splitPath(volumePath),
+		newRequest: func() Request { return &VolumeInspectRequest{} },
+		newReply:   func() Reply { return &Volume{} },
+	},
+	{
+		name: "VolumeCopy", httpMethod: "POST",
+		path: splitPath(volumePath), flag: "copy",
+		newRequest: func() Request { return &VolumeCopyRequest{} },
+		newReply:   func() Reply { return &Volume{} },
+	},
+	{
+		name: "VolumeAttach", httpMethod: "POST",
+		path: splitPath(volumePath), flag: "attach",
+		newRequest: func() Request { return &VolumeAttachRequest{} },
+		newReply:   func() Reply { return &VolumeAttachReply{} },
+	},
+	{
+		name: "VolumeDetach", httpMethod: "POST",
+		path: splitPath(volumePath), flag: "detach",
+		newRequest: func() Request { return &VolumeDetachRequest{} },
+		newReply:   func() Reply { return &Volume{} },
+	},
+	{
+		name: "VolumeResize", httpMethod: "POST",
+		path: splitPath(volumePath), flag: "resize",
+		newRequest: func() Request { return &VolumeResizeRequest{} },
+		newReply:   func() Reply { return &Volume{} },
+	},
+	{
+		name: "VolumeSnapshot", httpMethod: "POST",
+		path: splitPath(volumePath), flag: "snapshot",
+		newRequest: func() Request { return &VolumeSnapshotRequest{} },
+		newReply:   func() Reply { return &Snapshot{} },
+	},
+	{
+		name: "VolumeRemove", httpMethod: "DELETE",
+		path:       splitPath(volumePath),
+		newRequest: func() Request { return &VolumeRemoveRequest{} },
+		newReply:   func() Reply { return &VolumeRemoveReply{} },
+	},
+	{
+		name: "Snapshots", httpMethod: "GET", path: splitPath("/snapshots"),
+		newRequest: func() Request { return &SnapshotsRequest{} },
+		newReply:   func() Reply { return &SnapshotsReply{} },
+	},
+	{
+		name: "SnapshotsByService", httpMethod: "GET",
+		path:       splitPath(snapshotsPath),
+		newRequest: func() Request { return &SnapshotsByServiceRequest{} },
+		newReply:   func() Reply { return &SnapshotsByServiceReply{} },
+	},
+	{
+		name: "SnapshotInspect", httpMethod: "GET",
+		path:       splitPath(snapshotPath),
+		newRequest: func() Request { return &SnapshotInspectRequest{} },
+		newReply:   func() Reply { return &Snapshot{} },
+	},
+	{
+		name: "VolumeCreateFromSnapshot", httpMethod: "POST",
+		path: splitPath(snapshotPath), flag: "create",
+		newRequest: func() Request {
+			return &VolumeCreateFromSnapshotRequest{}
+		},
+		newReply: func() Reply { return &Volume{} },
+	},
+	{
+		name: "SnapshotCopy", httpMethod: "POST",
+		path: splitPath(snapshotPath), flag: "copy",
+		newRequest: func() Request { return &SnapshotCopyRequest{} },
+		newReply:   func() Reply { return &Snapshot{} },
+	},
+	{
+		name: "SnapshotRemove", httpMethod: "DELETE",
+		path:       splitPath(snapshotPath),
+		newRequest: func() Request { return &SnapshotRemoveRequest{} },
+		newReply:   func() Reply { return &SnapshotRemoveReply{} },
+	},
+	{
+		name: "Tasks", httpMethod: "GET", path: splitPath("/tasks"),
+		newRequest: func() Request { return &TasksRequest{} },
+		newReply:   func() Reply { return &TasksReply{} },
+	},
+	{
+		name: "TaskInspect", httpMethod: "GET",
+		path:       splitPath("/tasks/{taskID}"),
+		newRequest: func() Request { return &TaskInspectRequest{} },
+		newReply:   func() Reply { return &Task{} },
+	},
+	{
+		name: "TaskCancel", httpMethod: "DELETE",
+		path:       splitPath("/tasks/{taskID}"),
+		newRequest: func() Request { return &TaskCancelRequest{} },
+		newReply:   func() Reply { return &Task{} },
+	},
+}
+
+// asyncMethods are the methods that also have an asynchronous variant that
+// returns the task that performs the operation.
+var asyncMethods = map[string]bool{
+	"VolumeCreate":              true,
+	"VolumeCreateFromSnapshot":  true,
+	"VolumeCopy":                true,
+	"VolumeRemove":              true,
+	"VolumeAttach":              true,
+	"VolumeDetach":              true,
+	"VolumeDetachAll":           true,
+	"VolumeDetachAllForService": true,
+	"VolumeResize":              true,
+	"VolumeSnapshot":            true,
+	"SnapshotRemove":            true,
+	"SnapshotCopy":              true,
+}
+
+// init appends the asynchronous variants of the methods in the same order
+// as the methods so the variants distinguished by a query flag still
+// precede the variant with the same route and no flag.
+func init() {
+	for _, sm := range methods {
+		if !asyncMethods[sm.name] {
+			continue
+		}
+		m := *sm
+		m.name = sm.name + "Async"
+		m.async = true
+		m.newReply = func() Reply { return &Task{} }
+		methods = append(methods, &m)
+	}
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// Methods returns the API's methods.
+func Methods() []*Method {
+	return methods
+}
+
+// LookupMethod returns the method with the specified name, or nil if the API
+// does not have the method.
+func LookupMethod(name string) *Method {
+	for _, m := range methods {
+		if m.name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Name returns the method's name.
+func (m *Method) Name() string {
+	return m.name
+}
+
+// FullMethod returns the full gRPC name of the method.
+func (m *Method) FullMethod() string {
+	return fmt.Sprintf("/%s/%s", ServiceName, m.name)
+}
+
+// NewReply returns a new reply message for the method.
+func (m *Method) NewReply() Reply {
+	return m.newReply()
+}
+
+// NewRequest returns the method and request for an HTTP request with the
+// provided method, path, query, and body.
+func NewRequest(
+	httpMethod, path, rawQuery string,
+	body []byte) (*Method, Request, error) {
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, async := query["async"]
+
+	segs := splitPath(path)
+	for _, m := range methods {
+		if m.httpMethod != httpMethod ||
+			len(m.path) != len(segs) || m.async != async {
+			continue
+		}
+		if _, ok := query[m.flag]; m.flag != "" && !ok {
+			continue
+		}
+		p := &params{}
+		if !m.match(segs, p) {
+			continue
+		}
+		if err := p.setQuery(query); err != nil {
+			return nil, nil, err
+		}
+		req := m.newRequest()
+		if len(bytes.TrimSpace(body)) == 0 {
+			body = nil
+		}
+		if err := req.fromHTTP(p, body); err != nil {
+			return nil, nil, err
+		}
+		return m, req, nil
+	}
+
+	return nil, nil, goof.WithFields(goof.Fields{
+		"method": httpMethod,
+		"path":   path,
+	}, "no rpc method for route")
+}
+
+// match returns a flag indicating whether the path segments match the
+// method's path and sets the params to the path's variables.
+func (m *Method) match(segs []string, p *params) bool {
+	for i, s := range m.path {
+		if !strings.HasPrefix(s, "{") {
+			if s != segs[i] {
+				return false
+			}
+			continue
+		}
+		switch s {
+		case "{service}":
+			p.service = segs[i]
+		case "{volumeID}":
+			p.volumeID = segs[i]
+		case "{snapshotID}":
+			p.snapshotID = segs[i]
+		case "{taskID}":
+			id, err := strconv.Atoi(segs[i])
+			if err != nil {
+				return false
+			}
+			p.taskID = id
+		}
+	}
+	return true
+}
+
+func (p *params) setQuery(query url.Values) error {
+	var err error
+	if v := query.Get("attachments"); v != "" {
+		var a int
+		if a, err = strconv.Atoi(v); err != nil {
+			return goof.WithFieldE(
+				"attachments", v, "invalid attachments", err)
+		}
+		p.attachments = types.VolumeAttachmentsTypes(a)
+	}
+	if v := query.Get("limit"); v != "" {
+		if p.limit, err = strconv.Atoi(v); err != nil {
+			return goof.WithFieldE("limit", v, "invalid limit", err)
+		}
+	}
+	p.filter = query.Get("filter")
+	p.continueTok = query.Get("continue")
+	_, p.byName = query["byName"]
+	_, p.instance = query["instance"]
+	_, p.force = query["force"]
+	return nil
+}
+
+// HTTPRequest returns the HTTP method, URL, and body of the route to which
+// the method maps for the request.
+func (m *Method) HTTPRequest(req Request) (string, *url.URL, []byte, error) {
+
+	p := &params{}
+	payload, err := req.toHTTP(p)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	segs := make([]string, len(m.path))
+	for i, s := range m.path {
+		switch s {
+		case "{service}":
+			s = p.service
+		case "{volumeID}":
+			s = p.volumeID
+		case "{snapshotID}":
+			s = p.snapshotID
+		case "{taskID}":
+			s = strconv.Itoa(p.taskID)
+		default:
+			segs[i] = s
+			continue
+		}
+		if s == "" {
+			return "", nil, nil, goof.WithFields(goof.Fields{
+				"method": m.name,
+				"param":  strings.Trim(m.path[i], "{}"),
+			}, "missing rpc request param")
+		}
+		segs[i] = s
+	}
+
+	// the flags are encoded without a value, the way the HTTP client sends
+	// them
+	var q []string
+	flag := func(name string, set bool) {
+		if set {
+			q = append(q, name)
+		}
+	}
+	value := func(name, v string) {
+		if v != "" {
+			q = append(q, name+"="+url.QueryEscape(v))
+		}
+	}
+	flag(m.flag, m.flag != "")
+	if p.attachments != 0 {
+		value("attachments", strconv.Itoa(int(p.attachments)))
+	}
+	value("filter", p.filter)
+	if p.limit > 0 {
+		value("limit", strconv.Itoa(p.limit))
+	}
+	value("continue", p.continueTok)
+	flag("byName", p.byName)
+	flag("instance", p.instance)
+	flag("force", p.force)
+	flag("async", m.async)
+
+	var body []byte
+	if payload != nil {
+		if body, err = json.Marshal(payload); err != nil {
+			return "", nil, nil, err
+		}
+	}
+
+	return m.httpMethod, &url.URL{
+		Path:     "/" + strings.Join(segs, "/"),
+		RawQuery: strings.Join(q, "&"),
+	}, body, nil
+}
+
+// DecodeReply sets the reply's fields to the headers and body of the HTTP
+// response.
+func DecodeReply(reply Reply, header http.Header, body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		body = nil
+	}
+	return reply.fromHTTP(header, body)
+}
+
+// EncodeReply returns the HTTP response body for the reply and sets the
+// response's headers.
+func EncodeReply(reply Reply, header http.Header) ([]byte, error) {
+	v, err := reply.toHTTP(header)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
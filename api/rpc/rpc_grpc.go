@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewError returns the gRPC error for an HTTP response with an error status.
+// The error's message is the response's body, which is the JSON encoding of
+// the error. A body that is not a JSON object, such as a plain text error,
+// becomes the message of a JSON-encoded error.
+func NewError(httpStatus int, body []byte) error {
+	body = bytes.TrimSpace(body)
+	var v map[string]interface{}
+	if json.Unmarshal(body, &v) != nil {
+		body, _ = json.Marshal(map[string]interface{}{
+			"message": string(body),
+			"status":  httpStatus,
+		})
+	}
+	return status.Error(Code(httpStatus), string(body))
+}
+
+// HTTPError returns the HTTP status and body of the error response from
+// which a gRPC error was created with NewError. The flag is false if the
+// error was not returned by a libStorage server, ex. if the server could not
+// be reached.
+func HTTPError(err error) (int, []byte, bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return 0, nil, false
+	}
+	body := []byte(s.Message())
+	var v struct {
+		Status int `json:"status"`
+	}
+	if json.Unmarshal(body, &v) != nil {
+		return 0, nil, false
+	}
+	if v.Status == 0 {
+		v.Status = HTTPStatus(s.Code())
+	}
+	return v.Status, body, true
+}
+
+// Code returns the gRPC code for an HTTP status.
+func Code(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+	if httpStatus >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// HTTPStatus returns the HTTP status for a gRPC code.
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.DeadlineExceeded:
+		return http.StatusRequestTimeout
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
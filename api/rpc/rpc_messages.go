@@ -0,0 +1,794 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// decode decodes the JSON body into v. An empty body leaves v unchanged.
+func decode(body []byte, v interface{}) error {
+	if body == nil {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
+
+// setContinue sets the header with the token of a page's continuation.
+func setContinue(header http.Header, token string) {
+	if token != "" {
+		header.Set(types.ContinueHeader, token)
+	}
+}
+
+func (m *RootRequest) toHTTP(p *params) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *RootRequest) fromHTTP(p *params, body []byte) error {
+	return nil
+}
+
+func (m *RootReply) toHTTP(header http.Header) (interface{}, error) {
+	if m.Resources == nil {
+		return []string{}, nil
+	}
+	return m.Resources, nil
+}
+
+func (m *RootReply) fromHTTP(header http.Header, body []byte) error {
+	return decode(body, &m.Resources)
+}
+
+func (m *ServicesRequest) toHTTP(p *params) (interface{}, error) {
+	p.instance = m.Instance
+	return nil, nil
+}
+
+func (m *ServicesRequest) fromHTTP(p *params, body []byte) error {
+	m.Instance = p.instance
+	return nil
+}
+
+func (m *ServicesReply) toHTTP(header http.Header) (interface{}, error) {
+	services := types.ServicesMap{}
+	for k, v := range m.Services {
+		var err error
+		if services[k], err = v.model(); err != nil {
+			return nil, err
+		}
+	}
+	return services, nil
+}
+
+func (m *ServicesReply) fromHTTP(header http.Header, body []byte) error {
+	services := types.ServicesMap{}
+	if err := decode(body, &services); err != nil {
+		return err
+	}
+	m.Services = map[string]*ServiceInfo{}
+	for k, v := range services {
+		var err error
+		if m.Services[k], err = newServiceInfo(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ServiceInspectRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.instance = m.Instance
+	return nil, nil
+}
+
+func (m *ServiceInspectRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.Instance = p.instance
+	return nil
+}
+
+func (m *ServiceInfo) toHTTP(header http.Header) (interface{}, error) {
+	return m.model()
+}
+
+func (m *ServiceInfo) fromHTTP(header http.Header, body []byte) error {
+	si := &types.ServiceInfo{}
+	if err := decode(body, si); err != nil {
+		return err
+	}
+	v, err := newServiceInfo(si)
+	if err != nil {
+		return err
+	}
+	*m = *v
+	return nil
+}
+
+func (m *VolumesRequest) toHTTP(p *params) (interface{}, error) {
+	p.attachments = types.VolumeAttachmentsTypes(m.Attachments)
+	p.filter = m.Filter
+	p.limit = int(m.Limit)
+	p.continueTok = m.Continue
+	return nil, nil
+}
+
+func (m *VolumesRequest) fromHTTP(p *params, body []byte) error {
+	m.Attachments = int32(p.attachments)
+	m.Filter = p.filter
+	m.Limit = int32(p.limit)
+	m.Continue = p.continueTok
+	return nil
+}
+
+func (m *VolumesReply) toHTTP(header http.Header) (interface{}, error) {
+	svm := types.ServiceVolumeMap{}
+	for k, v := range m.Services {
+		var err error
+		if svm[k], err = v.model(); err != nil {
+			return nil, err
+		}
+	}
+	setContinue(header, m.Continue)
+	return svm, nil
+}
+
+func (m *VolumesReply) fromHTTP(header http.Header, body []byte) error {
+	svm := types.ServiceVolumeMap{}
+	if err := decode(body, &svm); err != nil {
+		return err
+	}
+	m.Services = map[string]*VolumeMap{}
+	for k, v := range svm {
+		var err error
+		if m.Services[k], err = newVolumeMap(v); err != nil {
+			return err
+		}
+	}
+	m.Continue = header.Get(types.ContinueHeader)
+	return nil
+}
+
+func (m *VolumesByServiceRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.attachments = types.VolumeAttachmentsTypes(m.Attachments)
+	p.filter = m.Filter
+	p.limit = int(m.Limit)
+	p.continueTok = m.Continue
+	return nil, nil
+}
+
+func (m *VolumesByServiceRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.Attachments = int32(p.attachments)
+	m.Filter = p.filter
+	m.Limit = int32(p.limit)
+	m.Continue = p.continueTok
+	return nil
+}
+
+func (m *VolumesByServiceReply) toHTTP(
+	header http.Header) (interface{}, error) {
+
+	vm, err := (&VolumeMap{Volumes: m.Volumes}).model()
+	if err != nil {
+		return nil, err
+	}
+	setContinue(header, m.Continue)
+	return vm, nil
+}
+
+func (m *VolumesByServiceReply) fromHTTP(
+	header http.Header, body []byte) error {
+
+	vm := types.VolumeMap{}
+	if err := decode(body, &vm); err != nil {
+		return err
+	}
+	v, err := newVolumeMap(vm)
+	if err != nil {
+		return err
+	}
+	m.Volumes = v.Volumes
+	m.Continue = header.Get(types.ContinueHeader)
+	return nil
+}
+
+func (m *VolumeMap) toHTTP(header http.Header) (interface{}, error) {
+	return m.model()
+}
+
+func (m *VolumeMap) fromHTTP(header http.Header, body []byte) error {
+	vm := types.VolumeMap{}
+	if err := decode(body, &vm); err != nil {
+		return err
+	}
+	v, err := newVolumeMap(vm)
+	if err != nil {
+		return err
+	}
+	m.Volumes = v.Volumes
+	return nil
+}
+
+func (m *VolumeInspectRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	p.attachments = types.VolumeAttachmentsTypes(m.Attachments)
+	p.byName = m.ByName
+	return nil, nil
+}
+
+func (m *VolumeInspectRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.VolumeId = p.volumeID
+	m.Attachments = int32(p.attachments)
+	m.ByName = p.byName
+	return nil
+}
+
+func (m *Volume) toHTTP(header http.Header) (interface{}, error) {
+	return m.model()
+}
+
+func (m *Volume) fromHTTP(header http.Header, body []byte) error {
+	vol := &types.Volume{}
+	if err := decode(body, vol); err != nil {
+		return err
+	}
+	v, err := newVolume(vol)
+	if err != nil {
+		return err
+	}
+	*m = *v
+	return nil
+}
+
+func (m *VolumeCreateRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	return &types.VolumeCreateRequest{
+		Name:             m.Name,
+		AvailabilityZone: stringPtr(m.AvailabilityZone),
+		Encrypted:        boolPtr(m.Encrypted),
+		EncryptionKey:    stringPtr(m.EncryptionKey),
+		IOPS:             int64Ptr(m.Iops),
+		Size:             int64Ptr(m.Size),
+		Type:             stringPtr(m.Type),
+		Labels:           m.Labels,
+		Opts:             structMap(m.Opts),
+	}, nil
+}
+
+func (m *VolumeCreateRequest) fromHTTP(p *params, body []byte) error {
+	req := &types.VolumeCreateRequest{}
+	if err := decode(body, req); err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeCreateRequest{
+		Service:          p.service,
+		Name:             req.Name,
+		AvailabilityZone: newStringValue(req.AvailabilityZone),
+		Encrypted:        newBoolValue(req.Encrypted),
+		EncryptionKey:    newStringValue(req.EncryptionKey),
+		Iops:             newInt64Value(req.IOPS),
+		Size:             newInt64Value(req.Size),
+		Type:             newStringValue(req.Type),
+		Labels:           req.Labels,
+		Opts:             opts,
+	}
+	return nil
+}
+
+func (m *VolumeCreateFromSnapshotRequest) toHTTP(
+	p *params) (interface{}, error) {
+
+	p.service = m.Service
+	p.snapshotID = m.SnapshotId
+	return &types.VolumeCreateRequest{
+		Name:             m.Name,
+		AvailabilityZone: stringPtr(m.AvailabilityZone),
+		Encrypted:        boolPtr(m.Encrypted),
+		EncryptionKey:    stringPtr(m.EncryptionKey),
+		IOPS:             int64Ptr(m.Iops),
+		Size:             int64Ptr(m.Size),
+		Type:             stringPtr(m.Type),
+		Labels:           m.Labels,
+		Opts:             structMap(m.Opts),
+	}, nil
+}
+
+func (m *VolumeCreateFromSnapshotRequest) fromHTTP(
+	p *params, body []byte) error {
+
+	req := &VolumeCreateRequest{}
+	if err := req.fromHTTP(p, body); err != nil {
+		return err
+	}
+	*m = VolumeCreateFromSnapshotRequest{
+		Service:          p.service,
+		SnapshotId:       p.snapshotID,
+		Name:             req.Name,
+		AvailabilityZone: req.AvailabilityZone,
+		Encrypted:        req.Encrypted,
+		EncryptionKey:    req.EncryptionKey,
+		Iops:             req.Iops,
+		Size:             req.Size,
+		Type:             req.Type,
+		Labels:           req.Labels,
+		Opts:             req.Opts,
+	}
+	return nil
+}
+
+func (m *VolumeCopyRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	return &types.VolumeCopyRequest{
+		VolumeName: m.VolumeName,
+		Opts:       structMap(m.Opts),
+	}, nil
+}
+
+func (m *VolumeCopyRequest) fromHTTP(p *params, body []byte) error {
+	req := &types.VolumeCopyRequest{}
+	if err := decode(body, req); err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeCopyRequest{
+		Service:    p.service,
+		VolumeId:   p.volumeID,
+		VolumeName: req.VolumeName,
+		Opts:       opts,
+	}
+	return nil
+}
+
+func (m *VolumeRemoveRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	p.force = m.Force
+	return nil, nil
+}
+
+func (m *VolumeRemoveRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.VolumeId = p.volumeID
+	m.Force = p.force
+	return nil
+}
+
+func (m *VolumeRemoveReply) toHTTP(header http.Header) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *VolumeRemoveReply) fromHTTP(header http.Header, body []byte) error {
+	return nil
+}
+
+func (m *VolumeAttachRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	return &types.VolumeAttachRequest{
+		Force:          m.Force,
+		NextDeviceName: stringPtr(m.NextDeviceName),
+		ReadOnly:       m.ReadOnly,
+		Opts:           structMap(m.Opts),
+	}, nil
+}
+
+func (m *VolumeAttachRequest) fromHTTP(p *params, body []byte) error {
+	req := &types.VolumeAttachRequest{}
+	if err := decode(body, req); err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeAttachRequest{
+		Service:        p.service,
+		VolumeId:       p.volumeID,
+		Force:          req.Force,
+		NextDeviceName: newStringValue(req.NextDeviceName),
+		ReadOnly:       req.ReadOnly,
+		Opts:           opts,
+	}
+	return nil
+}
+
+func (m *VolumeAttachReply) toHTTP(header http.Header) (interface{}, error) {
+	vol, err := m.Volume.model()
+	if err != nil {
+		return nil, err
+	}
+	return &types.VolumeAttachResponse{
+		Volume:      vol,
+		AttachToken: m.AttachToken,
+	}, nil
+}
+
+func (m *VolumeAttachReply) fromHTTP(header http.Header, body []byte) error {
+	res := &types.VolumeAttachResponse{}
+	if err := decode(body, res); err != nil {
+		return err
+	}
+	vol, err := newVolume(res.Volume)
+	if err != nil {
+		return err
+	}
+	m.Volume = vol
+	m.AttachToken = res.AttachToken
+	return nil
+}
+
+// detachRequest returns the HTTP payload of the detach operations.
+func detachRequest(force bool, opts map[string]interface{}) interface{} {
+	return &types.VolumeDetachRequest{Force: force, Opts: opts}
+}
+
+// parseDetachRequest parses the HTTP payload of the detach operations.
+func parseDetachRequest(body []byte) (*types.VolumeDetachRequest, error) {
+	req := &types.VolumeDetachRequest{}
+	if err := decode(body, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (m *VolumeDetachRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	return detachRequest(m.Force, structMap(m.Opts)), nil
+}
+
+func (m *VolumeDetachRequest) fromHTTP(p *params, body []byte) error {
+	req, err := parseDetachRequest(body)
+	if err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeDetachRequest{
+		Service:  p.service,
+		VolumeId: p.volumeID,
+		Force:    req.Force,
+		Opts:     opts,
+	}
+	return nil
+}
+
+func (m *VolumeDetachAllRequest) toHTTP(p *params) (interface{}, error) {
+	return detachRequest(m.Force, structMap(m.Opts)), nil
+}
+
+func (m *VolumeDetachAllRequest) fromHTTP(p *params, body []byte) error {
+	req, err := parseDetachRequest(body)
+	if err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeDetachAllRequest{Force: req.Force, Opts: opts}
+	return nil
+}
+
+func (m *VolumeDetachAllForServiceRequest) toHTTP(
+	p *params) (interface{}, error) {
+
+	p.service = m.Service
+	return detachRequest(m.Force, structMap(m.Opts)), nil
+}
+
+func (m *VolumeDetachAllForServiceRequest) fromHTTP(
+	p *params, body []byte) error {
+
+	req, err := parseDetachRequest(body)
+	if err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeDetachAllForServiceRequest{
+		Service: p.service,
+		Force:   req.Force,
+		Opts:    opts,
+	}
+	return nil
+}
+
+func (m *VolumeResizeRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	return &types.VolumeResizeRequest{
+		Size:  m.Size,
+		Force: m.Force,
+		Opts:  structMap(m.Opts),
+	}, nil
+}
+
+func (m *VolumeResizeRequest) fromHTTP(p *params, body []byte) error {
+	req := &types.VolumeResizeRequest{}
+	if err := decode(body, req); err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeResizeRequest{
+		Service:  p.service,
+		VolumeId: p.volumeID,
+		Size:     req.Size,
+		Force:    req.Force,
+		Opts:     opts,
+	}
+	return nil
+}
+
+func (m *VolumeSnapshotRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.volumeID = m.VolumeId
+	return &types.VolumeSnapshotRequest{
+		SnapshotName: m.SnapshotName,
+		Labels:       m.Labels,
+		Opts:         structMap(m.Opts),
+	}, nil
+}
+
+func (m *VolumeSnapshotRequest) fromHTTP(p *params, body []byte) error {
+	req := &types.VolumeSnapshotRequest{}
+	if err := decode(body, req); err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = VolumeSnapshotRequest{
+		Service:      p.service,
+		VolumeId:     p.volumeID,
+		SnapshotName: req.SnapshotName,
+		Labels:       req.Labels,
+		Opts:         opts,
+	}
+	return nil
+}
+
+func (m *Snapshot) toHTTP(header http.Header) (interface{}, error) {
+	return m.model(), nil
+}
+
+func (m *Snapshot) fromHTTP(header http.Header, body []byte) error {
+	s := &types.Snapshot{}
+	if err := decode(body, s); err != nil {
+		return err
+	}
+	*m = *newSnapshot(s)
+	return nil
+}
+
+func (m *SnapshotsRequest) toHTTP(p *params) (interface{}, error) {
+	p.filter = m.Filter
+	p.limit = int(m.Limit)
+	p.continueTok = m.Continue
+	return nil, nil
+}
+
+func (m *SnapshotsRequest) fromHTTP(p *params, body []byte) error {
+	m.Filter = p.filter
+	m.Limit = int32(p.limit)
+	m.Continue = p.continueTok
+	return nil
+}
+
+func (m *SnapshotsReply) toHTTP(header http.Header) (interface{}, error) {
+	ssm := types.ServiceSnapshotMap{}
+	for k, v := range m.Services {
+		ssm[k] = v.model()
+	}
+	setContinue(header, m.Continue)
+	return ssm, nil
+}
+
+func (m *SnapshotsReply) fromHTTP(header http.Header, body []byte) error {
+	ssm := types.ServiceSnapshotMap{}
+	if err := decode(body, &ssm); err != nil {
+		return err
+	}
+	m.Services = map[string]*SnapshotMap{}
+	for k, v := range ssm {
+		m.Services[k] = newSnapshotMap(v)
+	}
+	m.Continue = header.Get(types.ContinueHeader)
+	return nil
+}
+
+func (m *SnapshotsByServiceRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.filter = m.Filter
+	p.limit = int(m.Limit)
+	p.continueTok = m.Continue
+	return nil, nil
+}
+
+func (m *SnapshotsByServiceRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.Filter = p.filter
+	m.Limit = int32(p.limit)
+	m.Continue = p.continueTok
+	return nil
+}
+
+func (m *SnapshotsByServiceReply) toHTTP(
+	header http.Header) (interface{}, error) {
+
+	setContinue(header, m.Continue)
+	return (&SnapshotMap{Snapshots: m.Snapshots}).model(), nil
+}
+
+func (m *SnapshotsByServiceReply) fromHTTP(
+	header http.Header, body []byte) error {
+
+	sm := types.SnapshotMap{}
+	if err := decode(body, &sm); err != nil {
+		return err
+	}
+	m.Snapshots = newSnapshotMap(sm).Snapshots
+	m.Continue = header.Get(types.ContinueHeader)
+	return nil
+}
+
+func (m *SnapshotInspectRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.snapshotID = m.SnapshotId
+	return nil, nil
+}
+
+func (m *SnapshotInspectRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.SnapshotId = p.snapshotID
+	return nil
+}
+
+func (m *SnapshotRemoveRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.snapshotID = m.SnapshotId
+	return nil, nil
+}
+
+func (m *SnapshotRemoveRequest) fromHTTP(p *params, body []byte) error {
+	m.Service = p.service
+	m.SnapshotId = p.snapshotID
+	return nil
+}
+
+func (m *SnapshotRemoveReply) toHTTP(
+	header http.Header) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *SnapshotRemoveReply) fromHTTP(
+	header http.Header, body []byte) error {
+	return nil
+}
+
+func (m *SnapshotCopyRequest) toHTTP(p *params) (interface{}, error) {
+	p.service = m.Service
+	p.snapshotID = m.SnapshotId
+	return &types.SnapshotCopyRequest{
+		SnapshotName:  m.SnapshotName,
+		DestinationID: m.DestinationId,
+		Opts:          structMap(m.Opts),
+	}, nil
+}
+
+func (m *SnapshotCopyRequest) fromHTTP(p *params, body []byte) error {
+	req := &types.SnapshotCopyRequest{}
+	if err := decode(body, req); err != nil {
+		return err
+	}
+	opts, err := newStruct(req.Opts)
+	if err != nil {
+		return err
+	}
+	*m = SnapshotCopyRequest{
+		Service:       p.service,
+		SnapshotId:    p.snapshotID,
+		SnapshotName:  req.SnapshotName,
+		DestinationId: req.DestinationID,
+		Opts:          opts,
+	}
+	return nil
+}
+
+func (m *TasksRequest) toHTTP(p *params) (interface{}, error) {
+	return nil, nil
+}
+
+func (m *TasksRequest) fromHTTP(p *params, body []byte) error {
+	return nil
+}
+
+func (m *TasksReply) toHTTP(header http.Header) (interface{}, error) {
+	tasks := map[string]*taskJSON{}
+	for k, v := range m.Tasks {
+		var err error
+		if tasks[strconv.Itoa(int(k))], err = v.model(); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+func (m *TasksReply) fromHTTP(header http.Header, body []byte) error {
+	tasks := map[string]*taskJSON{}
+	if err := decode(body, &tasks); err != nil {
+		return err
+	}
+	m.Tasks = map[int32]*Task{}
+	for k, v := range tasks {
+		id, err := strconv.Atoi(k)
+		if err != nil {
+			return goof.WithFieldE("taskID", k, "invalid task id", err)
+		}
+		if m.Tasks[int32(id)], err = newTask(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *TaskInspectRequest) toHTTP(p *params) (interface{}, error) {
+	p.taskID = int(m.Id)
+	return nil, nil
+}
+
+func (m *TaskInspectRequest) fromHTTP(p *params, body []byte) error {
+	m.Id = int32(p.taskID)
+	return nil
+}
+
+func (m *TaskCancelRequest) toHTTP(p *params) (interface{}, error) {
+	p.taskID = int(m.Id)
+	return nil, nil
+}
+
+func (m *TaskCancelRequest) fromHTTP(p *params, body []byte) error {
+	m.Id = int32(p.taskID)
+	return nil
+}
+
+func (m *Task) toHTTP(header http.Header) (interface{}, error) {
+	return m.model()
+}
+
+func (m *Task) fromHTTP(header http.Header, body []byte) error {
+	t := &taskJSON{Task: &types.Task{}}
+	if err := decode(body, t); err != nil {
+		return err
+	}
+	v, err := newTask(t)
+	if err != nil {
+		return err
+	}
+	*m = *v
+	return nil
+}
@@ -0,0 +1,346 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func TestNewRequest(t *testing.T) {
+	tests := []struct {
+		httpMethod, path, query string
+		method                  string
+		req                     Request
+	}{
+		{"GET", "/", "", "Root", &RootRequest{}},
+		{"GET", "/services/vfs", "instance", "ServiceInspect",
+			&ServiceInspectRequest{Service: "vfs", Instance: true}},
+		{"GET", "/volumes", "attachments=3&limit=10&continue=abc", "Volumes",
+			&VolumesRequest{
+				Attachments: int32(types.VolAttReqForInstance),
+				Limit:       10,
+				Continue:    "abc",
+			}},
+		{"POST", "/volumes", "detach", "VolumeDetachAll",
+			&VolumeDetachAllRequest{}},
+		{"POST", "/volumes/vfs", "", "VolumeCreate",
+			&VolumeCreateRequest{Service: "vfs"}},
+		{"POST", "/volumes/vfs", "detach&async",
+			"VolumeDetachAllForServiceAsync",
+			&VolumeDetachAllForServiceRequest{Service: "vfs"}},
+		{"GET", "/volumes/vfs/vfs-000", "byName", "VolumeInspect",
+			&VolumeInspectRequest{
+				Service: "vfs", VolumeId: "vfs-000", ByName: true}},
+		{"POST", "/volumes/vfs/vfs-000", "attach", "VolumeAttach",
+			&VolumeAttachRequest{Service: "vfs", VolumeId: "vfs-000"}},
+		{"DELETE", "/volumes/vfs/vfs-000", "force", "VolumeRemove",
+			&VolumeRemoveRequest{
+				Service: "vfs", VolumeId: "vfs-000", Force: true}},
+		{"POST", "/snapshots/vfs/vfs-000-000", "create",
+			"VolumeCreateFromSnapshot",
+			&VolumeCreateFromSnapshotRequest{
+				Service: "vfs", SnapshotId: "vfs-000-000"}},
+		{"GET", "/snapshots", "filter=(name=a)", "Snapshots",
+			&SnapshotsRequest{Filter: "(name=a)"}},
+		{"DELETE", "/tasks/7", "", "TaskCancel", &TaskCancelRequest{Id: 7}},
+	}
+
+	for _, tt := range tests {
+		m, req, err := NewRequest(tt.httpMethod, tt.path, tt.query, nil)
+		if !assert.NoError(t, err, tt.path) {
+			continue
+		}
+		assert.Equal(t, tt.method, m.Name(), tt.path)
+		assert.True(t, proto.Equal(tt.req, req), "%s: %v", tt.path, req)
+	}
+}
+
+func TestNewRequestPayload(t *testing.T) {
+	body := []byte(`{
+		"name": "a",
+		"size": 10,
+		"encrypted": false,
+		"labels": {"env": "test"},
+		"opts": {"count": 2, "tags": ["x"]}}`)
+	m, req, err := NewRequest("POST", "/volumes/vfs", "", body)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "VolumeCreate", m.Name())
+	cr := req.(*VolumeCreateRequest)
+	assert.Equal(t, "vfs", cr.Service)
+	assert.Equal(t, "a", cr.Name)
+	if assert.NotNil(t, cr.Size) {
+		assert.Equal(t, int64(10), cr.Size.Value)
+	}
+	if assert.NotNil(t, cr.Encrypted) {
+		assert.False(t, cr.Encrypted.Value)
+	}
+	assert.Nil(t, cr.Iops)
+	assert.Equal(t, "test", cr.Labels["env"])
+
+	// the request maps back to the same HTTP payload
+	httpMethod, u, body2, err := m.HTTPRequest(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", httpMethod)
+	assert.Equal(t, "/volumes/vfs", u.Path)
+	assert.JSONEq(t, string(body), string(body2))
+
+	_, _, err = NewRequest("POST", "/volumes/vfs", "", []byte("{"))
+	assert.Error(t, err)
+}
+
+func TestNewRequestErrors(t *testing.T) {
+	tests := []struct{ httpMethod, path, query string }{
+		{"HEAD", "/", ""},
+		{"GET", "/metrics", ""},
+		{"PUT", "/volumes/vfs", ""},
+		{"GET", "/tasks/abc", ""},
+		{"GET", "/volumes", "limit=ten"},
+		{"GET", "/volumes", "attachments=all"},
+		{"GET", "/volumes", "async"},
+	}
+	for _, tt := range tests {
+		_, _, err := NewRequest(tt.httpMethod, tt.path, tt.query, nil)
+		assert.Error(t, err, tt.path)
+	}
+}
+
+func TestHTTPRequest(t *testing.T) {
+	p := &params{
+		service:     "vfs",
+		volumeID:    "vfs 000",
+		snapshotID:  "vfs-000-000",
+		taskID:      3,
+		attachments: types.VolAttReq,
+		filter:      "(name=a b)",
+		limit:       5,
+		continueTok: "abc",
+		byName:      true,
+		instance:    true,
+		force:       true,
+	}
+
+	// every method maps to a route that maps back to the method
+	for _, m := range Methods() {
+		req := m.newRequest()
+		if !assert.NoError(t, req.fromHTTP(p, nil), m.Name()) {
+			continue
+		}
+		httpMethod, u, body, err := m.HTTPRequest(req)
+		if !assert.NoError(t, err, m.Name()) {
+			continue
+		}
+		m2, req2, err := NewRequest(httpMethod, u.Path, u.RawQuery, body)
+		if !assert.NoError(t, err, m.Name()) {
+			continue
+		}
+		assert.Equal(t, m.Name(), m2.Name())
+		assert.Equal(t, m.FullMethod(), m2.FullMethod())
+		assert.True(t, proto.Equal(req, req2), "%s: %v", m.Name(), req2)
+	}
+
+	m := LookupMethod("VolumeAttach")
+	httpMethod, u, _, err := m.HTTPRequest(
+		&VolumeAttachRequest{Service: "vfs", VolumeId: "vfs 000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", httpMethod)
+	assert.Equal(t, "/volumes/vfs/vfs 000", u.Path)
+	assert.Equal(t, "attach", u.RawQuery)
+
+	_, _, _, err = LookupMethod("VolumeInspect").HTTPRequest(
+		&VolumeInspectRequest{})
+	assert.Error(t, err)
+	assert.Nil(t, LookupMethod("Unknown"))
+	assert.Equal(t, "/libstorage.API/VolumeCreateAsync",
+		LookupMethod("VolumeCreateAsync").FullMethod())
+}
+
+func TestVolumeReply(t *testing.T) {
+	body := []byte(`{
+		"attachments": [{
+			"deviceName": "/dev/xvda",
+			"instanceID": {
+				"id": "i-000",
+				"driver": "vfs",
+				"service": "vfs",
+				"metadata": {"host": "a"}},
+			"status": "attached",
+			"volumeID": "vfs-000"}],
+		"attachmentState": 2,
+		"name": "a",
+		"size": 10,
+		"id": "vfs-000",
+		"type": "",
+		"labels": {"env": "test"}}`)
+
+	reply := &Volume{}
+	assert.NoError(t, DecodeReply(reply, http.Header{}, body))
+	assert.Equal(t, "vfs-000", reply.Id)
+	assert.Equal(t, int64(10), reply.Size)
+	assert.Equal(t, "i-000", reply.Attachments[0].InstanceId.Id)
+
+	// the reply survives the protobuf encoding and maps back to the same
+	// JSON object
+	buf, err := proto.Marshal(reply)
+	assert.NoError(t, err)
+	reply2 := &Volume{}
+	assert.NoError(t, proto.Unmarshal(buf, reply2))
+	body2, err := EncodeReply(reply2, http.Header{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(body), string(body2))
+
+	vol := &types.Volume{}
+	assert.NoError(t, json.Unmarshal(body2, vol))
+	assert.True(t, vol.Attachments[0].InstanceID.HasMetadata())
+}
+
+func TestPagedReply(t *testing.T) {
+	body := []byte(`{"vfs":{"vfs-000":{"name":"a","id":"vfs-000","type":""}}}`)
+	header := http.Header{}
+	header.Set(types.ContinueHeader, "abc")
+
+	reply := &VolumesReply{}
+	assert.NoError(t, DecodeReply(reply, header, body))
+	assert.Equal(t, "abc", reply.Continue)
+	assert.Equal(t, "a", reply.Services["vfs"].Volumes["vfs-000"].Name)
+
+	header2 := http.Header{}
+	body2, err := EncodeReply(reply, header2)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(body), string(body2))
+	assert.Equal(t, "abc", header2.Get(types.ContinueHeader))
+
+	header2 = http.Header{}
+	_, err = EncodeReply(&SnapshotsReply{}, header2)
+	assert.NoError(t, err)
+	assert.Empty(t, header2.Get(types.ContinueHeader))
+}
+
+func TestTaskReply(t *testing.T) {
+	body := []byte(`{
+		"id": 3,
+		"queueTime": 100,
+		"state": "error",
+		"result": {"name": "a", "size": 10},
+		"error": {"message": "boom"},
+		"locks": [{"key": "vfs-000", "state": "held"}]}`)
+
+	reply := &Task{}
+	assert.NoError(t, DecodeReply(reply, http.Header{}, body))
+	assert.Equal(t, int32(3), reply.Id)
+	assert.Equal(t, "held", reply.Locks[0].State)
+
+	body2, err := EncodeReply(reply, http.Header{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(body), string(body2))
+
+	tasks := &TasksReply{}
+	assert.NoError(t, DecodeReply(tasks, http.Header{},
+		[]byte(`{"3":{"id":3,"queueTime":100,"state":"running"}}`)))
+	assert.Equal(t, "running", tasks.Tasks[3].State)
+	assert.Error(t, DecodeReply(tasks, http.Header{},
+		[]byte(`{"x":{"id":3}}`)))
+}
+
+func TestServicesReply(t *testing.T) {
+	body := []byte(`{"vfs":{
+		"name": "vfs",
+		"instance": {
+			"instanceID": {"id": "i-000", "driver": "vfs", "service": "vfs"},
+			"providerName": "vfs"},
+		"driver": {
+			"name": "vfs",
+			"type": "nas",
+			"nextDevice": {"ignore": false, "prefix": "xvd", "pattern": "\\w"},
+			"capabilities": {"snapshots": true, "copy": false}}}}`)
+
+	reply := &ServicesReply{}
+	assert.NoError(t, DecodeReply(reply, http.Header{}, body))
+	si := reply.Services["vfs"]
+	assert.Equal(t, "i-000", si.Instance.InstanceId.Id)
+	assert.True(t, si.Driver.Capabilities["snapshots"])
+
+	body2, err := EncodeReply(reply, http.Header{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(body), string(body2))
+}
+
+func TestError(t *testing.T) {
+	body := []byte(`{"message":"not found","status":404}`)
+	err := NewError(http.StatusNotFound, body)
+	assert.Equal(t, codes.NotFound, grpc.Code(err))
+	httpStatus, body2, ok := HTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpStatus)
+	assert.Equal(t, body, body2)
+
+	err = NewError(http.StatusInternalServerError, []byte("boom\n"))
+	assert.Equal(t, codes.Internal, grpc.Code(err))
+	httpStatus, body2, ok = HTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, httpStatus)
+	assert.JSONEq(t, `{"message":"boom","status":500}`, string(body2))
+
+	_, _, ok = HTTPError(status.Error(codes.Unavailable, "transport closing"))
+	assert.False(t, ok)
+
+	for _, s := range []int{400, 401, 403, 404, 409, 429, 501, 503} {
+		assert.Equal(t, s, HTTPStatus(Code(s)))
+	}
+}
+
+// testServer implements the VolumeInspect method of the API.
+type testServer struct {
+	APIServer
+}
+
+func (s *testServer) VolumeInspect(
+	ctx context.Context, req *VolumeInspectRequest) (*Volume, error) {
+
+	if req.VolumeId == "missing" {
+		return nil, NewError(http.StatusNotFound, []byte(`{"status":404}`))
+	}
+	return &Volume{Id: req.VolumeId, Name: req.Service}, nil
+}
+
+func TestAPIServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	RegisterAPIServer(s, &testServer{})
+	go s.Serve(l)
+	defer s.Stop()
+
+	// the API is served with the standard protobuf codec
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := NewAPIClient(conn)
+
+	vol, err := client.VolumeInspect(context.Background(),
+		&VolumeInspectRequest{Service: "vfs", VolumeId: "vfs-000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "vfs-000", vol.Id)
+	assert.Equal(t, "vfs", vol.Name)
+
+	_, err = client.VolumeInspect(context.Background(),
+		&VolumeInspectRequest{Service: "vfs", VolumeId: "missing"})
+	httpStatus, _, ok := HTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpStatus)
+}
@@ -0,0 +1,547 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// newStruct returns the Struct for a map of options. The map's values are
+// normalized to their JSON representation so the Struct holds the same
+// values the server receives from the HTTP API.
+func newStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	var v map[string]interface{}
+	if err := normalize(m, &v); err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for k, fv := range v {
+		s.Fields[k] = newValue(fv)
+	}
+	return s, nil
+}
+
+// structMap returns the map of options for a Struct.
+func structMap(s *structpb.Struct) map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+	m := map[string]interface{}{}
+	for k, v := range s.Fields {
+		m[k] = valueOf(v)
+	}
+	return m
+}
+
+// newRawValue returns the Value for a JSON document.
+func newRawValue(buf json.RawMessage) (*structpb.Value, error) {
+	if len(buf) == 0 || string(buf) == "null" {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return nil, err
+	}
+	return newValue(v), nil
+}
+
+// rawValue returns the JSON document for a Value.
+func rawValue(v *structpb.Value) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(valueOf(v))
+}
+
+// normalize decodes the JSON encoding of v into dst.
+func normalize(v, dst interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, dst)
+}
+
+// newValue returns the Value for a JSON-decoded value.
+func newValue(v interface{}) *structpb.Value {
+	switch tv := v.(type) {
+	case nil:
+		return &structpb.Value{
+			Kind: &structpb.Value_NullValue{
+				NullValue: structpb.NullValue_NULL_VALUE}}
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: tv}}
+	case float64:
+		return &structpb.Value{
+			Kind: &structpb.Value_NumberValue{NumberValue: tv}}
+	case string:
+		return &structpb.Value{
+			Kind: &structpb.Value_StringValue{StringValue: tv}}
+	case []interface{}:
+		l := &structpb.ListValue{Values: make([]*structpb.Value, len(tv))}
+		for i, lv := range tv {
+			l.Values[i] = newValue(lv)
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: l}}
+	case map[string]interface{}:
+		s := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		for k, fv := range tv {
+			s.Fields[k] = newValue(fv)
+		}
+		return &structpb.Value{
+			Kind: &structpb.Value_StructValue{StructValue: s}}
+	}
+	var nv interface{}
+	if err := normalize(v, &nv); err != nil {
+		return newValue(nil)
+	}
+	return newValue(nv)
+}
+
+// valueOf returns the JSON-decoded value for a Value.
+func valueOf(v *structpb.Value) interface{} {
+	switch kv := v.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		return kv.BoolValue
+	case *structpb.Value_NumberValue:
+		return kv.NumberValue
+	case *structpb.Value_StringValue:
+		return kv.StringValue
+	case *structpb.Value_ListValue:
+		l := make([]interface{}, len(kv.ListValue.GetValues()))
+		for i, lv := range kv.ListValue.GetValues() {
+			l[i] = valueOf(lv)
+		}
+		return l
+	case *structpb.Value_StructValue:
+		return structMap(kv.StructValue)
+	}
+	return nil
+}
+
+func newStringValue(v *string) *wrappers.StringValue {
+	if v == nil {
+		return nil
+	}
+	return &wrappers.StringValue{Value: *v}
+}
+
+func stringPtr(v *wrappers.StringValue) *string {
+	if v == nil {
+		return nil
+	}
+	return &v.Value
+}
+
+func newBoolValue(v *bool) *wrappers.BoolValue {
+	if v == nil {
+		return nil
+	}
+	return &wrappers.BoolValue{Value: *v}
+}
+
+func boolPtr(v *wrappers.BoolValue) *bool {
+	if v == nil {
+		return nil
+	}
+	return &v.Value
+}
+
+func newInt64Value(v *int64) *wrappers.Int64Value {
+	if v == nil {
+		return nil
+	}
+	return &wrappers.Int64Value{Value: *v}
+}
+
+func int64Ptr(v *wrappers.Int64Value) *int64 {
+	if v == nil {
+		return nil
+	}
+	return &v.Value
+}
+
+// newInstanceID returns the message for an instance ID. The instance ID is
+// converted through its JSON encoding, which is the only way to read and
+// write its metadata outside of the types package.
+func newInstanceID(iid *types.InstanceID) (*InstanceID, error) {
+	if iid == nil {
+		return nil, nil
+	}
+	buf, err := json.Marshal(iid)
+	if err != nil {
+		return nil, err
+	}
+	v := &struct {
+		ID       string            `json:"id"`
+		Driver   string            `json:"driver"`
+		Service  string            `json:"service"`
+		Fields   map[string]string `json:"fields"`
+		Metadata json.RawMessage   `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(buf, v); err != nil {
+		return nil, err
+	}
+	m := &InstanceID{
+		Id:      v.ID,
+		Driver:  v.Driver,
+		Service: v.Service,
+		Fields:  v.Fields,
+	}
+	if m.Metadata, err = newRawValue(v.Metadata); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *InstanceID) model() (*types.InstanceID, error) {
+	if m == nil {
+		return nil, nil
+	}
+	md, err := rawValue(m.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := json.Marshal(&struct {
+		ID       string            `json:"id"`
+		Driver   string            `json:"driver"`
+		Service  string            `json:"service"`
+		Fields   map[string]string `json:"fields,omitempty"`
+		Metadata json.RawMessage   `json:"metadata,omitempty"`
+	}{m.Id, m.Driver, m.Service, m.Fields, md})
+	if err != nil {
+		return nil, err
+	}
+	iid := &types.InstanceID{}
+	if err := json.Unmarshal(buf, iid); err != nil {
+		return nil, err
+	}
+	return iid, nil
+}
+
+func newInstance(i *types.Instance) (*Instance, error) {
+	if i == nil {
+		return nil, nil
+	}
+	iid, err := newInstanceID(i.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &Instance{
+		InstanceId:   iid,
+		Name:         i.Name,
+		ProviderName: i.ProviderName,
+		Region:       i.Region,
+		Fields:       i.Fields,
+	}, nil
+}
+
+func (m *Instance) model() (*types.Instance, error) {
+	if m == nil {
+		return nil, nil
+	}
+	iid, err := m.InstanceId.model()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Instance{
+		InstanceID:   iid,
+		Name:         m.Name,
+		ProviderName: m.ProviderName,
+		Region:       m.Region,
+		Fields:       m.Fields,
+	}, nil
+}
+
+func newDriverInfo(d *types.DriverInfo) *DriverInfo {
+	if d == nil {
+		return nil
+	}
+	m := &DriverInfo{Name: d.Name, Type: string(d.Type)}
+	if d.NextDevice != nil {
+		m.NextDevice = &NextDeviceInfo{
+			Ignore:  d.NextDevice.Ignore,
+			Prefix:  d.NextDevice.Prefix,
+			Pattern: d.NextDevice.Pattern,
+		}
+	}
+	if d.Capabilities != nil {
+		m.Capabilities = map[string]bool{}
+		for k, v := range d.Capabilities {
+			m.Capabilities[string(k)] = v
+		}
+	}
+	return m
+}
+
+func (m *DriverInfo) model() *types.DriverInfo {
+	if m == nil {
+		return nil
+	}
+	d := &types.DriverInfo{Name: m.Name, Type: types.StorageType(m.Type)}
+	if m.NextDevice != nil {
+		d.NextDevice = &types.NextDeviceInfo{
+			Ignore:  m.NextDevice.Ignore,
+			Prefix:  m.NextDevice.Prefix,
+			Pattern: m.NextDevice.Pattern,
+		}
+	}
+	if m.Capabilities != nil {
+		d.Capabilities = types.DriverCapabilities{}
+		for k, v := range m.Capabilities {
+			d.Capabilities[types.DriverCapability(k)] = v
+		}
+	}
+	return d
+}
+
+func newServiceInfo(si *types.ServiceInfo) (*ServiceInfo, error) {
+	if si == nil {
+		return nil, nil
+	}
+	i, err := newInstance(si.Instance)
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceInfo{
+		Name:     si.Name,
+		Instance: i,
+		Driver:   newDriverInfo(si.Driver),
+	}, nil
+}
+
+func (m *ServiceInfo) model() (*types.ServiceInfo, error) {
+	if m == nil {
+		return nil, nil
+	}
+	i, err := m.Instance.model()
+	if err != nil {
+		return nil, err
+	}
+	return &types.ServiceInfo{
+		Name:     m.Name,
+		Instance: i,
+		Driver:   m.Driver.model(),
+	}, nil
+}
+
+func newVolume(v *types.Volume) (*Volume, error) {
+	if v == nil {
+		return nil, nil
+	}
+	m := &Volume{
+		AttachmentState:  int32(v.AttachmentState),
+		AvailabilityZone: v.AvailabilityZone,
+		Encrypted:        v.Encrypted,
+		Iops:             v.IOPS,
+		Name:             v.Name,
+		NetworkName:      v.NetworkName,
+		Size:             v.Size,
+		Status:           v.Status,
+		Id:               v.ID,
+		Type:             v.Type,
+		Labels:           v.Labels,
+		Fields:           v.Fields,
+	}
+	for _, a := range v.Attachments {
+		if a == nil {
+			continue
+		}
+		iid, err := newInstanceID(a.InstanceID)
+		if err != nil {
+			return nil, err
+		}
+		m.Attachments = append(m.Attachments, &VolumeAttachment{
+			DeviceName: a.DeviceName,
+			MountPoint: a.MountPoint,
+			InstanceId: iid,
+			Status:     a.Status,
+			VolumeId:   a.VolumeID,
+			Fields:     a.Fields,
+		})
+	}
+	return m, nil
+}
+
+func (m *Volume) model() (*types.Volume, error) {
+	if m == nil {
+		return nil, nil
+	}
+	v := &types.Volume{
+		AttachmentState:  types.VolumeAttachmentStates(m.AttachmentState),
+		AvailabilityZone: m.AvailabilityZone,
+		Encrypted:        m.Encrypted,
+		IOPS:             m.Iops,
+		Name:             m.Name,
+		NetworkName:      m.NetworkName,
+		Size:             m.Size,
+		Status:           m.Status,
+		ID:               m.Id,
+		Type:             m.Type,
+		Labels:           m.Labels,
+		Fields:           m.Fields,
+	}
+	for _, a := range m.Attachments {
+		iid, err := a.InstanceId.model()
+		if err != nil {
+			return nil, err
+		}
+		v.Attachments = append(v.Attachments, &types.VolumeAttachment{
+			DeviceName: a.DeviceName,
+			MountPoint: a.MountPoint,
+			InstanceID: iid,
+			Status:     a.Status,
+			VolumeID:   a.VolumeId,
+			Fields:     a.Fields,
+		})
+	}
+	return v, nil
+}
+
+func newVolumeMap(vm types.VolumeMap) (*VolumeMap, error) {
+	m := &VolumeMap{Volumes: map[string]*Volume{}}
+	for k, v := range vm {
+		var err error
+		if m.Volumes[k], err = newVolume(v); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *VolumeMap) model() (types.VolumeMap, error) {
+	vm := types.VolumeMap{}
+	for k, v := range m.GetVolumes() {
+		var err error
+		if vm[k], err = v.model(); err != nil {
+			return nil, err
+		}
+	}
+	return vm, nil
+}
+
+func newSnapshot(s *types.Snapshot) *Snapshot {
+	if s == nil {
+		return nil
+	}
+	return &Snapshot{
+		Description: s.Description,
+		Name:        s.Name,
+		Encrypted:   s.Encrypted,
+		Id:          s.ID,
+		StartTime:   s.StartTime,
+		Status:      s.Status,
+		VolumeId:    s.VolumeID,
+		VolumeSize:  s.VolumeSize,
+		Labels:      s.Labels,
+		Fields:      s.Fields,
+	}
+}
+
+func (m *Snapshot) model() *types.Snapshot {
+	if m == nil {
+		return nil
+	}
+	return &types.Snapshot{
+		Description: m.Description,
+		Name:        m.Name,
+		Encrypted:   m.Encrypted,
+		ID:          m.Id,
+		StartTime:   m.StartTime,
+		Status:      m.Status,
+		VolumeID:    m.VolumeId,
+		VolumeSize:  m.VolumeSize,
+		Labels:      m.Labels,
+		Fields:      m.Fields,
+	}
+}
+
+func newSnapshotMap(sm types.SnapshotMap) *SnapshotMap {
+	m := &SnapshotMap{Snapshots: map[string]*Snapshot{}}
+	for k, v := range sm {
+		m.Snapshots[k] = newSnapshot(v)
+	}
+	return m
+}
+
+func (m *SnapshotMap) model() types.SnapshotMap {
+	sm := types.SnapshotMap{}
+	for k, v := range m.GetSnapshots() {
+		sm[k] = v.model()
+	}
+	return sm
+}
+
+// taskJSON is the JSON encoding of a task. The result and error are kept as
+// raw JSON since a task's result is any of the API's models and its error
+// is marshaled by the server as either a string or an object.
+type taskJSON struct {
+	*types.Task
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+func newTask(t *taskJSON) (*Task, error) {
+	if t == nil || t.Task == nil {
+		return nil, nil
+	}
+	m := &Task{
+		Id:           int32(t.ID),
+		User:         t.User,
+		CompleteTime: t.CompleteTime,
+		QueueTime:    t.QueueTime,
+		StartTime:    t.StartTime,
+		State:        string(t.State),
+	}
+	for _, l := range t.Locks {
+		if l == nil {
+			continue
+		}
+		m.Locks = append(m.Locks, &TaskLock{
+			Key:   l.Key,
+			State: string(l.State),
+		})
+	}
+	var err error
+	if m.Result, err = newRawValue(t.Result); err != nil {
+		return nil, err
+	}
+	if m.Error, err = newRawValue(t.Error); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Task) model() (*taskJSON, error) {
+	if m == nil {
+		return nil, nil
+	}
+	t := &taskJSON{Task: &types.Task{
+		ID:           int(m.Id),
+		User:         m.User,
+		CompleteTime: m.CompleteTime,
+		QueueTime:    m.QueueTime,
+		StartTime:    m.StartTime,
+		State:        types.TaskState(m.State),
+	}}
+	for _, l := range m.Locks {
+		t.Locks = append(t.Locks, &types.TaskLock{
+			Key:   l.Key,
+			State: types.TaskLockState(l.State),
+		})
+	}
+	var err error
+	if t.Result, err = rawValue(m.Result); err != nil {
+		return nil, err
+	}
+	if t.Error, err = rawValue(m.Error); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
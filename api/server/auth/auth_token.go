@@ -8,9 +8,11 @@ import (
 
 	jcrypto "github.com/SermoDigital/jose/crypto"
 	"github.com/SermoDigital/jose/jws"
+	"github.com/SermoDigital/jose/jwt"
 
 	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
 )
 
 var rxBearer = regexp.MustCompile(`Bearer (.+)`)
@@ -96,6 +98,12 @@ func validateAuthTokenAllowed(
 		}
 	}
 
+	// when there is no allow list but there are roles then access is
+	// determined by the permissions granted to the token
+	if len(config.Allow) == 0 && len(config.Roles) > 0 {
+		return nil
+	}
+
 	ctx.WithFields(logFields).Error("access not granted")
 	return &types.ErrSecTokInvalid{Denied: true}
 }
@@ -174,12 +182,16 @@ func ValidateAuthTokenWithJWT(
 		IssuedAt:  iat.UTC().Unix(),
 		Expires:   exp.UTC().Unix(),
 		NotBefore: nbf.UTC().Unix(),
+		Scope:     getClaimStrings(jwt.Claims(), "scope"),
+		Roles:     getClaimStrings(jwt.Claims(), "roles"),
 	}
 
 	lf["sub"] = tok.Subject
 	lf["iat"] = tok.IssuedAt
 	lf["exp"] = tok.Expires
 	lf["nbf"] = tok.NotBefore
+	lf["scope"] = tok.Scope
+	lf["roles"] = tok.Roles
 
 	if err := validateAuthTokenAllowed(ctx, config, lf, tok); err != nil {
 		return nil, err
@@ -189,6 +201,87 @@ func ValidateAuthTokenWithJWT(
 	return tok, nil
 }
 
+// getClaimStrings returns the values of a claim that is either a list of
+// strings or a single, space-delimited string such as the OAuth2 scope claim.
+func getClaimStrings(claims jwt.Claims, name string) []string {
+	switch tv := claims.Get(name).(type) {
+	case string:
+		return strings.Fields(tv)
+	case []string:
+		return tv
+	case []interface{}:
+		var vals []string
+		for _, v := range tv {
+			if s, ok := v.(string); ok {
+				vals = append(vals, s)
+			}
+		}
+		return vals
+	}
+	return nil
+}
+
+// ValidateAuthTokenPermission validates that the auth token is granted the
+// specified permission. A permission is granted when it is matched by one of
+// the permissions in the token's scope or one of the permissions mapped by
+// the auth configuration to one of the token's roles. No permissions are
+// enforced if the auth configuration does not define any roles.
+func ValidateAuthTokenPermission(
+	ctx types.Context,
+	config *types.AuthConfig,
+	tok *types.AuthToken,
+	perm string) error {
+
+	if config == nil || len(config.Roles) == 0 {
+		return nil
+	}
+
+	lf := map[string]interface{}{"permission": perm}
+	if tok == nil {
+		ctx.WithFields(lf).Error("permission denied; missing security token")
+		return utils.NewPermissionDeniedError("", perm)
+	}
+
+	lf["sub"] = tok.Subject
+	lf["scope"] = tok.Scope
+	lf["roles"] = tok.Roles
+
+	for _, v := range tok.Scope {
+		if permMatches(v, perm) {
+			ctx.WithFields(lf).Debug("permission granted by scope")
+			return nil
+		}
+	}
+
+	for _, r := range tok.Roles {
+		for _, v := range config.Roles[r] {
+			if permMatches(v, perm) {
+				lf["role"] = r
+				ctx.WithFields(lf).Debug("permission granted by role")
+				return nil
+			}
+		}
+	}
+
+	ctx.WithFields(lf).Error("permission denied")
+	return utils.NewPermissionDeniedError(tok.Subject, perm)
+}
+
+// permMatches returns a flag indicating whether or not the granted permission
+// matches the requested one. A granted permission matches if it is equal to
+// the requested permission, if it is "*", or if it is a wildcard for the
+// requested permission's resource, ex. "volumes:*".
+func permMatches(granted, requested string) bool {
+	if granted == types.AuthPermAll || strings.EqualFold(granted, requested) {
+		return true
+	}
+	if !strings.HasSuffix(granted, ":*") {
+		return false
+	}
+	return strings.HasPrefix(
+		strings.ToLower(requested), strings.ToLower(granted[:len(granted)-1]))
+}
+
 var signingMethods = []jcrypto.SigningMethod{
 	jcrypto.SigningMethodES256,
 	jcrypto.SigningMethodES384,
@@ -2,7 +2,10 @@ package auth
 
 import (
 	"testing"
+	"time"
 
+	jcrypto "github.com/SermoDigital/jose/crypto"
+	"github.com/SermoDigital/jose/jws"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/codedellemc/libstorage/api/context"
@@ -97,3 +100,80 @@ func TestValidateAuthToken_InvalidKey(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func newTestJWT(t *testing.T, claims jws.Claims) string {
+	now := time.Now()
+	jwt := jws.NewJWT(claims, jcrypto.SigningMethodHS256)
+	jwt.Claims().SetSubject("ci")
+	jwt.Claims().SetIssuedAt(now)
+	jwt.Claims().SetNotBefore(now)
+	jwt.Claims().SetExpiration(now.Add(time.Hour))
+	buf, err := jwt.Serialize([]byte(jwtKey))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return string(buf)
+}
+
+func TestValidateAuthToken_RolesWithoutAllowList(t *testing.T) {
+	sc := &types.AuthConfig{
+		Key:   []byte(jwtKey),
+		Alg:   jwtAlg,
+		Roles: map[string][]string{"ci": {types.AuthPermVolumesList}},
+	}
+	tok, err := ValidateAuthTokenWithJWT(
+		context.Background(), sc, newTestJWT(t, jws.Claims{
+			"scope": "volumes:inspect snapshots:list",
+			"roles": []string{"ci"},
+		}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NotNil(t, tok) {
+		t.FailNow()
+	}
+	assert.Equal(t, "ci", tok.Subject)
+	assert.Equal(t, []string{"volumes:inspect", "snapshots:list"}, tok.Scope)
+	assert.Equal(t, []string{"ci"}, tok.Roles)
+}
+
+func TestValidateAuthTokenPermission(t *testing.T) {
+	sc := &types.AuthConfig{
+		Roles: map[string][]string{
+			"ci":       {types.AuthPermVolumesList, "snapshots:*"},
+			"operator": {types.AuthPermAll},
+		},
+	}
+	ctx := context.Background()
+
+	tok := &types.AuthToken{Subject: "ci", Roles: []string{"ci"}}
+	assert.NoError(t, ValidateAuthTokenPermission(
+		ctx, sc, tok, types.AuthPermVolumesList))
+	assert.NoError(t, ValidateAuthTokenPermission(
+		ctx, sc, tok, types.AuthPermSnapshotsRemove))
+	err := ValidateAuthTokenPermission(
+		ctx, sc, tok, types.AuthPermVolumesRemove)
+	if assert.Error(t, err) {
+		assert.IsType(t, &types.ErrPermissionDenied{}, err)
+	}
+
+	tok = &types.AuthToken{
+		Subject: "ci",
+		Scope:   []string{types.AuthPermVolumesRemove},
+	}
+	assert.NoError(t, ValidateAuthTokenPermission(
+		ctx, sc, tok, types.AuthPermVolumesRemove))
+	assert.Error(t, ValidateAuthTokenPermission(
+		ctx, sc, tok, types.AuthPermVolumesList))
+
+	tok = &types.AuthToken{Subject: "admin", Roles: []string{"operator"}}
+	assert.NoError(t, ValidateAuthTokenPermission(
+		ctx, sc, tok, types.AuthPermVolumesRemove))
+
+	assert.Error(t, ValidateAuthTokenPermission(
+		ctx, sc, nil, types.AuthPermVolumesList))
+
+	// permissions are not enforced without roles
+	assert.NoError(t, ValidateAuthTokenPermission(
+		ctx, &types.AuthConfig{}, nil, types.AuthPermVolumesRemove))
+}
@@ -0,0 +1,3 @@
+// Package eventsink provides the EventSink implementations to which the
+// server writes the events emitted by the volume and snapshot routes.
+package eventsink
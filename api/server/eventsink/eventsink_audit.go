@@ -0,0 +1,117 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"sync"
+
+	gofig "github.com/akutz/gofig/types"
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// AuditSinkName is the name of the audit log event sink.
+	AuditSinkName = "audit"
+
+	auditSinkDefaultFileName = "audit.log"
+)
+
+func init() {
+	registry.RegisterEventSink(AuditSinkName, newAuditSink)
+}
+
+// auditSink is an event sink that appends each event to a file as a line of
+// JSON. The file is only ever appended to, and each event is synced to disk
+// before the next event is written.
+type auditSink struct {
+	sync.Mutex
+	filePath string
+	file     *os.File
+}
+
+func newAuditSink() types.EventSink {
+	return &auditSink{}
+}
+
+func (s *auditSink) Name() string {
+	return AuditSinkName
+}
+
+func (s *auditSink) Init(ctx types.Context, config gofig.Config) error {
+	s.filePath = config.GetString(types.ConfigServerEventsAuditFile)
+	if s.filePath == "" {
+		pathConfig, ok := context.PathConfig(ctx)
+		if !ok {
+			return goof.WithField(
+				"configKey", types.ConfigServerEventsAuditFile,
+				"audit file path required")
+		}
+		s.filePath = path.Join(pathConfig.Log, auditSinkDefaultFileName)
+	}
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	ctx.WithField("filePath", s.filePath).Debug("initialized audit event sink")
+	return nil
+}
+
+// open opens the audit log for appending, creating it if it does not exist.
+func (s *auditSink) open() error {
+	if err := os.MkdirAll(path.Dir(s.filePath), 0755); err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error creating audit log dir", err)
+	}
+
+	f, err := os.OpenFile(
+		s.filePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error opening audit log", err)
+	}
+	s.file = f
+	return nil
+}
+
+func (s *auditSink) Emit(ctx types.Context, event *types.Event) error {
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return goof.WithError("error encoding event", err)
+	}
+	buf = append(buf, '\n')
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.file == nil {
+		return goof.WithField(
+			"filePath", s.filePath, "audit log closed")
+	}
+	if _, err := s.file.Write(buf); err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error writing audit log", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error syncing audit log", err)
+	}
+	return nil
+}
+
+// Close closes the audit log.
+func (s *auditSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
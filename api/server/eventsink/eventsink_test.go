@@ -0,0 +1,134 @@
+package eventsink
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func TestAuditSink(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "eventsink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filePath := path.Join(dir, "log", "audit.log")
+
+	s := &auditSink{filePath: filePath}
+	if err := s.open(); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, s.Emit(ctx, &types.Event{
+		Operation: types.EventVolumeCreate,
+		VolumeID:  "vol-000",
+	}))
+	s.file.Close()
+
+	// a reopened audit log should be appended to rather than truncated
+	s = &auditSink{filePath: filePath}
+	if err := s.open(); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, s.Emit(ctx, &types.Event{
+		Operation: types.EventVolumeRemove,
+		VolumeID:  "vol-000",
+		Subject:   "akutz",
+	}))
+	s.file.Close()
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var events []*types.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := &types.Event{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	if !assert.Len(t, events, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, types.EventVolumeCreate, events[0].Operation)
+	assert.Equal(t, types.EventVolumeRemove, events[1].Operation)
+	assert.Equal(t, "akutz", events[1].Subject)
+}
+
+func TestWebhookSinkRetry(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		attempts int32
+		received = &types.Event{}
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			json.NewDecoder(req.Body).Decode(received)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	defer srv.Close()
+
+	s := &webhookSink{
+		url:        srv.URL,
+		client:     &http.Client{Timeout: time.Second},
+		retries:    3,
+		retryDelay: time.Millisecond,
+	}
+
+	assert.NoError(t, s.Emit(ctx, &types.Event{
+		Operation: types.EventVolumeAttach,
+		VolumeID:  "vol-000",
+	}))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+	assert.Equal(t, types.EventVolumeAttach, received.Operation)
+	assert.Equal(t, "vol-000", received.VolumeID)
+}
+
+func TestWebhookSinkRetriesExhausted(t *testing.T) {
+	ctx := context.Background()
+
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+	defer srv.Close()
+
+	s := &webhookSink{
+		url:        srv.URL,
+		client:     &http.Client{Timeout: time.Second},
+		retries:    2,
+		retryDelay: time.Millisecond,
+	}
+
+	assert.Error(t, s.Emit(ctx, &types.Event{
+		Operation: types.EventVolumeDetach,
+	}))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+}
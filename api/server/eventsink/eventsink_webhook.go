@@ -0,0 +1,114 @@
+package eventsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	gofig "github.com/akutz/gofig/types"
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// WebhookSinkName is the name of the webhook event sink.
+	WebhookSinkName = "webhook"
+)
+
+func init() {
+	registry.RegisterEventSink(WebhookSinkName, newWebhookSink)
+}
+
+// webhookSink is an event sink that POSTs each event as JSON to a URL. A
+// failed request is retried, waiting twice as long before each retry as
+// before the previous one.
+type webhookSink struct {
+	url        string
+	client     *http.Client
+	retries    int
+	retryDelay time.Duration
+}
+
+func newWebhookSink() types.EventSink {
+	return &webhookSink{}
+}
+
+func (s *webhookSink) Name() string {
+	return WebhookSinkName
+}
+
+func (s *webhookSink) Init(ctx types.Context, config gofig.Config) error {
+	s.url = config.GetString(types.ConfigServerEventsWebhookURL)
+	if s.url == "" {
+		return goof.WithField(
+			"configKey", types.ConfigServerEventsWebhookURL,
+			"webhook url required")
+	}
+
+	timeout, err := time.ParseDuration(
+		config.GetString(types.ConfigServerEventsWebhookTimeout))
+	if err != nil {
+		timeout = time.Duration(time.Second * 10)
+	}
+	s.client = &http.Client{Timeout: timeout}
+
+	s.retries = config.GetInt(types.ConfigServerEventsWebhookRetries)
+	if s.retries < 0 {
+		s.retries = 0
+	}
+
+	s.retryDelay, err = time.ParseDuration(
+		config.GetString(types.ConfigServerEventsWebhookRetryDelay))
+	if err != nil {
+		s.retryDelay = time.Duration(time.Second * 1)
+	}
+
+	ctx.WithField("url", s.url).Debug("initialized webhook event sink")
+	return nil
+}
+
+func (s *webhookSink) Emit(ctx types.Context, event *types.Event) error {
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return goof.WithError("error encoding event", err)
+	}
+
+	delay := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		if err = s.post(buf); err == nil {
+			return nil
+		}
+		if attempt >= s.retries {
+			break
+		}
+		ctx.WithError(err).WithField("attempt", attempt+1).Warn(
+			"error posting event to webhook; retrying")
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return goof.WithFieldE("url", s.url, "error posting event", err)
+		}
+		delay = delay * 2
+	}
+
+	return goof.WithFieldE("url", s.url, "error posting event", err)
+}
+
+func (s *webhookSink) post(buf []byte) error {
+	res, err := s.client.Post(s.url, "application/json", bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/server/auth"
 	"github.com/codedellemc/libstorage/api/server/services"
 	"github.com/codedellemc/libstorage/api/types"
@@ -33,17 +34,21 @@ func (h *authAllSvcsHandler) Handle(
 	req *http.Request,
 	store types.Store) error {
 
+	var tok *types.AuthToken
 	for svc := range services.StorageServices(ctx) {
 		if svc.AuthConfig() == nil {
 			ctx.WithField("service", svc.Name()).Debug(
 				"skipping service auth handler; empty auth config")
 			continue
 		}
-		if len(svc.AuthConfig().Allow) == 0 && len(svc.AuthConfig().Deny) == 0 {
-			ctx.Debug("skipping svc auth handler; empty allow & deny lists")
+		if len(svc.AuthConfig().Allow) == 0 &&
+			len(svc.AuthConfig().Deny) == 0 &&
+			len(svc.AuthConfig().Roles) == 0 {
+			ctx.Debug("skipping svc auth handler; empty allow, deny & roles")
 			continue
 		}
-		_, err := auth.ValidateAuthTokenWithCtxOrReq(
+		var err error
+		tok, err = auth.ValidateAuthTokenWithCtxOrReq(
 			ctx, svc.AuthConfig(), req)
 		if err != nil {
 			return err
@@ -52,5 +57,11 @@ func (h *authAllSvcsHandler) Handle(
 
 	ctx.Debug("validated all services access")
 
+	// the token is stored in the context so that subsequent handlers, such
+	// as the rate limit handler, know the request's subject
+	if tok != nil {
+		ctx = ctx.WithValue(context.AuthTokenKey, tok)
+	}
+
 	return h.handler(ctx, w, req, store)
 }
@@ -40,8 +40,10 @@ func (h *authGlobalHandler) Handle(
 		return h.handler(ctx, w, req, store)
 	}
 
-	if len(h.config.Allow) == 0 && len(h.config.Deny) == 0 {
-		ctx.Debug("skipping global auth handler; empty allow & deny lists")
+	if len(h.config.Allow) == 0 &&
+		len(h.config.Deny) == 0 &&
+		len(h.config.Roles) == 0 {
+		ctx.Debug("skipping global auth handler; empty allow, deny & roles")
 		return h.handler(ctx, w, req, store)
 	}
 
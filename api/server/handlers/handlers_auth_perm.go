@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/server/auth"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// authPermHandler is an HTTP filter for validating that the JWT is granted
+// the permission required by a route.
+type authPermHandler struct {
+	handler types.APIFunc
+	perm    string
+}
+
+// NewAuthPermHandler returns a new authPermHandler. The handler validates the
+// permission against the storage service in the request's context or, if
+// there is no such service, against all of the storage services.
+func NewAuthPermHandler(perm string) types.Middleware {
+	return &authPermHandler{perm: perm}
+}
+
+func (h *authPermHandler) Name() string {
+	return "auth-perm-handler"
+}
+
+func (h *authPermHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&authPermHandler{m, h.perm}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *authPermHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if svc, ok := context.Service(ctx); ok {
+		if err := h.validate(ctx, svc, req); err != nil {
+			return err
+		}
+	} else {
+		for svc := range services.StorageServices(ctx) {
+			if err := h.validate(ctx, svc, req); err != nil {
+				return err
+			}
+		}
+	}
+
+	ctx.WithField("permission", h.perm).Debug("validated permission")
+
+	return h.handler(ctx, w, req, store)
+}
+
+func (h *authPermHandler) validate(
+	ctx types.Context,
+	svc types.StorageService,
+	req *http.Request) error {
+
+	config := svc.AuthConfig()
+	if config == nil || len(config.Roles) == 0 {
+		ctx.WithField("service", svc.Name()).Debug(
+			"skipping auth perm handler; no roles")
+		return nil
+	}
+
+	tok, err := auth.ValidateAuthTokenWithCtxOrReq(ctx, config, req)
+	if err != nil {
+		return err
+	}
+
+	return auth.ValidateAuthTokenPermission(ctx, config, tok, h.perm)
+}
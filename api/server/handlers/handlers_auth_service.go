@@ -43,8 +43,10 @@ func (h *authSvcHandler) Handle(
 		return h.handler(ctx, w, req, store)
 	}
 
-	if len(svc.AuthConfig().Allow) == 0 && len(svc.AuthConfig().Deny) == 0 {
-		ctx.Debug("skipping svc auth handler; empty allow & deny lists")
+	if len(svc.AuthConfig().Allow) == 0 &&
+		len(svc.AuthConfig().Deny) == 0 &&
+		len(svc.AuthConfig().Roles) == 0 {
+		ctx.Debug("skipping svc auth handler; empty allow, deny & roles")
 		return h.handler(ctx, w, req, store)
 	}
 
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// capabilityHandler is an HTTP filter for rejecting requests for operations
+// that require a capability the service's driver does not support.
+type capabilityHandler struct {
+	handler types.APIFunc
+	caps    []types.DriverCapability
+}
+
+// NewCapabilityHandler returns a new capabilityHandler. A request is
+// rejected only if the driver is known not to support one of the
+// capabilities.
+func NewCapabilityHandler(caps ...types.DriverCapability) types.Middleware {
+	return &capabilityHandler{caps: caps}
+}
+
+func (h *capabilityHandler) Name() string {
+	return "capability-handler"
+}
+
+func (h *capabilityHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&capabilityHandler{m, h.caps}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *capabilityHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	svc, ok := context.Service(ctx)
+	if !ok {
+		return h.handler(ctx, w, req, store)
+	}
+	if err := services.RequireCapabilities(ctx, svc, h.caps...); err != nil {
+		return err
+	}
+	return h.handler(ctx, w, req, store)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gofigCore "github.com/akutz/gofig"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+const capabilitiesTestDriverName = "capabilitiestest"
+
+// capabilitiesTestDriver is a storage driver that supports only snapshots.
+type capabilitiesTestDriver struct {
+	types.StorageDriver
+}
+
+func (d *capabilitiesTestDriver) Name() string {
+	return capabilitiesTestDriverName
+}
+
+func (d *capabilitiesTestDriver) Init(
+	ctx types.Context, config gofig.Config) error {
+	return nil
+}
+
+func (d *capabilitiesTestDriver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(types.DriverCapabilitySnapshots), nil
+}
+
+func init() {
+	registry.RegisterStorageDriver(capabilitiesTestDriverName,
+		func() types.StorageDriver { return &capabilitiesTestDriver{} })
+}
+
+func capabilitiesTestDo(
+	t *testing.T,
+	caps ...types.DriverCapability) (*httptest.ResponseRecorder, bool) {
+
+	config := gofigCore.New()
+	config.Set(types.ConfigServices, map[string]interface{}{
+		"s0": map[string]interface{}{"driver": capabilitiesTestDriverName},
+	})
+	ctx := context.Background().WithValue(context.ServerKey, t.Name())
+	if err := services.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	ctx = ctx.WithValue(
+		context.ServiceKey, services.GetStorageService(ctx, "s0"))
+
+	var called bool
+	h := NewErrorHandler().Handler(NewCapabilityHandler(caps...).Handler(
+		func(types.Context,
+			http.ResponseWriter, *http.Request, types.Store) error {
+			called = true
+			return nil
+		}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/volumes/s0/vol-000", nil)
+	assert.NoError(t, h(ctx, w, req, utils.NewStore()))
+	return w, called
+}
+
+func TestCapabilityHandlerSupported(t *testing.T) {
+	w, called := capabilitiesTestDo(t, types.DriverCapabilitySnapshots)
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCapabilityHandlerUnsupported(t *testing.T) {
+	w, called := capabilitiesTestDo(t,
+		types.DriverCapabilitySnapshots, types.DriverCapabilityCopy)
+	assert.False(t, called)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+
+	// the error names the unsupported capability
+	var body struct {
+		Error map[string]interface{} `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "copy", body.Error["capability"])
+	assert.Equal(t, "s0", body.Error["service"])
+}
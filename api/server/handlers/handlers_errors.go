@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/goof"
@@ -45,6 +47,13 @@ func (h *errorHandler) Handle(
 	gerr := goof.Newe(err)
 	ctx.WithError(gerr).Error("error: api call failed")
 
+	// a client that is rate limited is told how long to wait before it sends
+	// the request again; the value is rounded up to whole seconds
+	if rerr, ok := err.(*types.ErrRateLimited); ok {
+		w.Header().Set("Retry-After", fmt.Sprintf("%d",
+			int64((rerr.RetryAfter+time.Second-1)/time.Second)))
+	}
+
 	httpErr := goof.NewHTTPError(gerr, getStatus(err))
 	if isLogAPICallErrJSON(ctx) {
 		buf, err := json.Marshal(httpErr)
@@ -64,15 +73,31 @@ func getStatus(err error) int {
 	if err == types.ErrMissingStorageService {
 		return http.StatusInternalServerError
 	}
+	if err == types.ErrNotImplemented {
+		return http.StatusNotImplemented
+	}
 	switch err.(type) {
 	case *types.ErrBadAdminToken,
 		*types.ErrSecTokInvalid:
 		return http.StatusUnauthorized
+	case *types.ErrPermissionDenied:
+		return http.StatusForbidden
 	case *types.ErrNotFound:
 		return http.StatusNotFound
 	case *types.ErrMissingInstanceID,
-		*types.ErrMissingLocalDevices:
+		*types.ErrMissingLocalDevices,
+		*types.ErrBadFilter,
+		*types.ErrBadLabelSelector,
+		*types.ErrBadLimit,
+		*types.ErrBadContinueToken:
 		return http.StatusBadRequest
+	case *types.ErrIdempotencyKeyReused,
+		*types.ErrLockTimeout:
+		return http.StatusConflict
+	case *types.ErrRateLimited:
+		return http.StatusTooManyRequests
+	case *types.ErrUnsupportedCapability:
+		return http.StatusNotImplemented
 	default:
 		return http.StatusInternalServerError
 	}
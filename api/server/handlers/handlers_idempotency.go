@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+// idempotencyHandler is a global HTTP filter that responds to a request that
+// modifies state and that has an idempotency key the server has already seen
+// with the response to the original request instead of handling the request
+// again.
+type idempotencyHandler struct {
+	handler types.APIFunc
+	cache   *idempotencyCache
+}
+
+// NewIdempotencyHandler returns a new global HTTP filter that caches the
+// responses to requests with idempotency keys for the specified duration.
+func NewIdempotencyHandler(ttl time.Duration) types.Middleware {
+	return &idempotencyHandler{
+		cache: &idempotencyCache{
+			ttl:     ttl,
+			entries: map[string]*idempotencyEntry{},
+		},
+	}
+}
+
+func (h *idempotencyHandler) Name() string {
+	return "idempotency-handler"
+}
+
+func (h *idempotencyHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&idempotencyHandler{m, h.cache}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *idempotencyHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return h.handler(ctx, w, req, store)
+	}
+
+	key := req.Header.Get(types.IdempotencyKeyHeader)
+	if key == "" {
+		return h.handler(ctx, w, req, store)
+	}
+
+	// the body is read in order to fingerprint the request and then replaced
+	// so the remaining handlers may read it
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	// responses are cached per principal and service so the same key sent
+	// by different callers or to different services is not mistaken for the
+	// same request
+	var (
+		cacheKey = fmt.Sprintf("%s/%s/%s",
+			requestPrincipal(ctx, req), store.GetString("service"), key)
+		fingerprint = fingerprintRequest(req, body)
+	)
+
+	for {
+		entry, owner := h.cache.begin(cacheKey, fingerprint)
+		if entry.fingerprint != fingerprint {
+			return utils.NewIdempotencyKeyReusedErr(key)
+		}
+
+		if owner {
+			rec := newResponseRecorder()
+			err := h.handler(ctx, rec, req, store)
+			h.cache.end(cacheKey, entry, rec, err)
+			if err != nil {
+				return err
+			}
+			rec.replay(w, req)
+			return nil
+		}
+
+		// another request with the same key is in flight, so its response
+		// is awaited
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if rec := entry.rec; rec != nil {
+			ctx.WithField("idempotencyKey", key).Debug(
+				"replaying response for idempotency key")
+			rec.replay(w, req)
+			return nil
+		}
+
+		// the request in flight failed and its response was not cached, so
+		// this request is handled as if it were the first
+	}
+}
+
+// requestPrincipal returns the subject of the request's security token. If
+// the token has not been validated yet then a hash of the request's
+// authorization header is returned instead so that callers with different
+// credentials never share a response.
+func requestPrincipal(ctx types.Context, req *http.Request) string {
+	if tok, ok := context.AuthToken(ctx); ok && tok.Subject != "" {
+		return tok.Subject
+	}
+	auth := req.Header.Get("Authorization")
+	if auth == "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(auth)))
+}
+
+// fingerprintRequest returns a hash of the request's method, URL, and body.
+func fingerprintRequest(req *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", req.Method, req.URL.RequestURI())
+	h.Write(body)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+type idempotencyCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[string]*idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	fingerprint string
+	done        chan struct{}
+	rec         *responseRecorder
+	expires     time.Time
+}
+
+// begin returns the entry for the key. If there is no entry for the key then
+// a new entry is created and a flag is returned indicating the caller owns it
+// and must end it.
+func (c *idempotencyCache) begin(
+	key, fingerprint string) (*idempotencyEntry, bool) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	if e, ok := c.entries[key]; ok {
+		return e, false
+	}
+
+	e := &idempotencyEntry{
+		fingerprint: fingerprint,
+		done:        make(chan struct{}),
+	}
+	c.entries[key] = e
+	return e, true
+}
+
+// end completes the entry. Only successful responses are cached; otherwise
+// the entry is removed so the request may be handled again.
+func (c *idempotencyCache) end(
+	key string,
+	e *idempotencyEntry,
+	rec *responseRecorder,
+	err error) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	if code := rec.status(); err == nil && code >= 200 && code <= 299 {
+		e.rec = rec
+		e.expires = time.Now().Add(c.ttl)
+	} else {
+		delete(c.entries, key)
+	}
+	close(e.done)
+}
+
+// responseRecorder is an http.ResponseWriter that records a response so it
+// may be written to other clients.
+type responseRecorder struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: http.Header{}}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+// status returns the recorded status code. A handler that does not write a
+// status code responds with http.StatusOK.
+func (r *responseRecorder) status() int {
+	if r.code == 0 {
+		return http.StatusOK
+	}
+	return r.code
+}
+
+// replay writes the recorded response.
+func (r *responseRecorder) replay(w http.ResponseWriter, req *http.Request) {
+	for k, v := range r.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(r.status())
+	if req.Method != http.MethodHead {
+		w.Write(r.body.Bytes())
+	}
+}
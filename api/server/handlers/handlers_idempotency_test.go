@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+// newIdempotencyTestHandler returns a handler that creates a volume behind
+// the idempotency and error handlers, and the number of volumes created.
+func newIdempotencyTestHandler() (types.APIFunc, *int) {
+	n := 0
+	create := func(
+		ctx types.Context,
+		w http.ResponseWriter,
+		req *http.Request,
+		store types.Store) error {
+
+		n++
+		w.Header().Set("Location", fmt.Sprintf("/volumes/s0/vol-%03d", n))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":"vol-%03d"}`, n)
+		return nil
+	}
+	h := NewIdempotencyHandler(time.Minute).Handler(create)
+	return NewErrorHandler().Handler(h), &n
+}
+
+func idempotencyTestDo(
+	ctx types.Context,
+	h types.APIFunc,
+	key, body string) *httptest.ResponseRecorder {
+
+	req, _ := http.NewRequest(
+		http.MethodPost, "/volumes/s0", strings.NewReader(body))
+	req.Header.Set(types.IdempotencyKeyHeader, key)
+	store := utils.NewStore()
+	store.Set("service", "s0")
+	w := httptest.NewRecorder()
+	h(ctx, w, req, store)
+	return w
+}
+
+func TestIdempotencyHandlerReplay(t *testing.T) {
+	ctx := context.Background()
+	h, n := newIdempotencyTestHandler()
+
+	w1 := idempotencyTestDo(ctx, h, "key0", `{"name":"data"}`)
+	w2 := idempotencyTestDo(ctx, h, "key0", `{"name":"data"}`)
+
+	// the request that is sent again receives the original response and
+	// does not create another volume
+	assert.Equal(t, 1, *n)
+	assert.Equal(t, http.StatusCreated, w1.Code)
+	assert.Equal(t, http.StatusCreated, w2.Code)
+	assert.Equal(t, `{"id":"vol-001"}`, w2.Body.String())
+	assert.Equal(t, "/volumes/s0/vol-001", w2.Header().Get("Location"))
+
+	// a request with another key is handled
+	idempotencyTestDo(ctx, h, "key1", `{"name":"data"}`)
+	assert.Equal(t, 2, *n)
+}
+
+func TestIdempotencyHandlerKeyReused(t *testing.T) {
+	ctx := context.Background()
+	h, n := newIdempotencyTestHandler()
+
+	idempotencyTestDo(ctx, h, "key0", `{"name":"data"}`)
+	w := idempotencyTestDo(ctx, h, "key0", `{"name":"logs"}`)
+
+	assert.Equal(t, 1, *n)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotencyHandlerPrincipal(t *testing.T) {
+	h, n := newIdempotencyTestHandler()
+
+	for _, sub := range []string{"user1", "user2"} {
+		ctx := context.Background().WithValue(
+			context.AuthTokenKey, &types.AuthToken{Subject: sub})
+		w := idempotencyTestDo(ctx, h, "key0", `{"name":"data"}`)
+		assert.Equal(t, http.StatusCreated, w.Code, sub)
+	}
+
+	// a response is never returned to another caller
+	assert.Equal(t, 2, *n)
+}
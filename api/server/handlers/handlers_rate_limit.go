@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// rateLimitHandler is an HTTP filter for rejecting requests that exceed the
+// rate limits of the storage services they use.
+type rateLimitHandler struct {
+	handler types.APIFunc
+}
+
+// NewRateLimitHandler returns a new rateLimitHandler. Requests for a single
+// service are limited by that service's limits, while requests for all
+// services are limited by the limits of every service.
+func NewRateLimitHandler() types.Middleware {
+	return &rateLimitHandler{}
+}
+
+func (h *rateLimitHandler) Name() string {
+	return "rate-limit-handler"
+}
+
+func (h *rateLimitHandler) Handler(m types.APIFunc) types.APIFunc {
+	return (&rateLimitHandler{m}).Handle
+}
+
+// Handle is the type's Handler function.
+func (h *rateLimitHandler) Handle(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	if svc, ok := context.Service(ctx); ok {
+		if err := services.RateLimit(ctx, svc); err != nil {
+			return err
+		}
+		return h.handler(ctx, w, req, store)
+	}
+
+	// a request for all services is limited by every service, but it takes
+	// tokens only if every service allows it
+	var svcs []types.StorageService
+	for svc := range services.StorageServices(ctx) {
+		svcs = append(svcs, svc)
+	}
+	if err := services.RateLimit(ctx, svcs...); err != nil {
+		return err
+	}
+
+	return h.handler(ctx, w, req, store)
+}
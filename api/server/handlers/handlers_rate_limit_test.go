@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gofigCore "github.com/akutz/gofig"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+const rateLimitTestDriverName = "ratelimittest"
+
+type rateLimitTestDriver struct {
+	types.StorageDriver
+}
+
+func (d *rateLimitTestDriver) Name() string {
+	return rateLimitTestDriverName
+}
+
+func (d *rateLimitTestDriver) Init(
+	ctx types.Context, config gofig.Config) error {
+	return nil
+}
+
+func init() {
+	registry.RegisterStorageDriver(rateLimitTestDriverName,
+		func() types.StorageDriver { return &rateLimitTestDriver{} })
+}
+
+// newRateLimitTestServices initializes the services s0, which accepts two
+// requests at once, and s1, which accepts one request at once.
+func newRateLimitTestServices(t *testing.T) types.Context {
+	config := gofigCore.New()
+	config.Set(types.ConfigServices, map[string]interface{}{
+		"s0": map[string]interface{}{
+			"driver": rateLimitTestDriverName,
+			"limits": map[string]interface{}{"rate": 1, "burst": 2},
+		},
+		"s1": map[string]interface{}{
+			"driver": rateLimitTestDriverName,
+			"limits": map[string]interface{}{"rate": 1, "burst": 1},
+		},
+	})
+
+	ctx := context.Background().WithValue(context.ServerKey, t.Name())
+	if err := services.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+// rateLimitTestDo sends a request through the rate limit handler and
+// returns the number of requests that reached the handler it wraps.
+func rateLimitTestDo(
+	ctx types.Context, h types.APIFunc, n *int) error {
+
+	req, _ := http.NewRequest(http.MethodGet, "/volumes", nil)
+	err := h(ctx, httptest.NewRecorder(), req, utils.NewStore())
+	if err == nil {
+		*n++
+	}
+	return err
+}
+
+func assertRateLimited(t *testing.T, err error) {
+	if assert.Error(t, err) {
+		_, ok := err.(*types.ErrRateLimited)
+		assert.True(t, ok, "unexpected error: %v", err)
+	}
+}
+
+func TestRateLimitHandlerService(t *testing.T) {
+	ctx := newRateLimitTestServices(t)
+	ctx0 := ctx.WithValue(
+		context.ServiceKey, services.GetStorageService(ctx, "s0"))
+	ctx1 := ctx.WithValue(
+		context.ServiceKey, services.GetStorageService(ctx, "s1"))
+
+	var n int
+	h := NewRateLimitHandler().Handler(func(
+		types.Context, http.ResponseWriter, *http.Request, types.Store) error {
+		return nil
+	})
+
+	assert.NoError(t, rateLimitTestDo(ctx0, h, &n))
+	assert.NoError(t, rateLimitTestDo(ctx0, h, &n))
+	assertRateLimited(t, rateLimitTestDo(ctx0, h, &n))
+
+	// the services are limited separately
+	assert.NoError(t, rateLimitTestDo(ctx1, h, &n))
+	assertRateLimited(t, rateLimitTestDo(ctx1, h, &n))
+
+	assert.Equal(t, 3, n)
+}
+
+func TestRateLimitHandlerAllServices(t *testing.T) {
+	ctx := newRateLimitTestServices(t)
+	ctx0 := ctx.WithValue(
+		context.ServiceKey, services.GetStorageService(ctx, "s0"))
+
+	var n int
+	h := NewRateLimitHandler().Handler(func(
+		types.Context, http.ResponseWriter, *http.Request, types.Store) error {
+		return nil
+	})
+
+	// a request for all services takes a token from every service
+	assert.NoError(t, rateLimitTestDo(ctx, h, &n))
+
+	// s1 rejects the request, so it does not count against the limit of s0
+	assertRateLimited(t, rateLimitTestDo(ctx, h, &n))
+	assert.NoError(t, rateLimitTestDo(ctx0, h, &n))
+	assertRateLimited(t, rateLimitTestDo(ctx0, h, &n))
+
+	assert.Equal(t, 2, n)
+}
@@ -73,6 +73,10 @@ func WriteTask(
 		if task.Error != nil {
 			return task.Error
 		}
+		if pr, ok := task.Result.(*types.PagedResult); ok && pr.Continue != "" {
+			w.Header().Set(types.ContinueHeader, pr.Continue)
+		}
+		services.SetVolumeCacheHeaders(ctx, w.Header())
 		WriteJSON(w, okStatus, task.Result)
 	case <-exeTimeout.C:
 		WriteJSON(w, http.StatusRequestTimeout, task)
@@ -0,0 +1,16 @@
+// Package lockmgr provides the LockManager implementations used by the
+// server's task service to serialize the tasks that operate on the same
+// resource.
+//
+// The in-memory lock manager serializes the tasks of a single server. The
+// file lock manager uses advisory file locks in a directory, which serializes
+// the tasks of all the servers that share the directory. Other lock managers,
+// such as one backed by etcd, may be registered with
+// registry.RegisterLockManager.
+package lockmgr
+
+import "time"
+
+// pollInterval is how often a lock manager that cannot wait on a lock to
+// be released checks whether the lock is available.
+const pollInterval = 100 * time.Millisecond
@@ -0,0 +1,135 @@
+// +build !windows
+
+package lockmgr
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"sync"
+	"syscall"
+	"time"
+
+	gofig "github.com/akutz/gofig/types"
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// FileLockManagerName is the name of the file lock manager.
+	FileLockManagerName = "file"
+
+	fileLockManagerDefaultDirName = "locks"
+)
+
+func init() {
+	registry.RegisterLockManager(FileLockManagerName, newFileLockManager)
+}
+
+// fileLockManager is a lock manager that holds a lock by holding an
+// exclusive advisory lock on a file named after the lock's key. Servers
+// that share the lock directory, for example via a shared file system that
+// supports flock, share their locks.
+type fileLockManager struct {
+	lck   sync.Mutex
+	dir   string
+	files map[string]*fileLock
+}
+
+type fileLock struct {
+	holder string
+	file   *os.File
+}
+
+func newFileLockManager() types.LockManager {
+	return &fileLockManager{}
+}
+
+func (m *fileLockManager) Name() string {
+	return FileLockManagerName
+}
+
+func (m *fileLockManager) Init(ctx types.Context, config gofig.Config) error {
+	m.files = map[string]*fileLock{}
+
+	m.dir = config.GetString(types.ConfigServerLocksDir)
+	if m.dir == "" {
+		pathConfig, ok := context.PathConfig(ctx)
+		if !ok {
+			return goof.WithField(
+				"configKey", types.ConfigServerLocksDir,
+				"lock directory required")
+		}
+		m.dir = path.Join(pathConfig.Lib, fileLockManagerDefaultDirName)
+	}
+
+	ctx.WithField("dir", m.dir).Debug("initializing file lock manager")
+	return os.MkdirAll(m.dir, 0755)
+}
+
+func (m *fileLockManager) filePath(key string) string {
+	return path.Join(m.dir, url.QueryEscape(key)+".lock")
+}
+
+func (m *fileLockManager) Lock(ctx types.Context, key, holder string) error {
+	m.lck.Lock()
+	if l, ok := m.files[key]; ok && l.holder == holder {
+		m.lck.Unlock()
+		return nil
+	}
+	m.lck.Unlock()
+
+	f, err := os.OpenFile(m.filePath(key), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return goof.WithFieldE("key", key, "error opening lock file", err)
+	}
+
+	// the lock is polled for since a blocking flock cannot be interrupted
+	// when the context is done
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if err != syscall.EWOULDBLOCK && err != syscall.EINTR {
+			f.Close()
+			return goof.WithFieldE("key", key, "error locking file", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			f.Close()
+			return ctx.Err()
+		}
+	}
+
+	// the holder is written to the file to help identify the holder of a
+	// lock that is never released
+	f.Truncate(0)
+	f.WriteAt([]byte(holder+"\n"), 0)
+
+	m.lck.Lock()
+	defer m.lck.Unlock()
+	m.files[key] = &fileLock{holder: holder, file: f}
+	return nil
+}
+
+func (m *fileLockManager) Unlock(ctx types.Context, key, holder string) error {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+	l, ok := m.files[key]
+	if !ok || l.holder != holder {
+		return nil
+	}
+	delete(m.files, key)
+	defer l.file.Close()
+	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil {
+		return goof.WithFieldE("key", key, "error unlocking file", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// +build !windows
+
+package lockmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLockManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lockmgr")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	// two managers that share a directory act like two servers that share
+	// their locks
+	m1 := &fileLockManager{dir: dir, files: map[string]*fileLock{}}
+	m2 := &fileLockManager{dir: dir, files: map[string]*fileLock{}}
+	testLockManager(t, m1, m2)
+}
@@ -0,0 +1,80 @@
+package lockmgr
+
+import (
+	"sync"
+
+	gofig "github.com/akutz/gofig/types"
+
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// MemLockManagerName is the name of the in-memory lock manager.
+	MemLockManagerName = "mem"
+)
+
+func init() {
+	registry.RegisterLockManager(MemLockManagerName, newMemLockManager)
+}
+
+type memLockManager struct {
+	lck   sync.Mutex
+	locks map[string]*memLock
+}
+
+type memLock struct {
+	holder   string
+	released chan struct{}
+}
+
+func newMemLockManager() types.LockManager {
+	return &memLockManager{}
+}
+
+func (m *memLockManager) Name() string {
+	return MemLockManagerName
+}
+
+func (m *memLockManager) Init(ctx types.Context, config gofig.Config) error {
+	m.locks = map[string]*memLock{}
+	return nil
+}
+
+func (m *memLockManager) Lock(ctx types.Context, key, holder string) error {
+	for {
+		m.lck.Lock()
+		l, ok := m.locks[key]
+		if !ok {
+			m.locks[key] = &memLock{
+				holder:   holder,
+				released: make(chan struct{}),
+			}
+			m.lck.Unlock()
+			return nil
+		}
+		m.lck.Unlock()
+
+		if l.holder == holder {
+			return nil
+		}
+
+		select {
+		case <-l.released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *memLockManager) Unlock(ctx types.Context, key, holder string) error {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+	l, ok := m.locks[key]
+	if !ok || l.holder != holder {
+		return nil
+	}
+	delete(m.locks, key)
+	close(l.released)
+	return nil
+}
@@ -0,0 +1,61 @@
+package lockmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const testKey = "vfs/volumes/vfs-000"
+
+func TestMemLockManager(t *testing.T) {
+	m := &memLockManager{locks: map[string]*memLock{}}
+	testLockManager(t, m, m)
+}
+
+func testLockManager(t *testing.T, m1, m2 types.LockManager) {
+	ctx := context.Background()
+
+	assert.NoError(t, m1.Lock(ctx, testKey, "a"))
+
+	// the lock is reentrant for its holder
+	assert.NoError(t, m1.Lock(ctx, testKey, "a"))
+
+	// another holder times out waiting for the lock
+	tctx, cancel := context.WithTimeout(ctx, 3*pollInterval)
+	err := m2.Lock(tctx, testKey, "b")
+	cancel()
+	assert.Error(t, err)
+
+	// only the holder may release the lock
+	assert.NoError(t, m2.Unlock(ctx, testKey, "b"))
+	tctx, cancel = context.WithTimeout(ctx, 3*pollInterval)
+	err = m2.Lock(tctx, testKey, "b")
+	cancel()
+	assert.Error(t, err)
+
+	// another holder acquires the lock once it is released
+	lockedC := make(chan error, 1)
+	go func() {
+		lockedC <- m2.Lock(ctx, testKey, "b")
+	}()
+	select {
+	case err := <-lockedC:
+		t.Fatalf("lock acquired while held: %v", err)
+	case <-time.After(3 * pollInterval):
+	}
+
+	assert.NoError(t, m1.Unlock(ctx, testKey, "a"))
+	select {
+	case err := <-lockedC:
+		assert.NoError(t, err)
+	case <-time.After(10 * pollInterval):
+		t.Fatal("lock not acquired after it was released")
+	}
+
+	assert.NoError(t, m2.Unlock(ctx, testKey, "b"))
+}
@@ -0,0 +1,454 @@
+/*
+Package openapi generates OpenAPI 3 documents that describe the libStorage API
+from the routes registered with the server and the libStorage JSON schema.
+
+The payloads of an operation are described by the JSON schemas of the route's
+schema middleware, and the definitions of the libStorage JSON schema are the
+document's component schemas. Routes that share a method and path and are
+distinguished by a query parameter, such as the routes that attach and detach
+a volume, are described by a single operation. The operation's query
+parameters select the route, and its payloads are one of the routes'
+payloads.
+*/
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils/schema"
+)
+
+const (
+	// Version is the version of the OpenAPI specification to which the
+	// generated documents conform.
+	Version = "3.0.3"
+
+	// ContentType is the content type of the payloads described by the
+	// generated documents.
+	ContentType = "application/json"
+
+	definitionsRef = "#/definitions/"
+	componentsRef  = "#/components/schemas/"
+)
+
+var pathParamRx = regexp.MustCompile(`\{([^{}:]+)(?::[^{}]+)?\}`)
+
+// queryParams are the query parameters that are not part of a route's
+// definition, in the order in which they are described.
+var queryParams = []*Parameter{
+	{
+		Name:        "attachments",
+		Description: "The attachment information to return.",
+		Schema:      Schema{"type": "string"},
+	},
+	{
+		Name:        "fresh",
+		Description: "Bypass the volume cache.",
+		Schema:      Schema{"type": "boolean"},
+	},
+	{
+		Name:        "filter",
+		Description: "An LDAP filter that the returned objects must match.",
+		Schema:      Schema{"type": "string"},
+	},
+	{
+		Name:        "limit",
+		Description: "The maximum number of objects to return.",
+		Schema:      Schema{"type": "integer", "minimum": 0},
+	},
+	{
+		Name:        "continue",
+		Description: "The token that continues a previous listing.",
+		Schema:      Schema{"type": "string"},
+	},
+	{
+		Name:        "force",
+		Description: "Force the operation.",
+		Schema:      Schema{"type": "boolean"},
+	},
+	{
+		Name:        "async",
+		Description: "Respond with the operation's task without waiting.",
+		Schema:      Schema{"type": "boolean"},
+	},
+}
+
+// routeQueryParams are the names of the query parameters of each route.
+var routeQueryParams = map[string][]string{
+	"volumes": {
+		"attachments", "fresh", "filter", "limit", "continue", "async"},
+	"volumesForService": {
+		"attachments", "fresh", "filter", "limit", "continue", "async"},
+	"volumeInspect":           {"attachments", "async"},
+	"volumeCreate":            {"async"},
+	"volumeCopy":              {"async"},
+	"volumeSnapshot":          {"async"},
+	"volumeResize":            {"force", "async"},
+	"volumeAttach":            {"force", "async"},
+	"volumeDetach":            {"force", "async"},
+	"volumesDetachAll":        {"force", "async"},
+	"volumesDetachForService": {"force", "async"},
+	"volumeRemove":            {"force", "async"},
+	"snapshots":               {"filter", "limit", "continue", "async"},
+	"snapshotsForService":     {"filter", "limit", "continue", "async"},
+	"snapshotInspect":         {"async"},
+	"snapshotCreate":          {"async"},
+	"snapshotCopy":            {"async"},
+	"snapshotRemove":          {"async"},
+}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       *Info               `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components *Components         `json:"components,omitempty"`
+}
+
+// Info is the metadata of an OpenAPI document.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem is the operations available on a path, keyed by the lower-case
+// HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes a route.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a parameter of an operation.
+type Parameter struct {
+	Name            string `json:"name"`
+	In              string `json:"in"`
+	Description     string `json:"description,omitempty"`
+	Required        bool   `json:"required"`
+	AllowEmptyValue bool   `json:"allowEmptyValue,omitempty"`
+	Schema          Schema `json:"schema"`
+}
+
+// RequestBody describes the request payload of an operation.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes a payload.
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Schema is a schema object.
+type Schema map[string]interface{}
+
+// Components are the reusable objects of an OpenAPI document.
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+// New returns a new OpenAPI document that describes the routes of the
+// provided routers. The version is the version of the API.
+func New(version string, routers ...types.Router) (*Document, error) {
+
+	schemas, err := componentSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: &Info{
+			Title:   "libStorage",
+			Version: version,
+		},
+		Paths:      map[string]PathItem{},
+		Components: &Components{Schemas: schemas},
+	}
+
+	for _, router := range routers {
+		tag := strings.TrimSuffix(router.Name(), "-router")
+
+		// the routes that share a method and path are described by one
+		// operation
+		var (
+			keys   []string
+			groups = map[string][]types.Route{}
+		)
+		for _, route := range router.Routes() {
+			key := route.GetMethod() + " " + pathKey(route)
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], route)
+		}
+
+		for _, key := range keys {
+			routes := groups[key]
+			path := pathKey(routes[0])
+			method := strings.ToLower(routes[0].GetMethod())
+			item, ok := doc.Paths[path]
+			if !ok {
+				item = PathItem{}
+				doc.Paths[path] = item
+			}
+			if _, ok := item[method]; ok {
+				return nil, fmt.Errorf(
+					"openapi: duplicate route: %s %s", method, path)
+			}
+			op, err := newOperation(routes, tag, schemas)
+			if err != nil {
+				return nil, err
+			}
+			item[method] = op
+		}
+	}
+
+	return doc, nil
+}
+
+// Schemas returns the JSON schemas for the request and response payloads of
+// a route. The flag is false if the route has no schema middleware.
+func Schemas(route types.Route) (req, res []byte, ok bool) {
+	for _, m := range route.GetMiddlewares() {
+		if sm, isSM := m.(types.SchemaMiddleware); isSM {
+			return sm.RequestSchema(), sm.ResponseSchema(), true
+		}
+	}
+	return nil, nil, false
+}
+
+// newOperation returns the operation that describes routes that share a
+// method and path. The routes that have queries are selected by their query
+// parameters, and a route without queries is selected by their absence.
+func newOperation(
+	routes []types.Route,
+	tag string,
+	schemas map[string]Schema) (*Operation, error) {
+
+	op := &Operation{
+		OperationID: operationID(routes),
+		Tags:        []string{tag},
+		Responses: map[string]*Response{
+			"default": {
+				Description: "An error.",
+				Content:     content(componentRef("error")),
+			},
+		},
+	}
+
+	path := routes[0].GetPath()
+	for _, m := range pathParamRx.FindAllStringSubmatch(path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   Schema{"type": "string"},
+		})
+	}
+
+	// a query that selects a route is required only if no other route
+	// shares the operation
+	for _, route := range routes {
+		q := route.GetQueries()
+		for i := 0; i+1 < len(q); i += 2 {
+			p := &Parameter{
+				Name: q[i],
+				In:   "query",
+				Description: fmt.Sprintf(
+					"Selects the %s operation.", route.GetName()),
+				Required: len(routes) == 1,
+				Schema:   Schema{"type": "boolean"},
+			}
+			if q[i+1] == "" {
+				p.AllowEmptyValue = true
+			} else {
+				p.Schema = Schema{"type": "string", "enum": []string{q[i+1]}}
+			}
+			op.Parameters = append(op.Parameters, p)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, route := range routes {
+		for _, name := range routeQueryParams[route.GetName()] {
+			names[name] = true
+		}
+	}
+	for _, p := range queryParams {
+		if names[p.Name] {
+			qp := *p
+			qp.In = "query"
+			op.Parameters = append(op.Parameters, &qp)
+		}
+	}
+
+	var reqRefs, resRefs []Schema
+	reqRequired := true
+	for _, route := range routes {
+		req, res, _ := Schemas(route)
+		if req == nil {
+			reqRequired = false
+		} else {
+			ref, err := schemaRef(route, req, schemas)
+			if err != nil {
+				return nil, err
+			}
+			reqRefs = appendRef(reqRefs, ref)
+		}
+		if res != nil {
+			ref, err := schemaRef(route, res, schemas)
+			if err != nil {
+				return nil, err
+			}
+			resRefs = appendRef(resRefs, ref)
+		}
+	}
+
+	if len(reqRefs) > 0 {
+		op.RequestBody = &RequestBody{
+			Required: reqRequired,
+			Content:  content(oneOf(reqRefs)),
+		}
+	}
+
+	success := &Response{Description: "Success."}
+	if len(resRefs) > 0 {
+		success.Content = content(oneOf(resRefs))
+	}
+	op.Responses["2XX"] = success
+
+	return op, nil
+}
+
+// operationID returns the ID of the operation that describes the routes.
+// The ID is the name of the route without queries, if any; otherwise the
+// routes' common name prefix followed by "Action" identifies the operation.
+func operationID(routes []types.Route) string {
+	if len(routes) == 1 {
+		return routes[0].GetName()
+	}
+	names := make([]string, len(routes))
+	for i, route := range routes {
+		if len(route.GetQueries()) == 0 {
+			return route.GetName()
+		}
+		names[i] = route.GetName()
+	}
+	sort.Strings(names)
+	first, last := names[0], names[len(names)-1]
+	n := 0
+	for n < len(first) && n < len(last) && first[n] == last[n] {
+		n++
+	}
+	// the prefix ends at a word boundary of the camel-cased names
+	for n > 0 && n < len(first) && !unicode.IsUpper(rune(first[n])) {
+		n--
+	}
+	return first[:n] + "Action"
+}
+
+func appendRef(refs []Schema, ref Schema) []Schema {
+	for _, r := range refs {
+		if r["$ref"] == ref["$ref"] {
+			return refs
+		}
+	}
+	return append(refs, ref)
+}
+
+func oneOf(refs []Schema) Schema {
+	if len(refs) == 1 {
+		return refs[0]
+	}
+	return Schema{"oneOf": refs}
+}
+
+// pathKey returns the path under which a route is described, which is the
+// route's path without the patterns of its path variables.
+func pathKey(route types.Route) string {
+	return pathParamRx.ReplaceAllString(route.GetPath(), "{$1}")
+}
+
+func schemaRef(
+	route types.Route, s []byte, schemas map[string]Schema) (Schema, error) {
+
+	name := schema.DefinitionName(s)
+	if _, ok := schemas[name]; !ok {
+		return nil, fmt.Errorf(
+			"openapi: route %s: unknown schema: %s", route.GetName(), s)
+	}
+	return componentRef(name), nil
+}
+
+func componentRef(name string) Schema {
+	return Schema{"$ref": componentsRef + name}
+}
+
+func content(s Schema) map[string]*MediaType {
+	return map[string]*MediaType{ContentType: {Schema: s}}
+}
+
+// componentSchemas returns the definitions of the libStorage JSON schema as
+// OpenAPI schema objects.
+func componentSchemas() (map[string]Schema, error) {
+	var root struct {
+		Definitions map[string]Schema `json:"definitions"`
+	}
+	if err := json.Unmarshal([]byte(schema.JSONSchema), &root); err != nil {
+		return nil, err
+	}
+	for _, s := range root.Definitions {
+		convert(s)
+	}
+	return root.Definitions, nil
+}
+
+// convert converts a JSON schema to an OpenAPI schema object in place. The
+// references to definitions become references to component schemas, and
+// since OpenAPI 3.0 does not support pattern properties, a schema with a
+// single pattern property has additional properties of the pattern's
+// schema instead.
+func convert(v interface{}) {
+	switch tv := v.(type) {
+	case Schema:
+		convert(map[string]interface{}(tv))
+	case map[string]interface{}:
+		if ref, ok := tv["$ref"].(string); ok &&
+			strings.HasPrefix(ref, definitionsRef) {
+			tv["$ref"] = componentsRef + ref[len(definitionsRef):]
+		}
+		if pp, ok := tv["patternProperties"].(map[string]interface{}); ok &&
+			len(pp) == 1 {
+			for _, s := range pp {
+				tv["additionalProperties"] = s
+			}
+			delete(tv, "patternProperties")
+		}
+		for _, e := range tv {
+			convert(e)
+		}
+	case []interface{}:
+		for _, e := range tv {
+			convert(e)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/openapi"
+	"github.com/codedellemc/libstorage/api/types"
+
+	// load the routers
+	_ "github.com/codedellemc/libstorage/api/server/router/help"
+	_ "github.com/codedellemc/libstorage/api/server/router/metrics"
+	_ "github.com/codedellemc/libstorage/api/server/router/root"
+	_ "github.com/codedellemc/libstorage/api/server/router/service"
+	_ "github.com/codedellemc/libstorage/api/server/router/snapshot"
+	_ "github.com/codedellemc/libstorage/api/server/router/tasks"
+	_ "github.com/codedellemc/libstorage/api/server/router/volume"
+)
+
+// uncovered are the names of the routes that do not have schema middleware
+// and the reasons why.
+var uncovered = map[string]string{
+	"metrics":        "responds with the Prometheus text format",
+	"helpConfig":     "responds with the server's configuration",
+	"helpEnv":        "responds with the server's environment",
+	"helpOpenAPI":    "responds with an OpenAPI document",
+	"tasksWatch":     "streams server-sent events",
+	"volumeRemove":   "has no request or response payload",
+	"snapshotRemove": "has no request or response payload",
+}
+
+func initRouters() []types.Router {
+	config := registry.NewConfig()
+	routers := []types.Router{}
+	for r := range registry.Routers() {
+		r.Init(config)
+		routers = append(routers, r)
+	}
+	return routers
+}
+
+func TestSchemaCoverage(t *testing.T) {
+	names := map[string]bool{}
+	for _, router := range initRouters() {
+		for _, route := range router.Routes() {
+			name := route.GetName()
+			assert.False(t, names[name], "duplicate route name: %s", name)
+			names[name] = true
+
+			req, res, ok := openapi.Schemas(route)
+			if _, ok := uncovered[name]; ok {
+				continue
+			}
+			if !assert.True(t, ok, "route %s has no schemas", name) {
+				continue
+			}
+			switch route.GetMethod() {
+			case "POST", "PUT", "PATCH":
+				assert.NotNil(t, req, "route %s has no req schema", name)
+			case "GET":
+				assert.NotNil(t, res, "route %s has no res schema", name)
+			}
+		}
+	}
+
+	for name := range uncovered {
+		assert.True(t, names[name], "unknown uncovered route: %s", name)
+	}
+}
+
+func TestNew(t *testing.T) {
+	doc, err := openapi.New("0.0.0", initRouters()...)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	for path := range doc.Paths {
+		assert.NotContains(t, path, "?")
+	}
+
+	// the routes that act on a volume are selected by a query parameter
+	op := doc.Paths["/volumes/{service}/{volumeID}"]["post"]
+	if assert.NotNil(t, op) {
+		assert.Equal(t, "volumeAction", op.OperationID)
+		assert.Equal(t, []string{"volume"}, op.Tags)
+		assert.Equal(t, "volumeID", op.Parameters[1].Name)
+		attach := parameter(op, "attach")
+		if assert.NotNil(t, attach) {
+			assert.Equal(t, "query", attach.In)
+			assert.False(t, attach.Required)
+			assert.True(t, attach.AllowEmptyValue)
+		}
+		assert.NotNil(t, parameter(op, "force"))
+		assert.Contains(t,
+			op.RequestBody.Content[openapi.ContentType].Schema["oneOf"],
+			openapi.Schema{"$ref": "#/components/schemas/volumeAttachRequest"})
+		assert.Contains(t,
+			op.Responses["2XX"].Content[openapi.ContentType].Schema["oneOf"],
+			openapi.Schema{
+				"$ref": "#/components/schemas/volumeAttachResponse"})
+	}
+
+	// a route that is the only one on its path requires its query
+	op = doc.Paths["/volumes"]["post"]
+	if assert.NotNil(t, op) {
+		assert.Equal(t, "volumesDetachAll", op.OperationID)
+		if p := parameter(op, "detach"); assert.NotNil(t, p) {
+			assert.True(t, p.Required)
+		}
+	}
+
+	op = doc.Paths["/volumes"]["get"]
+	if assert.NotNil(t, op) {
+		for _, name := range []string{
+			"attachments", "fresh", "filter", "limit", "continue", "async"} {
+			if p := parameter(op, name); assert.NotNil(t, p, name) {
+				assert.Equal(t, "query", p.In)
+				assert.False(t, p.Required)
+			}
+		}
+	}
+
+	op = doc.Paths["/volumes/{service}/{volumeID}"]["delete"]
+	if assert.NotNil(t, op) {
+		assert.Nil(t, op.RequestBody)
+		assert.NotNil(t, parameter(op, "force"))
+	}
+
+	taskMap := doc.Components.Schemas["taskMap"]
+	assert.NotContains(t, taskMap, "patternProperties")
+	assert.Equal(t,
+		map[string]interface{}{"$ref": "#/components/schemas/task"},
+		taskMap["additionalProperties"])
+
+	// every reference must be to one of the document's component schemas
+	buf, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	var v interface{}
+	assert.NoError(t, json.Unmarshal(buf, &v))
+	for _, ref := range refs(v, nil) {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		assert.NotEqual(t, ref, name, "invalid ref: %s", ref)
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+}
+
+func parameter(op *openapi.Operation, name string) *openapi.Parameter {
+	for _, p := range op.Parameters {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func refs(v interface{}, found []string) []string {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range tv {
+			if s, ok := e.(string); ok && k == "$ref" {
+				found = append(found, s)
+			} else {
+				found = refs(e, found)
+			}
+		}
+	case []interface{}:
+		for _, e := range tv {
+			found = refs(e, found)
+		}
+	}
+	return found
+}
@@ -4,8 +4,10 @@ import (
 	gofig "github.com/akutz/gofig/types"
 
 	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/handlers"
 	"github.com/codedellemc/libstorage/api/server/httputils"
 	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils/schema"
 )
 
 func init() {
@@ -34,9 +36,19 @@ func (r *router) Routes() []types.Route {
 func (r *router) initRoutes() {
 	r.routes = []types.Route{
 		// GET
-		httputils.NewGetRoute("version", "/help", r.helpInspect),
-		httputils.NewGetRoute("version", "/help/config", r.configInspect),
-		httputils.NewGetRoute("version", "/help/env", r.envInspect),
-		httputils.NewGetRoute("version", "/help/version", r.versionInspect),
+		httputils.NewGetRoute(
+			"help",
+			"/help",
+			r.helpInspect,
+			handlers.NewSchemaValidator(nil, schema.URLsSchema, nil)),
+		httputils.NewGetRoute("helpConfig", "/help/config", r.configInspect),
+		httputils.NewGetRoute("helpEnv", "/help/env", r.envInspect),
+		httputils.NewGetRoute(
+			"helpOpenAPI", "/help/openapi", r.openAPIInspect),
+		httputils.NewGetRoute(
+			"helpVersion",
+			"/help/version",
+			r.versionInspect,
+			handlers.NewSchemaValidator(nil, schema.VersionInfoSchema, nil)),
 	}
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/codedellemc/libstorage/api"
 	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
 	"github.com/codedellemc/libstorage/api/server/httputils"
+	"github.com/codedellemc/libstorage/api/server/openapi"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
 )
@@ -27,6 +29,7 @@ func (r *router) helpInspect(
 	reply := []string{
 		fmt.Sprintf("%s/help/config", rootURL),
 		fmt.Sprintf("%s/help/env", rootURL),
+		fmt.Sprintf("%s/help/openapi", rootURL),
 		fmt.Sprintf("%s/help/version", rootURL),
 	}
 
@@ -44,6 +47,28 @@ func (r *router) versionInspect(
 	return nil
 }
 
+// openAPIInspect returns an OpenAPI document that describes the routes of all
+// the registered routers.
+func (r *router) openAPIInspect(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	routers := []types.Router{}
+	for router := range registry.Routers() {
+		routers = append(routers, router)
+	}
+
+	doc, err := openapi.New(api.Version.SemVer, routers...)
+	if err != nil {
+		return err
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, doc)
+	return nil
+}
+
 func (r *router) configInspect(
 	ctx types.Context,
 	w http.ResponseWriter,
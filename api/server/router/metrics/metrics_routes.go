@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// metrics writes the metrics of the server's default Prometheus registry.
+func (r *router) metrics(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	promhttp.Handler().ServeHTTP(w, req)
+	return nil
+}
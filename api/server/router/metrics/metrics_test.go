@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jcrypto "github.com/SermoDigital/jose/crypto"
+	"github.com/SermoDigital/jose/jws"
+	gofigCore "github.com/akutz/gofig"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/handlers"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+const (
+	testDriverName = "metricstest"
+	testJWTKey     = "key"
+)
+
+type testDriver struct {
+	types.StorageDriver
+}
+
+func (d *testDriver) Name() string {
+	return testDriverName
+}
+
+func (d *testDriver) Init(ctx types.Context, config gofig.Config) error {
+	return nil
+}
+
+func init() {
+	registry.RegisterStorageDriver(testDriverName,
+		func() types.StorageDriver { return &testDriver{} })
+}
+
+func newTestJWT(t *testing.T, scope string) string {
+	now := time.Now()
+	jwt := jws.NewJWT(jws.Claims{"scope": scope}, jcrypto.SigningMethodHS256)
+	jwt.Claims().SetSubject("prometheus")
+	jwt.Claims().SetIssuedAt(now)
+	jwt.Claims().SetNotBefore(now)
+	jwt.Claims().SetExpiration(now.Add(time.Hour))
+	buf, err := jwt.Serialize([]byte(testJWTKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(buf)
+}
+
+// testDo sends a request to the metrics route of a server whose service
+// requires the metrics:read permission.
+func testDo(t *testing.T, tok string) *httptest.ResponseRecorder {
+	config := gofigCore.New()
+	config.Set(types.ConfigServices, map[string]interface{}{
+		"s0": map[string]interface{}{"driver": testDriverName},
+	})
+	config.Set(types.ConfigServerAuthKey, testJWTKey)
+	config.Set(types.ConfigServerAuthRoles, map[string]interface{}{
+		"admin": []string{types.AuthPermAll},
+	})
+	ctx := context.Background().WithValue(context.ServerKey, t.Name())
+	if err := services.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	defer services.Close(ctx)
+
+	r := &router{}
+	r.Init(config)
+	route := r.Routes()[0]
+	h := route.GetHandler()
+	mw := route.GetMiddlewares()
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i].Handler(h)
+	}
+	h = handlers.NewErrorHandler().Handler(h)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if tok != "" {
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+	assert.NoError(t, h(ctx, w, req, utils.NewStore()))
+	return w
+}
+
+func TestMetricsRequiresToken(t *testing.T) {
+	w := testDo(t, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NotContains(t, w.Body.String(), "go_goroutines")
+}
+
+func TestMetricsRequiresPermission(t *testing.T) {
+	w := testDo(t, newTestJWT(t, types.AuthPermVolumesList))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "go_goroutines")
+}
+
+func TestMetrics(t *testing.T) {
+	w := testDo(t, newTestJWT(t, types.AuthPermMetricsRead))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+}
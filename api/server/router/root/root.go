@@ -4,8 +4,10 @@ import (
 	gofig "github.com/akutz/gofig/types"
 
 	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/handlers"
 	"github.com/codedellemc/libstorage/api/server/httputils"
 	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils/schema"
 )
 
 func init() {
@@ -32,6 +34,10 @@ func (r *router) Routes() []types.Route {
 func (r *router) initRoutes() {
 	r.routes = []types.Route{
 		// GET
-		httputils.NewGetRoute("root", "/", r.root),
+		httputils.NewGetRoute(
+			"root",
+			"/",
+			r.root,
+			handlers.NewSchemaValidator(nil, schema.URLsSchema, nil)),
 	}
 }
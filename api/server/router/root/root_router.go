@@ -30,6 +30,7 @@ func (r *router) root(
 		fmt.Sprintf("%s/snapshots", rootURL),
 		fmt.Sprintf("%s/tasks", rootURL),
 		fmt.Sprintf("%s/help", rootURL),
+		fmt.Sprintf("%s/metrics", rootURL),
 		fmt.Sprintf("%s/volumes", rootURL),
 	}
 
@@ -41,6 +41,7 @@ func (r *router) initRoutes() {
 			"/services",
 			r.servicesList,
 			handlers.NewAuthAllSvcsHandler(),
+			handlers.NewRateLimitHandler(),
 			handlers.NewSchemaValidator(nil, schema.ServiceInfoMapSchema, nil)),
 
 		httputils.NewGetRoute(
@@ -49,6 +50,7 @@ func (r *router) initRoutes() {
 			r.serviceInspect,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewRateLimitHandler(),
 			handlers.NewSchemaValidator(nil, schema.ServiceInfoSchema, nil)),
 	}
 }
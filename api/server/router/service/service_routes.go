@@ -74,14 +74,19 @@ func toServiceInfo(
 	if err != nil {
 		return nil, err
 	}
+	caps, err := services.Capabilities(ctx, service)
+	if err != nil {
+		return nil, err
+	}
 
 	return &types.ServiceInfo{
 		Name:     service.Name(),
 		Instance: instance,
 		Driver: &types.DriverInfo{
-			Name:       d.Name(),
-			Type:       st,
-			NextDevice: nd,
+			Name:         d.Name(),
+			Type:         st,
+			NextDevice:   nd,
+			Capabilities: caps,
 		},
 	}, nil
 }
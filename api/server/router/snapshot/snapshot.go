@@ -44,6 +44,8 @@ func (r *router) initRoutes() {
 			"/snapshots",
 			r.snapshots,
 			handlers.NewAuthAllSvcsHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermSnapshotsList),
+			handlers.NewRateLimitHandler(),
 			handlers.NewSchemaValidator(
 				nil, schema.ServiceSnapshotMapSchema, nil),
 		),
@@ -55,6 +57,9 @@ func (r *router) initRoutes() {
 			r.snapshotsForService,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermSnapshotsList),
+			handlers.NewCapabilityHandler(types.DriverCapabilitySnapshots),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				nil, schema.SnapshotMapSchema, nil),
@@ -67,6 +72,9 @@ func (r *router) initRoutes() {
 			r.snapshotInspect,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermSnapshotsInspect),
+			handlers.NewCapabilityHandler(types.DriverCapabilitySnapshots),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(nil, schema.SnapshotSchema, nil),
 		),
@@ -80,6 +88,10 @@ func (r *router) initRoutes() {
 			r.volumeCreate,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesCreate),
+			handlers.NewCapabilityHandler(
+				types.DriverCapabilityCreateFromSnapshot),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeCreateRequestSchema,
@@ -95,6 +107,11 @@ func (r *router) initRoutes() {
 			r.snapshotCopy,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermSnapshotsCopy),
+			handlers.NewCapabilityHandler(
+				types.DriverCapabilitySnapshots,
+				types.DriverCapabilitySnapshotCopy),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.SnapshotCopyRequestSchema,
@@ -112,6 +129,9 @@ func (r *router) initRoutes() {
 			r.snapshotRemove,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermSnapshotsRemove),
+			handlers.NewCapabilityHandler(types.DriverCapabilitySnapshots),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 		),
 	}
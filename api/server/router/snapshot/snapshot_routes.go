@@ -3,13 +3,17 @@ package snapshot
 import (
 	"net/http"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/goof"
+
 	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/server/httputils"
 	"github.com/codedellemc/libstorage/api/server/router/volume"
 	"github.com/codedellemc/libstorage/api/server/services"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/api/utils/filters"
+	"github.com/codedellemc/libstorage/api/utils/paging"
 	"github.com/codedellemc/libstorage/api/utils/schema"
 )
 
@@ -19,6 +23,22 @@ func (r *router) snapshots(
 	req *http.Request,
 	store types.Store) error {
 
+	filter, err := parseFilter(store)
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		store.Set("filter", filter)
+	}
+
+	page, tok, err := paging.ParseOpts(store)
+	if err != nil {
+		return err
+	}
+	if page != nil {
+		return r.snapshotsPage(ctx, w, store, filter, page, tok)
+	}
+
 	var (
 		tasks   = map[string]*types.Task{}
 		taskIDs []int
@@ -37,16 +57,7 @@ func (r *router) snapshots(
 				return nil, err
 			}
 
-			objs, err := svc.Driver().Snapshots(ctx, store)
-			if err != nil {
-				return nil, err
-			}
-
-			objMap := map[string]*types.Snapshot{}
-			for _, obj := range objs {
-				objMap[obj.ID] = obj
-			}
-			return objMap, nil
+			return getFilteredSnapshots(ctx, store, svc, filter)
 		}
 
 		task := service.TaskEnqueue(ctx, run, schema.SnapshotMapSchema)
@@ -63,10 +74,10 @@ func (r *router) snapshots(
 				return nil, utils.NewBatchProcessErr(reply, v.Error)
 			}
 
-			objMap, ok := v.Result.(map[string]*types.Snapshot)
+			objMap, ok := v.Result.(types.SnapshotMap)
 			if !ok {
 				return nil, utils.NewBatchProcessErr(
-					reply, goof.New("error casting to []*types.Snapshot"))
+					reply, goof.New("error casting to types.SnapshotMap"))
 			}
 			reply[k] = objMap
 		}
@@ -83,29 +94,143 @@ func (r *router) snapshots(
 		http.StatusOK)
 }
 
+// snapshotsPage returns a page of the snapshots for all services. The
+// services are paged one after the other, in order of their names, so that
+// a page may include snapshots from more than one service.
+func (r *router) snapshotsPage(
+	ctx types.Context,
+	w http.ResponseWriter,
+	store types.Store,
+	filter *types.Filter,
+	page *types.PageOpts,
+	tok *paging.Token) error {
+
+	run := func(ctx types.Context) (interface{}, error) {
+
+		var svcs []types.StorageService
+		for svc := range services.StorageServices(ctx) {
+			svcs = append(svcs, svc)
+		}
+		utils.SortServiceByName(svcs)
+
+		var (
+			reply     = types.ServiceSnapshotMap{}
+			remaining = page.Limit
+		)
+
+		for i, service := range svcs {
+
+			var svcTok *paging.Token
+			if tok != nil {
+				if service.Name() < tok.Service {
+					continue
+				}
+				if service.Name() == tok.Service {
+					svcTok = tok
+				}
+			}
+
+			var next *paging.Token
+
+			run := func(
+				ctx types.Context,
+				svc types.StorageService) (interface{}, error) {
+
+				ctx = context.WithStorageService(ctx, svc)
+
+				var err error
+				if ctx, err = context.WithStorageSession(ctx); err != nil {
+					return nil, err
+				}
+
+				var objMap types.SnapshotMap
+				objMap, next, err = getSnapshotsPage(
+					ctx, store, svc, filter, remaining, svcTok)
+				return objMap, err
+			}
+
+			task := service.TaskEnqueue(ctx, run, schema.SnapshotMapSchema)
+			services.TaskWait(ctx, task.ID)
+
+			if task.Error != nil {
+				return nil, utils.NewBatchProcessErr(reply, task.Error)
+			}
+
+			objMap, ok := task.Result.(types.SnapshotMap)
+			if !ok {
+				return nil, utils.NewBatchProcessErr(
+					reply, goof.New("error casting to types.SnapshotMap"))
+			}
+			reply[service.Name()] = objMap
+
+			if next != nil {
+				next.Service = service.Name()
+				return &types.PagedResult{
+					Result:   reply,
+					Continue: next.String(),
+				}, nil
+			}
+
+			if page.Limit == 0 {
+				continue
+			}
+
+			if remaining -= len(objMap); remaining == 0 && i+1 < len(svcs) {
+				next = &paging.Token{Service: svcs[i+1].Name()}
+				return &types.PagedResult{
+					Result:   reply,
+					Continue: next.String(),
+				}, nil
+			}
+		}
+
+		return &types.PagedResult{Result: reply}, nil
+	}
+
+	return httputils.WriteTask(
+		ctx,
+		r.config,
+		w,
+		store,
+		services.TaskEnqueue(ctx, run, schema.ServiceSnapshotMapSchema),
+		http.StatusOK)
+}
+
 func (r *router) snapshotsForService(
 	ctx types.Context,
 	w http.ResponseWriter,
 	req *http.Request,
 	store types.Store) error {
 
+	filter, err := parseFilter(store)
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		store.Set("filter", filter)
+	}
+
+	page, tok, err := paging.ParseOpts(store)
+	if err != nil {
+		return err
+	}
+
 	service := context.MustService(ctx)
 
 	run := func(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		var reply types.SnapshotMap = map[string]*types.Snapshot{}
+		if page == nil {
+			return getFilteredSnapshots(ctx, store, svc, filter)
+		}
 
-		objs, err := svc.Driver().Snapshots(ctx, store)
+		objMap, next, err := getSnapshotsPage(
+			ctx, store, svc, filter, page.Limit, tok)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, obj := range objs {
-			reply[obj.ID] = obj
-		}
-		return reply, nil
+		return &types.PagedResult{Result: objMap, Continue: next.String()}, nil
 	}
 
 	return httputils.WriteTask(
@@ -156,12 +281,28 @@ func (r *router) snapshotRemove(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		return nil, svc.Driver().SnapshotRemove(
+		err := svc.Driver().SnapshotRemove(
 			ctx,
 			store.GetString("snapshotID"),
 			store)
+
+		if err != nil {
+			return nil, err
+		}
+
+		services.EventEmit(ctx, &types.Event{
+			Operation:  types.EventSnapshotRemove,
+			SnapshotID: store.GetString("snapshotID"),
+		})
+
+		return nil, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.SnapshotLockKey(
+			service.Name(), store.GetString("snapshotID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -179,6 +320,12 @@ func (r *router) volumeCreate(
 
 	service := context.MustService(ctx)
 
+	if store.GetBool("encrypted") {
+		if err := services.RequireEncryption(ctx, service); err != nil {
+			return err
+		}
+	}
+
 	run := func(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
@@ -192,6 +339,9 @@ func (r *router) volumeCreate(
 				IOPS:             store.GetInt64Ptr("iops"),
 				Size:             store.GetInt64Ptr("size"),
 				Type:             store.GetStringPtr("type"),
+				Encrypted:        store.GetBoolPtr("encrypted"),
+				EncryptionKey:    store.GetStringPtr("encryptionKey"),
+				Labels:           store.GetStringMap("labels"),
 				Opts:             store,
 			})
 
@@ -209,9 +359,21 @@ func (r *router) volumeCreate(
 			}
 		}
 
+		services.EventEmit(ctx, &types.Event{
+			Operation:  types.EventVolumeCreateFromSnapshot,
+			VolumeID:   v.ID,
+			SnapshotID: store.GetString("snapshotID"),
+			Result:     v,
+		})
+
 		return v, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.SnapshotLockKey(
+			service.Name(), store.GetString("snapshotID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -233,14 +395,32 @@ func (r *router) snapshotCopy(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		return svc.Driver().SnapshotCopy(
+		s, err := svc.Driver().SnapshotCopy(
 			ctx,
 			store.GetString("snapshotID"),
 			store.GetString("snapshotName"),
 			store.GetString("destinationID"),
 			store)
+
+		if err != nil {
+			return nil, err
+		}
+
+		services.EventEmit(ctx, &types.Event{
+			Operation:  types.EventSnapshotCopy,
+			VolumeID:   s.VolumeID,
+			SnapshotID: s.ID,
+			Result:     s,
+		})
+
+		return s, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.SnapshotLockKey(
+			service.Name(), store.GetString("snapshotID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -249,3 +429,120 @@ func (r *router) snapshotCopy(
 		service.TaskEnqueue(ctx, run, schema.SnapshotSchema),
 		http.StatusCreated)
 }
+
+func getFilteredSnapshots(
+	ctx types.Context,
+	store types.Store,
+	storSvc types.StorageService,
+	filter *types.Filter) (types.SnapshotMap, error) {
+
+	objs, err := storSvc.Driver().Snapshots(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	objMap := types.SnapshotMap{}
+	for _, obj := range objs {
+		if includeSnapshot(ctx, obj, filter) {
+			objMap[obj.ID] = obj
+		}
+	}
+	return objMap, nil
+}
+
+// getSnapshotsPage returns a page of a service's snapshots. The snapshots
+// are paged by the driver if it implements types.StorageDriverWithPaging,
+// otherwise all of the service's snapshots are listed and then paged by ID.
+func getSnapshotsPage(
+	ctx types.Context,
+	store types.Store,
+	storSvc types.StorageService,
+	filter *types.Filter,
+	limit int,
+	tok *paging.Token) (types.SnapshotMap, *paging.Token, error) {
+
+	if pd, ok := storSvc.Driver().(types.StorageDriverWithPaging); ok {
+
+		var (
+			objs   []*types.Snapshot
+			objMap = types.SnapshotMap{}
+		)
+
+		next, err := paging.DriverPage(
+			limit,
+			tok,
+			func(pageToken string, size int) (int, string, error) {
+				ctx.WithFields(log.Fields{
+					"limit":     size,
+					"pageToken": pageToken,
+				}).Debug("querying snapshots page")
+				var (
+					next string
+					err  error
+				)
+				objs, next, err = pd.SnapshotsPage(
+					ctx, store, &types.PageOpts{
+						Limit:    size,
+						Continue: pageToken,
+					})
+				return len(objs), next, err
+			},
+			func(i int) (bool, error) {
+				ok := includeSnapshot(ctx, objs[i], filter)
+				if ok {
+					objMap[objs[i].ID] = objs[i]
+				}
+				return ok, nil
+			})
+
+		if err != types.ErrNotImplemented {
+			return objMap, next, err
+		}
+
+		ctx.Debug("driver does not implement snapshot paging")
+	}
+
+	objMap, err := getFilteredSnapshots(ctx, store, storSvc, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(objMap))
+	for id := range objMap {
+		ids = append(ids, id)
+	}
+
+	ids, next := paging.ServerPage(limit, tok, ids)
+
+	page := types.SnapshotMap{}
+	for _, id := range ids {
+		page[id] = objMap[id]
+	}
+
+	return page, next, nil
+}
+
+func includeSnapshot(
+	ctx types.Context,
+	obj *types.Snapshot,
+	filter *types.Filter) bool {
+
+	if filter != nil && !filters.MatchSnapshot(filter, obj) {
+		ctx.WithField("snapshotID", obj.ID).Debug(
+			"omitted snapshot due to filter")
+		return false
+	}
+	return true
+}
+
+func parseFilter(store types.Store) (*types.Filter, error) {
+	if !store.IsSet("filter") {
+		return nil, nil
+	}
+	fsz := store.GetString("filter")
+	filter, err := filters.CompileFilter(fsz)
+	if err != nil {
+		return nil, utils.NewBadFilterErr(fsz, err)
+	}
+	return filter, nil
+}
@@ -1,15 +1,21 @@
 package tasks
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/server/httputils"
 	"github.com/codedellemc/libstorage/api/server/services"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
 )
 
+const eventStreamContentType = "text/event-stream"
+
 func (r *router) tasks(
 	ctx types.Context,
 	w http.ResponseWriter,
@@ -38,3 +44,89 @@ func (r *router) taskInspect(
 	httputils.WriteJSON(w, http.StatusOK, task)
 	return nil
 }
+
+func (r *router) taskCancel(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	task := services.TaskCancel(ctx, store.GetInt("taskID"))
+	if task == nil {
+		return utils.NewNotFoundError(store.GetString("taskID"))
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, task)
+	return nil
+}
+
+// tasksWatch streams task state transitions to the client as server-sent
+// events when the client accepts the text/event-stream content type. Otherwise
+// the request is treated as a long-poll that returns the next task state
+// transition, or no content if there is no transition before the task
+// execution timeout.
+func (r *router) tasksWatch(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tasks := services.TaskWatch(ctx)
+
+	flusher, canFlush := w.(http.Flusher)
+	notifier, canNotify := w.(http.CloseNotifier)
+	if !canFlush || !canNotify ||
+		!strings.Contains(req.Header.Get("Accept"), eventStreamContentType) {
+		return r.tasksWatchPoll(ctx, w, tasks)
+	}
+
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	closed := notifier.CloseNotify()
+	for {
+		select {
+		case task, ok := <-tasks:
+			if !ok {
+				return nil
+			}
+			buf, err := json.Marshal(task)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", task.State, buf)
+			flusher.Flush()
+		case <-closed:
+			ctx.Debug("task watcher disconnected")
+			return nil
+		}
+	}
+}
+
+func (r *router) tasksWatchPoll(
+	ctx types.Context,
+	w http.ResponseWriter,
+	tasks <-chan *types.Task) error {
+
+	pollTimeoutDur, err := time.ParseDuration(
+		r.config.GetString(types.ConfigServerTasksExeTimeout))
+	if err != nil {
+		pollTimeoutDur = time.Duration(time.Second * 60)
+	}
+	pollTimeout := time.NewTimer(pollTimeoutDur)
+	defer pollTimeout.Stop()
+
+	select {
+	case task := <-tasks:
+		httputils.WriteJSON(w, http.StatusOK, task)
+	case <-pollTimeout.C:
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	gofig "github.com/akutz/gofig/types"
 
 	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/handlers"
 	"github.com/codedellemc/libstorage/api/server/httputils"
 	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils/schema"
 )
 
 func init() {
@@ -13,6 +15,7 @@ func init() {
 }
 
 type router struct {
+	config gofig.Config
 	routes []types.Route
 }
 
@@ -21,6 +24,7 @@ func (r *router) Name() string {
 }
 
 func (r *router) Init(config gofig.Config) {
+	r.config = config
 	r.initRoutes()
 }
 
@@ -33,16 +37,36 @@ func (r *router) initRoutes() {
 
 	r.routes = []types.Route{
 
+		// GET
+		httputils.NewGetRoute(
+			"tasksWatch",
+			"/tasks",
+			r.tasksWatch,
+			handlers.NewAuthAllSvcsHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermTasksWatch),
+		).Queries("watch"),
+
 		// GET
 		httputils.NewGetRoute(
 			"tasks",
 			"/tasks",
-			r.tasks),
+			r.tasks,
+			handlers.NewSchemaValidator(nil, schema.TaskMapSchema, nil)),
 
 		// GET
 		httputils.NewGetRoute(
 			"taskInspect",
 			"/tasks/{taskID}",
-			r.taskInspect),
+			r.taskInspect,
+			handlers.NewSchemaValidator(nil, schema.TaskSchema, nil)),
+
+		// DELETE
+		httputils.NewDeleteRoute(
+			"taskCancel",
+			"/tasks/{taskID}",
+			r.taskCancel,
+			handlers.NewAuthAllSvcsHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermTasksCancel),
+			handlers.NewSchemaValidator(nil, schema.TaskSchema, nil)),
 	}
 }
@@ -57,6 +57,8 @@ func (r *router) initRoutes() {
 			"/volumes",
 			r.volumes,
 			handlers.NewAuthAllSvcsHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesList),
+			handlers.NewRateLimitHandler(),
 			handlers.NewSchemaValidator(nil, schema.ServiceVolumeMapSchema, nil),
 		),
 
@@ -67,6 +69,8 @@ func (r *router) initRoutes() {
 			r.volumesForService,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesList),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(nil, schema.VolumeMapSchema, nil),
 		),
@@ -78,6 +82,8 @@ func (r *router) initRoutes() {
 			r.volumeInspect,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesInspect),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(nil, schema.VolumeSchema, nil),
 		),
@@ -91,6 +97,8 @@ func (r *router) initRoutes() {
 			r.volumeDetachAllForService,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesDetach),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeDetachRequestSchema,
@@ -106,6 +114,8 @@ func (r *router) initRoutes() {
 			r.volumeCreate,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesCreate),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeCreateRequestSchema,
@@ -121,6 +131,9 @@ func (r *router) initRoutes() {
 			r.volumeCopy,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesCopy),
+			handlers.NewCapabilityHandler(types.DriverCapabilityCopy),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeCopyRequestSchema,
@@ -136,6 +149,9 @@ func (r *router) initRoutes() {
 			r.volumeSnapshot,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesSnapshot),
+			handlers.NewCapabilityHandler(types.DriverCapabilitySnapshots),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeSnapshotRequestSchema,
@@ -144,6 +160,24 @@ func (r *router) initRoutes() {
 			handlers.NewPostArgsHandler(r.config),
 		).Queries("snapshot"),
 
+		// resize an existing volume
+		httputils.NewPostRoute(
+			"volumeResize",
+			"/volumes/{service}/{volumeID}",
+			r.volumeResize,
+			handlers.NewServiceValidator(),
+			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesResize),
+			handlers.NewCapabilityHandler(types.DriverCapabilityResize),
+			handlers.NewRateLimitHandler(),
+			handlers.NewStorageSessionHandler(),
+			handlers.NewSchemaValidator(
+				schema.VolumeResizeRequestSchema,
+				schema.VolumeSchema,
+				func() interface{} { return &types.VolumeResizeRequest{} }),
+			handlers.NewPostArgsHandler(r.config),
+		).Queries("resize"),
+
 		// attach an existing volume
 		httputils.NewPostRoute(
 			"volumeAttach",
@@ -151,10 +185,12 @@ func (r *router) initRoutes() {
 			r.volumeAttach,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesAttach),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeAttachRequestSchema,
-				schema.VolumeSchema,
+				schema.VolumeAttachResponseSchema,
 				func() interface{} { return &types.VolumeAttachRequest{} }),
 			handlers.NewPostArgsHandler(r.config),
 		).Queries("attach"),
@@ -165,6 +201,8 @@ func (r *router) initRoutes() {
 			"/volumes",
 			r.volumeDetachAll,
 			handlers.NewAuthAllSvcsHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesDetach),
+			handlers.NewRateLimitHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeDetachRequestSchema,
 				schema.ServiceVolumeMapSchema,
@@ -179,6 +217,8 @@ func (r *router) initRoutes() {
 			r.volumeDetach,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesDetach),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 			handlers.NewSchemaValidator(
 				schema.VolumeDetachRequestSchema,
@@ -194,6 +234,8 @@ func (r *router) initRoutes() {
 			r.volumeRemove,
 			handlers.NewServiceValidator(),
 			handlers.NewAuthSvcHandler(),
+			handlers.NewAuthPermHandler(types.AuthPermVolumesRemove),
+			handlers.NewRateLimitHandler(),
 			handlers.NewStorageSessionHandler(),
 		),
 	}
@@ -14,6 +14,8 @@ import (
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
 	"github.com/codedellemc/libstorage/api/utils/filters"
+	"github.com/codedellemc/libstorage/api/utils/labels"
+	"github.com/codedellemc/libstorage/api/utils/paging"
 	"github.com/codedellemc/libstorage/api/utils/schema"
 )
 
@@ -31,6 +33,16 @@ func (r *router) volumes(
 		store.Set("filter", filter)
 	}
 
+	selector, err := parseLabelSelector(store)
+	if err != nil {
+		return err
+	}
+
+	page, tok, err := paging.ParseOpts(store)
+	if err != nil {
+		return err
+	}
+
 	var (
 		tasks   = map[string]*types.Task{}
 		taskIDs []int
@@ -41,6 +53,13 @@ func (r *router) volumes(
 		reply = types.ServiceVolumeMap{}
 	)
 
+	ctx = services.WithVolumeCacheStatus(ctx)
+
+	if page != nil {
+		return r.volumesPage(
+			ctx, w, req, store, opts, filter, selector, page, tok)
+	}
+
 	for service := range services.StorageServices(ctx) {
 
 		run := func(
@@ -54,7 +73,8 @@ func (r *router) volumes(
 				return nil, err
 			}
 
-			return getFilteredVolumes(ctx, req, store, svc, opts, filter)
+			return getFilteredVolumes(
+				ctx, req, store, svc, opts, filter, selector)
 		}
 
 		task := service.TaskEnqueue(ctx, run, schema.VolumeMapSchema)
@@ -91,6 +111,112 @@ func (r *router) volumes(
 		http.StatusOK)
 }
 
+// volumesPage returns a page of the volumes for all services. The services
+// are paged one after the other, in order of their names, so that a page
+// may include volumes from more than one service.
+func (r *router) volumesPage(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store,
+	opts *types.VolumesOpts,
+	filter *types.Filter,
+	selector types.LabelSelector,
+	page *types.PageOpts,
+	tok *paging.Token) error {
+
+	run := func(ctx types.Context) (interface{}, error) {
+
+		var svcs []types.StorageService
+		for svc := range services.StorageServices(ctx) {
+			svcs = append(svcs, svc)
+		}
+		utils.SortServiceByName(svcs)
+
+		var (
+			reply     = types.ServiceVolumeMap{}
+			remaining = page.Limit
+		)
+
+		for i, service := range svcs {
+
+			var svcTok *paging.Token
+			if tok != nil {
+				if service.Name() < tok.Service {
+					continue
+				}
+				if service.Name() == tok.Service {
+					svcTok = tok
+				}
+			}
+
+			var next *paging.Token
+
+			run := func(
+				ctx types.Context,
+				svc types.StorageService) (interface{}, error) {
+
+				ctx = context.WithStorageService(ctx, svc)
+
+				var err error
+				if ctx, err = context.WithStorageSession(ctx); err != nil {
+					return nil, err
+				}
+
+				var objMap types.VolumeMap
+				objMap, next, err = getVolumesPage(
+					ctx, req, store, svc, opts, filter, selector,
+					remaining, svcTok)
+				return objMap, err
+			}
+
+			task := service.TaskEnqueue(ctx, run, schema.VolumeMapSchema)
+			services.TaskWait(ctx, task.ID)
+
+			if task.Error != nil {
+				return nil, utils.NewBatchProcessErr(reply, task.Error)
+			}
+
+			objMap, ok := task.Result.(types.VolumeMap)
+			if !ok {
+				return nil, utils.NewBatchProcessErr(
+					reply, goof.New("error casting to types.VolumeMap"))
+			}
+			reply[service.Name()] = objMap
+
+			if next != nil {
+				next.Service = service.Name()
+				return &types.PagedResult{
+					Result:   reply,
+					Continue: next.String(),
+				}, nil
+			}
+
+			if page.Limit == 0 {
+				continue
+			}
+
+			if remaining -= len(objMap); remaining == 0 && i+1 < len(svcs) {
+				next = &paging.Token{Service: svcs[i+1].Name()}
+				return &types.PagedResult{
+					Result:   reply,
+					Continue: next.String(),
+				}, nil
+			}
+		}
+
+		return &types.PagedResult{Result: reply}, nil
+	}
+
+	return httputils.WriteTask(
+		ctx,
+		r.config,
+		w,
+		store,
+		services.TaskEnqueue(ctx, run, schema.ServiceVolumeMapSchema),
+		http.StatusOK)
+}
+
 func (r *router) volumesForService(
 	ctx types.Context,
 	w http.ResponseWriter,
@@ -105,7 +231,18 @@ func (r *router) volumesForService(
 		store.Set("filter", filter)
 	}
 
+	selector, err := parseLabelSelector(store)
+	if err != nil {
+		return err
+	}
+
+	page, tok, err := paging.ParseOpts(store)
+	if err != nil {
+		return err
+	}
+
 	service := context.MustService(ctx)
+	ctx = services.WithVolumeCacheStatus(ctx)
 
 	opts := &types.VolumesOpts{
 		Attachments: store.GetAttachments(),
@@ -116,7 +253,17 @@ func (r *router) volumesForService(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		return getFilteredVolumes(ctx, req, store, svc, opts, filter)
+		if page == nil {
+			return getFilteredVolumes(
+				ctx, req, store, svc, opts, filter, selector)
+		}
+
+		objMap, next, err := getVolumesPage(
+			ctx, req, store, svc, opts, filter, selector, page.Limit, tok)
+		if err != nil {
+			return nil, err
+		}
+		return &types.PagedResult{Result: objMap, Continue: next.String()}, nil
 	}
 
 	return httputils.WriteTask(
@@ -214,14 +361,10 @@ func getFilteredVolumes(
 	store types.Store,
 	storSvc types.StorageService,
 	opts *types.VolumesOpts,
-	filter *types.Filter) (types.VolumeMap, error) {
+	filter *types.Filter,
+	selector types.LabelSelector) (types.VolumeMap, error) {
 
-	var (
-		filterOp    types.FilterOperator
-		filterLeft  string
-		filterRight string
-		objMap      = types.VolumeMap{}
-	)
+	objMap := types.VolumeMap{}
 
 	iid, iidOK := context.InstanceID(ctx)
 	if opts.Attachments.RequiresInstanceID() && !iidOK {
@@ -235,47 +378,142 @@ func getFilteredVolumes(
 		return nil, err
 	}
 
-	if filter != nil {
-		filterOp = filter.Op
-		filterLeft = strings.ToLower(filter.Left)
-		filterRight = strings.ToLower(filter.Right)
+	for _, obj := range objs {
+		ok, err := includeVolume(
+			ctx, req, store, iid, obj, opts, filter, selector)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			objMap[obj.ID] = obj
+		}
 	}
 
-	for _, obj := range objs {
+	return objMap, nil
+}
 
-		lf := log.Fields{
-			"attachments": opts.Attachments,
-			"volumeID":    obj.ID,
-			"volumeName":  obj.Name,
-		}
+// getVolumesPage returns a page of a service's volumes. The volumes are paged
+// by the driver if it implements types.StorageDriverWithPaging, otherwise all
+// of the service's volumes are listed and then paged by ID.
+func getVolumesPage(
+	ctx types.Context,
+	req *http.Request,
+	store types.Store,
+	storSvc types.StorageService,
+	opts *types.VolumesOpts,
+	filter *types.Filter,
+	selector types.LabelSelector,
+	limit int,
+	tok *paging.Token) (types.VolumeMap, *paging.Token, error) {
 
-		if filterOp == types.FilterEqualityMatch && filterLeft == "name" {
-			ctx.WithFields(lf).Debug("checking name filter")
-			if !strings.EqualFold(obj.Name, filterRight) {
-				ctx.WithFields(lf).Debug("omitted volume due to name filter")
-				continue
-			}
-		}
+	if pd, ok := storSvc.Driver().(types.StorageDriverWithPaging); ok {
 
-		if !handleVolAttachments(ctx, lf, iid, obj, opts.Attachments) {
-			continue
+		iid, iidOK := context.InstanceID(ctx)
+		if opts.Attachments.RequiresInstanceID() && !iidOK {
+			return nil, nil, utils.NewMissingInstanceIDError(storSvc.Name())
 		}
 
-		if OnVolume != nil {
-			ctx.WithFields(lf).Debug("invoking OnVolume handler")
-			ok, err := OnVolume(ctx, req, store, obj)
-			if err != nil {
-				return nil, err
-			}
-			if !ok {
-				continue
-			}
+		var (
+			objs   []*types.Volume
+			objMap = types.VolumeMap{}
+		)
+
+		next, err := paging.DriverPage(
+			limit,
+			tok,
+			func(pageToken string, size int) (int, string, error) {
+				ctx.WithFields(log.Fields{
+					"attachments": opts.Attachments,
+					"limit":       size,
+					"pageToken":   pageToken,
+				}).Debug("querying volumes page")
+				var (
+					next string
+					err  error
+				)
+				objs, next, err = pd.VolumesPage(
+					ctx, opts, &types.PageOpts{
+						Limit:    size,
+						Continue: pageToken,
+					})
+				return len(objs), next, err
+			},
+			func(i int) (bool, error) {
+				ok, err := includeVolume(
+					ctx, req, store, iid, objs[i], opts, filter, selector)
+				if ok {
+					objMap[objs[i].ID] = objs[i]
+				}
+				return ok, err
+			})
+
+		if err != types.ErrNotImplemented {
+			return objMap, next, err
 		}
 
-		objMap[obj.ID] = obj
+		ctx.Debug("driver does not implement volume paging")
 	}
 
-	return objMap, nil
+	objMap, err := getFilteredVolumes(
+		ctx, req, store, storSvc, opts, filter, selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(objMap))
+	for id := range objMap {
+		ids = append(ids, id)
+	}
+
+	ids, next := paging.ServerPage(limit, tok, ids)
+
+	page := types.VolumeMap{}
+	for _, id := range ids {
+		page[id] = objMap[id]
+	}
+
+	return page, next, nil
+}
+
+func includeVolume(
+	ctx types.Context,
+	req *http.Request,
+	store types.Store,
+	iid *types.InstanceID,
+	obj *types.Volume,
+	opts *types.VolumesOpts,
+	filter *types.Filter,
+	selector types.LabelSelector) (bool, error) {
+
+	lf := log.Fields{
+		"attachments": opts.Attachments,
+		"volumeID":    obj.ID,
+		"volumeName":  obj.Name,
+	}
+
+	if !selector.Matches(obj.Labels) {
+		ctx.WithFields(lf).Debug("omitted volume due to label selector")
+		return false, nil
+	}
+
+	if !handleVolAttachments(ctx, lf, iid, obj, opts.Attachments) {
+		return false, nil
+	}
+
+	// the filter is checked after the attachments are handled so that
+	// the attachment keys are matched against the attachments returned
+	// to the client
+	if filter != nil && !filters.MatchVolume(filter, obj) {
+		ctx.WithFields(lf).Debug("omitted volume due to filter")
+		return false, nil
+	}
+
+	if OnVolume != nil {
+		ctx.WithFields(lf).Debug("invoking OnVolume handler")
+		return OnVolume(ctx, req, store, obj)
+	}
+
+	return true, nil
 }
 
 func (r *router) volumeInspect(
@@ -313,11 +551,15 @@ func (r *router) volumeInspect(
 				ctx.Debug("driver is StorageDriverVolInspectByName")
 				vol, err = sd.VolumeInspectByName(
 					ctx, volID, opts)
-				if err != nil {
+				if err != nil && err != types.ErrNotImplemented {
 					return nil, err
 				}
 			} else {
-				ctx.Debug("driver is not StorageDriverVolInspectByName")
+				err = types.ErrNotImplemented
+			}
+
+			if err == types.ErrNotImplemented {
+				ctx.Debug("driver does not implement VolumeInspectByName")
 				vols, err := svc.Driver().Volumes(
 					ctx,
 					&types.VolumesOpts{
@@ -405,6 +647,12 @@ func (r *router) volumeCreate(
 
 	service := context.MustService(ctx)
 
+	if store.GetBool("encrypted") {
+		if err := services.RequireEncryption(ctx, service); err != nil {
+			return err
+		}
+	}
+
 	run := func(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
@@ -417,6 +665,7 @@ func (r *router) volumeCreate(
 			Type:             store.GetStringPtr("type"),
 			Encrypted:        store.GetBoolPtr("encrypted"),
 			EncryptionKey:    store.GetStringPtr("encryptionKey"),
+			Labels:           store.GetStringMap("labels"),
 			Opts:             store,
 		}
 		fields := map[string]interface{}{
@@ -440,6 +689,9 @@ func (r *router) volumeCreate(
 		if opts.Type != nil {
 			fields["type"] = &opts.Type
 		}
+		if opts.Labels != nil {
+			fields["labels"] = opts.Labels
+		}
 		ctx.WithFields(fields).Debug("creating volume")
 
 		v, err := svc.Driver().VolumeCreate(ctx, volumeName, opts)
@@ -465,6 +717,12 @@ func (r *router) volumeCreate(
 			v.AttachmentState = types.VolumeAvailable
 		}
 
+		services.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeCreate,
+			VolumeID:  v.ID,
+			Result:    v,
+		})
+
 		return v, nil
 	}
 
@@ -512,9 +770,20 @@ func (r *router) volumeCopy(
 		if v.AttachmentState == 0 {
 			v.AttachmentState = types.VolumeAvailable
 		}
+
+		services.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeCopy,
+			VolumeID:  v.ID,
+			Result:    v,
+		})
+
 		return v, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.VolumeLockKey(service.Name(), store.GetString("volumeID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -536,13 +805,30 @@ func (r *router) volumeSnapshot(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		return svc.Driver().VolumeSnapshot(
+		s, err := svc.Driver().VolumeSnapshot(
 			ctx,
 			store.GetString("volumeID"),
 			store.GetString("snapshotName"),
 			store)
+
+		if err != nil {
+			return nil, err
+		}
+
+		services.EventEmit(ctx, &types.Event{
+			Operation:  types.EventSnapshotCreate,
+			VolumeID:   store.GetString("volumeID"),
+			SnapshotID: s.ID,
+			Result:     s,
+		})
+
+		return s, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.VolumeLockKey(service.Name(), store.GetString("volumeID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -552,6 +838,69 @@ func (r *router) volumeSnapshot(
 		http.StatusCreated)
 }
 
+func (r *router) volumeResize(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	service := context.MustService(ctx)
+
+	run := func(
+		ctx types.Context,
+		svc types.StorageService) (interface{}, error) {
+
+		sd, ok := svc.Driver().(types.StorageDriverWithResize)
+		if !ok {
+			ctx.Debug("driver is not StorageDriverWithResize")
+			return nil, types.ErrNotImplemented
+		}
+
+		v, err := sd.VolumeResize(
+			ctx,
+			store.GetString("volumeID"),
+			&types.VolumeResizeOpts{
+				Size:  store.GetInt64("size"),
+				Force: store.GetBool("force"),
+				Opts:  store,
+			})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if OnVolume != nil {
+			ok, err := OnVolume(ctx, req, store, v)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, utils.NewNotFoundError(v.ID)
+			}
+		}
+
+		services.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeResize,
+			VolumeID:  v.ID,
+			Result:    v,
+		})
+
+		return v, nil
+	}
+
+	ctx = services.WithLocks(
+		ctx,
+		services.VolumeLockKey(service.Name(), store.GetString("volumeID")))
+
+	return httputils.WriteTask(
+		ctx,
+		r.config,
+		w,
+		store,
+		service.TaskEnqueue(ctx, run, schema.VolumeSchema),
+		http.StatusOK)
+}
+
 func (r *router) volumeAttach(
 	ctx types.Context,
 	w http.ResponseWriter,
@@ -573,6 +922,7 @@ func (r *router) volumeAttach(
 			&types.VolumeAttachOpts{
 				NextDevice: store.GetStringPtr("nextDeviceName"),
 				Force:      store.GetBool("force"),
+				ReadOnly:   store.GetBool("readOnly"),
 				Opts:       store,
 			})
 
@@ -594,12 +944,22 @@ func (r *router) volumeAttach(
 			v.AttachmentState = types.VolumeAttached
 		}
 
+		services.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeAttach,
+			VolumeID:  v.ID,
+			Result:    v,
+		})
+
 		return &types.VolumeAttachResponse{
 			Volume:      v,
 			AttachToken: attTokn,
 		}, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.VolumeLockKey(service.Name(), store.GetString("volumeID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -637,6 +997,10 @@ func (r *router) volumeDetach(
 		}
 
 		if v == nil {
+			services.EventEmit(ctx, &types.Event{
+				Operation: types.EventVolumeDetach,
+				VolumeID:  store.GetString("volumeID"),
+			})
 			return nil, nil
 		}
 
@@ -654,9 +1018,19 @@ func (r *router) volumeDetach(
 			v.AttachmentState = types.VolumeAvailable
 		}
 
+		services.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeDetach,
+			VolumeID:  v.ID,
+			Result:    v,
+		})
+
 		return v, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.VolumeLockKey(service.Name(), store.GetString("volumeID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -680,6 +1054,11 @@ func (r *router) volumeDetachAll(
 		replyRWL                        = &sync.Mutex{}
 	)
 
+	// the volumes are listed with the driver instead of read from the volume
+	// cache so that volumes attached since the cache was refreshed are also
+	// detached
+	store.Set("fresh", true)
+
 	for service := range services.StorageServices(ctx) {
 
 		run := func(
@@ -715,13 +1094,20 @@ func (r *router) volumeDetachAll(
 			}()
 
 			for _, volume := range volumes {
-				v, err := driver.VolumeDetach(
+				unlock, err := services.Lock(
+					ctx, services.VolumeLockKey(svc.Name(), volume.ID))
+				if err != nil {
+					return nil, err
+				}
+				v, err := detachAll(
 					ctx,
+					driver,
 					volume.ID,
 					&types.VolumeDetachOpts{
 						Force: store.GetBool("force"),
 						Opts:  store,
 					})
+				unlock()
 				if err != nil {
 					return nil, err
 				}
@@ -748,6 +1134,12 @@ func (r *router) volumeDetachAll(
 					v.AttachmentState = types.VolumeAvailable
 				}
 
+				services.EventEmit(ctx, &types.Event{
+					Operation: types.EventVolumeDetach,
+					VolumeID:  v.ID,
+					Result:    v,
+				})
+
 				volumeMap[v.ID] = v
 			}
 
@@ -789,6 +1181,11 @@ func (r *router) volumeDetachAllForService(
 		return utils.NewMissingInstanceIDError(service.Name())
 	}
 
+	// the volumes are listed with the driver instead of read from the volume
+	// cache so that volumes attached since the cache was refreshed are also
+	// detached
+	store.Set("fresh", true)
+
 	var reply types.VolumeMap = map[string]*types.Volume{}
 
 	run := func(
@@ -803,13 +1200,20 @@ func (r *router) volumeDetachAllForService(
 		}
 
 		for _, volume := range volumes {
-			v, err := driver.VolumeDetach(
+			unlock, err := services.Lock(
+				ctx, services.VolumeLockKey(svc.Name(), volume.ID))
+			if err != nil {
+				return nil, utils.NewBatchProcessErr(reply, err)
+			}
+			v, err := detachAll(
 				ctx,
+				driver,
 				volume.ID,
 				&types.VolumeDetachOpts{
 					Force: store.GetBool("force"),
 					Opts:  store,
 				})
+			unlock()
 			if err != nil {
 				return nil, utils.NewBatchProcessErr(reply, err)
 			}
@@ -836,6 +1240,12 @@ func (r *router) volumeDetachAllForService(
 				v.AttachmentState = types.VolumeAvailable
 			}
 
+			services.EventEmit(ctx, &types.Event{
+				Operation: types.EventVolumeDetach,
+				VolumeID:  v.ID,
+				Result:    v,
+			})
+
 			reply[v.ID] = v
 		}
 
@@ -863,15 +1273,30 @@ func (r *router) volumeRemove(
 		ctx types.Context,
 		svc types.StorageService) (interface{}, error) {
 
-		return nil, svc.Driver().VolumeRemove(
+		err := svc.Driver().VolumeRemove(
 			ctx,
 			store.GetString("volumeID"),
 			&types.VolumeRemoveOpts{
 				Force: store.GetBool("force"),
 				Opts:  store,
 			})
+
+		if err != nil {
+			return nil, err
+		}
+
+		services.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeRemove,
+			VolumeID:  store.GetString("volumeID"),
+		})
+
+		return nil, nil
 	}
 
+	ctx = services.WithLocks(
+		ctx,
+		services.VolumeLockKey(service.Name(), store.GetString("volumeID")))
+
 	return httputils.WriteTask(
 		ctx,
 		r.config,
@@ -881,6 +1306,32 @@ func (r *router) volumeRemove(
 		http.StatusNoContent)
 }
 
+// detachAll detaches a volume for a request to detach all volumes. A driver
+// that implements types.StorageDriverWithDetachAll detaches every one of the
+// volume's attachments to the instance, otherwise the volume is detached with
+// VolumeDetach.
+func detachAll(
+	ctx types.Context,
+	driver types.StorageDriver,
+	volumeID string,
+	opts *types.VolumeDetachOpts) (*types.Volume, error) {
+
+	if dd, ok := driver.(types.StorageDriverWithDetachAll); ok {
+		err := dd.VolumeDetachAll(ctx, volumeID, opts)
+		if err == nil {
+			return driver.VolumeInspect(
+				ctx, volumeID, &types.VolumeInspectOpts{
+					Attachments: types.VolAttReqTrue,
+					Opts:        opts.Opts,
+				})
+		}
+		if err != types.ErrNotImplemented {
+			return nil, err
+		}
+	}
+	return driver.VolumeDetach(ctx, volumeID, opts)
+}
+
 func parseFilter(store types.Store) (*types.Filter, error) {
 	if !store.IsSet("filter") {
 		return nil, nil
@@ -892,3 +1343,15 @@ func parseFilter(store types.Store) (*types.Filter, error) {
 	}
 	return filter, nil
 }
+
+func parseLabelSelector(store types.Store) (types.LabelSelector, error) {
+	if !store.IsSet("labelSelector") {
+		return nil, nil
+	}
+	lsz := store.GetString("labelSelector")
+	selector, err := labels.ParseSelector(lsz)
+	if err != nil {
+		return nil, utils.NewBadLabelSelectorErr(lsz, err)
+	}
+	return selector, nil
+}
@@ -27,6 +27,7 @@ import (
 
 	// import and load the routers
 	_ "github.com/codedellemc/libstorage/api/server/router/help"
+	_ "github.com/codedellemc/libstorage/api/server/router/metrics"
 	_ "github.com/codedellemc/libstorage/api/server/router/root"
 	_ "github.com/codedellemc/libstorage/api/server/router/service"
 	_ "github.com/codedellemc/libstorage/api/server/router/snapshot"
@@ -58,6 +59,8 @@ type server struct {
 	logHTTPRequests  bool
 	logHTTPResponses bool
 
+	idempotencyTTL time.Duration
+
 	stdOut io.WriteCloser
 	stdErr io.WriteCloser
 }
@@ -182,6 +185,11 @@ func newServer(goCtx gocontext.Context, config gofig.Config) (*server, error) {
 		s.stdErr = getLogIO(s.ctx, logConfig.Stderr, types.ConfigLogStderr)
 	}
 
+	if ttl, err := time.ParseDuration(
+		config.GetString(types.ConfigServerIdempotencyTTL)); err == nil {
+		s.idempotencyTTL = ttl
+	}
+
 	s.initGlobalMiddleware()
 
 	if err := s.initRouters(); err != nil {
@@ -303,6 +311,8 @@ func (s *server) close() error {
 		srv.ctx.Debug("shutdown endpoint complete")
 	}
 
+	services.Close(s.ctx)
+
 	if s.stdOut != nil {
 		if err := s.stdOut.Close(); err != nil {
 			log.Error(err)
@@ -0,0 +1,568 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	gocontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/rpc"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// newGRPCServer returns a server for an endpoint that serves the gRPC API.
+// The server handles a call by dispatching the HTTP request to which the
+// call's method maps to the endpoint's mux, so the calls are handled by the
+// same routes and middleware as the HTTP requests.
+func (s *server) newGRPCServer(
+	proto, laddr string, tlsConfig *types.TLSConfig) (*HTTPServer, error) {
+
+	l, err := net.Listen(proto, laddr)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(
+			opts, grpc.Creds(credentials.NewTLS(&tlsConfig.Config)))
+	}
+
+	host := fmt.Sprintf("%s://%s", proto, laddr)
+	ctx := s.ctx.WithValue(context.HostKey, host)
+	ctx = ctx.WithValue(context.TLSKey, tlsConfig != nil)
+
+	srv := &HTTPServer{
+		srv:  &http.Server{Addr: l.Addr().String()},
+		grpc: grpc.NewServer(opts...),
+		l:    l,
+		ctx:  ctx,
+	}
+	rpc.RegisterAPIServer(srv.grpc, &grpcHandler{srv})
+
+	return srv, nil
+}
+
+// grpcHandler handles the gRPC calls with the endpoint's mux.
+type grpcHandler struct {
+	srv *HTTPServer
+}
+
+// handle handles a call to the named method by serving the HTTP request to
+// which the call maps with the endpoint's mux and decoding the response into
+// the reply.
+func (h *grpcHandler) handle(
+	ctx gocontext.Context,
+	name string,
+	req rpc.Request,
+	reply rpc.Reply) error {
+
+	m := rpc.LookupMethod(name)
+	if m == nil {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", name)
+	}
+
+	httpMethod, u, body, err := m.HTTPRequest(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var r io.Reader
+	if len(body) > 0 {
+		r = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequest(httpMethod, u.String(), r)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.Host = h.srv.srv.Addr
+
+	// the libStorage headers, such as the transaction, instance ID, and
+	// local devices headers, are sent as the call's metadata
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, v := range md {
+			if strings.HasPrefix(k, "libstorage-") ||
+				k == strings.ToLower(types.AuthorizationHeader) {
+				httpReq.Header[http.CanonicalHeaderKey(k)] = v
+			}
+		}
+		if v := md[":authority"]; len(v) > 0 {
+			httpReq.Host = v[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			httpReq.RemoteAddr = p.Addr.String()
+		}
+		if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state := ti.State
+			httpReq.TLS = &state
+		}
+	}
+
+	w := newGRPCResponseWriter()
+	h.srv.srv.Handler.ServeHTTP(w, httpReq)
+
+	header := metadata.MD{}
+	for k, v := range w.header {
+		switch k {
+		case "Content-Type", "Content-Length":
+			continue
+		}
+		header[strings.ToLower(k)] = v
+	}
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		return err
+	}
+
+	resBody := bytes.TrimSpace(w.body.Bytes())
+	if w.status > 299 {
+		return rpc.NewError(w.status, resBody)
+	}
+	if err := rpc.DecodeReply(reply, w.header, resBody); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// grpcResponseWriter is the response writer for the HTTP requests to which
+// the gRPC calls map.
+type grpcResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newGRPCResponseWriter() *grpcResponseWriter {
+	return &grpcResponseWriter{header: http.Header{}}
+}
+
+func (w *grpcResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *grpcResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(p)
+}
+
+func (w *grpcResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (h *grpcHandler) Root(
+	ctx gocontext.Context,
+	req *rpc.RootRequest) (*rpc.RootReply, error) {
+
+	reply := &rpc.RootReply{}
+	if err := h.handle(ctx, "Root", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) Services(
+	ctx gocontext.Context,
+	req *rpc.ServicesRequest) (*rpc.ServicesReply, error) {
+
+	reply := &rpc.ServicesReply{}
+	if err := h.handle(ctx, "Services", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) ServiceInspect(
+	ctx gocontext.Context,
+	req *rpc.ServiceInspectRequest) (*rpc.ServiceInfo, error) {
+
+	reply := &rpc.ServiceInfo{}
+	if err := h.handle(ctx, "ServiceInspect", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) Volumes(
+	ctx gocontext.Context,
+	req *rpc.VolumesRequest) (*rpc.VolumesReply, error) {
+
+	reply := &rpc.VolumesReply{}
+	if err := h.handle(ctx, "Volumes", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumesByService(
+	ctx gocontext.Context,
+	req *rpc.VolumesByServiceRequest) (*rpc.VolumesByServiceReply, error) {
+
+	reply := &rpc.VolumesByServiceReply{}
+	if err := h.handle(ctx, "VolumesByService", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeInspect(
+	ctx gocontext.Context,
+	req *rpc.VolumeInspectRequest) (*rpc.Volume, error) {
+
+	reply := &rpc.Volume{}
+	if err := h.handle(ctx, "VolumeInspect", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeCreate(
+	ctx gocontext.Context,
+	req *rpc.VolumeCreateRequest) (*rpc.Volume, error) {
+
+	reply := &rpc.Volume{}
+	if err := h.handle(ctx, "VolumeCreate", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeCreateFromSnapshot(
+	ctx gocontext.Context,
+	req *rpc.VolumeCreateFromSnapshotRequest) (*rpc.Volume, error) {
+
+	reply := &rpc.Volume{}
+	err := h.handle(ctx, "VolumeCreateFromSnapshot", req, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeCopy(
+	ctx gocontext.Context,
+	req *rpc.VolumeCopyRequest) (*rpc.Volume, error) {
+
+	reply := &rpc.Volume{}
+	if err := h.handle(ctx, "VolumeCopy", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeRemove(
+	ctx gocontext.Context,
+	req *rpc.VolumeRemoveRequest) (*rpc.VolumeRemoveReply, error) {
+
+	reply := &rpc.VolumeRemoveReply{}
+	if err := h.handle(ctx, "VolumeRemove", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeAttach(
+	ctx gocontext.Context,
+	req *rpc.VolumeAttachRequest) (*rpc.VolumeAttachReply, error) {
+
+	reply := &rpc.VolumeAttachReply{}
+	if err := h.handle(ctx, "VolumeAttach", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeDetach(
+	ctx gocontext.Context,
+	req *rpc.VolumeDetachRequest) (*rpc.Volume, error) {
+
+	reply := &rpc.Volume{}
+	if err := h.handle(ctx, "VolumeDetach", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeDetachAll(
+	ctx gocontext.Context,
+	req *rpc.VolumeDetachAllRequest) (*rpc.VolumesReply, error) {
+
+	reply := &rpc.VolumesReply{}
+	if err := h.handle(ctx, "VolumeDetachAll", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeDetachAllForService(
+	ctx gocontext.Context,
+	req *rpc.VolumeDetachAllForServiceRequest) (*rpc.VolumeMap, error) {
+
+	reply := &rpc.VolumeMap{}
+	err := h.handle(ctx, "VolumeDetachAllForService", req, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeResize(
+	ctx gocontext.Context,
+	req *rpc.VolumeResizeRequest) (*rpc.Volume, error) {
+
+	reply := &rpc.Volume{}
+	if err := h.handle(ctx, "VolumeResize", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeSnapshot(
+	ctx gocontext.Context,
+	req *rpc.VolumeSnapshotRequest) (*rpc.Snapshot, error) {
+
+	reply := &rpc.Snapshot{}
+	if err := h.handle(ctx, "VolumeSnapshot", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) Snapshots(
+	ctx gocontext.Context,
+	req *rpc.SnapshotsRequest) (*rpc.SnapshotsReply, error) {
+
+	reply := &rpc.SnapshotsReply{}
+	if err := h.handle(ctx, "Snapshots", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) SnapshotsByService(
+	ctx gocontext.Context,
+	req *rpc.SnapshotsByServiceRequest) (*rpc.SnapshotsByServiceReply, error) {
+
+	reply := &rpc.SnapshotsByServiceReply{}
+	if err := h.handle(ctx, "SnapshotsByService", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) SnapshotInspect(
+	ctx gocontext.Context,
+	req *rpc.SnapshotInspectRequest) (*rpc.Snapshot, error) {
+
+	reply := &rpc.Snapshot{}
+	if err := h.handle(ctx, "SnapshotInspect", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) SnapshotRemove(
+	ctx gocontext.Context,
+	req *rpc.SnapshotRemoveRequest) (*rpc.SnapshotRemoveReply, error) {
+
+	reply := &rpc.SnapshotRemoveReply{}
+	if err := h.handle(ctx, "SnapshotRemove", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) SnapshotCopy(
+	ctx gocontext.Context,
+	req *rpc.SnapshotCopyRequest) (*rpc.Snapshot, error) {
+
+	reply := &rpc.Snapshot{}
+	if err := h.handle(ctx, "SnapshotCopy", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) Tasks(
+	ctx gocontext.Context,
+	req *rpc.TasksRequest) (*rpc.TasksReply, error) {
+
+	reply := &rpc.TasksReply{}
+	if err := h.handle(ctx, "Tasks", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) TaskInspect(
+	ctx gocontext.Context,
+	req *rpc.TaskInspectRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "TaskInspect", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) TaskCancel(
+	ctx gocontext.Context,
+	req *rpc.TaskCancelRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "TaskCancel", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeCreateAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeCreateRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeCreateAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeCreateFromSnapshotAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeCreateFromSnapshotRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	err := h.handle(ctx, "VolumeCreateFromSnapshotAsync", req, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeCopyAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeCopyRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeCopyAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeRemoveAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeRemoveRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeRemoveAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeAttachAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeAttachRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeAttachAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeDetachAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeDetachRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeDetachAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeDetachAllAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeDetachAllRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeDetachAllAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeDetachAllForServiceAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeDetachAllForServiceRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	err := h.handle(ctx, "VolumeDetachAllForServiceAsync", req, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeResizeAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeResizeRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeResizeAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) VolumeSnapshotAsync(
+	ctx gocontext.Context,
+	req *rpc.VolumeSnapshotRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "VolumeSnapshotAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) SnapshotRemoveAsync(
+	ctx gocontext.Context,
+	req *rpc.SnapshotRemoveRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "SnapshotRemoveAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) SnapshotCopyAsync(
+	ctx gocontext.Context,
+	req *rpc.SnapshotCopyRequest) (*rpc.Task, error) {
+
+	reply := &rpc.Task{}
+	if err := h.handle(ctx, "SnapshotCopyAsync", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gofigCore "github.com/akutz/gofig"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/stretchr/testify/assert"
+	gocontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/rpc"
+	"github.com/codedellemc/libstorage/api/server/httputils"
+	"github.com/codedellemc/libstorage/api/server/services"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+const testHeader = "Libstorage-Test"
+
+// testRouter has routes that reply with the values the global middleware
+// and the request provide to a handler.
+type testRouter struct {
+	routes []types.Route
+}
+
+func (r *testRouter) Name() string {
+	return "test-router"
+}
+
+func (r *testRouter) Init(config gofig.Config) {
+	r.routes = []types.Route{
+		httputils.NewGetRoute(
+			"volumeInspect", "/volumes/{service}/{volumeID}", r.volume),
+		httputils.NewPostRoute(
+			"volumeCreate", "/volumes/{service}", r.volumeCreate),
+	}
+}
+
+func (r *testRouter) Routes() []types.Route {
+	return r.routes
+}
+
+func (r *testRouter) volume(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	volumeID := store.GetString("volumeID")
+	if volumeID == "missing" {
+		return utils.NewNotFoundError(volumeID)
+	}
+
+	_, rpcCall := metadata.FromIncomingContext(req.Context())
+	w.Header().Set(testHeader, volumeID)
+	httputils.WriteJSON(w, http.StatusOK, &types.Volume{
+		ID:   volumeID,
+		Name: store.GetString("service"),
+		Fields: map[string]string{
+			"tx":         context.MustTransaction(ctx).ID.String(),
+			"instanceID": req.Header.Get(types.InstanceIDHeader),
+			"byName":     req.URL.Query().Get("byName"),
+			"remote":     req.RemoteAddr,
+			"rpc":        map[bool]string{true: "true"}[rpcCall],
+		},
+	})
+	return nil
+}
+
+func (r *testRouter) volumeCreate(
+	ctx types.Context,
+	w http.ResponseWriter,
+	req *http.Request,
+	store types.Store) error {
+
+	cr := &types.VolumeCreateRequest{}
+	if err := json.NewDecoder(req.Body).Decode(cr); err != nil {
+		return err
+	}
+	vol := &types.Volume{
+		ID:     cr.Name,
+		Name:   cr.Name,
+		Labels: cr.Labels,
+	}
+	if cr.Size != nil {
+		vol.Size = *cr.Size
+	}
+	httputils.WriteJSON(w, http.StatusOK, vol)
+	return nil
+}
+
+// newTestServer returns a libStorage server with the test routes and the
+// global middleware and a gRPC client connected to the server's endpoint.
+func newTestServer(t *testing.T) (
+	*httptest.Server, rpc.APIClient, func()) {
+
+	name := randomServerName()
+	ctx := context.Background().WithValue(context.ServerKey, name)
+	config := gofigCore.New()
+	config.Set(types.ConfigServices, map[string]interface{}{})
+	s := &server{
+		name:   name,
+		ctx:    ctx,
+		config: config,
+	}
+	if err := services.Init(ctx, s.config); err != nil {
+		t.Fatal(err)
+	}
+	s.initGlobalMiddleware()
+	r := &testRouter{}
+	r.Init(s.config)
+	s.addRouter(r)
+	s.initRouteMiddleware()
+
+	mux := s.createMux(ctx)
+	hs := httptest.NewServer(mux)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &HTTPServer{
+		srv:  &http.Server{Addr: l.Addr().String(), Handler: mux},
+		grpc: grpc.NewServer(),
+		l:    l,
+		ctx:  ctx,
+	}
+	rpc.RegisterAPIServer(srv.grpc, &grpcHandler{srv})
+	go srv.Serve()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hs, rpc.NewAPIClient(conn), func() {
+		conn.Close()
+		srv.Close()
+		hs.Close()
+		services.Close(ctx)
+	}
+}
+
+func newTestHeaders(t *testing.T) http.Header {
+	tx, err := types.NewTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	txText, err := tx.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	iid := &types.InstanceID{ID: "i-000", Driver: "vfs"}
+	iidText, err := iid.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return http.Header{
+		types.TransactionHeader: []string{string(txText)},
+		types.InstanceIDHeader:  []string{string(iidText)},
+	}
+}
+
+func newTestMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for k, v := range header {
+		md[strings.ToLower(k)] = v
+	}
+	return md
+}
+
+func TestGRPCHandlerParity(t *testing.T) {
+	hs, client, closeServer := newTestServer(t)
+	defer closeServer()
+
+	header := newTestHeaders(t)
+
+	req, err := http.NewRequest(
+		"GET", hs.URL+"/volumes/vfs/vfs-000?attachments=0&byName", nil)
+	assert.NoError(t, err)
+	req.Header = header
+	res, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer res.Body.Close()
+	httpVol := &types.Volume{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(httpVol))
+
+	var resMD metadata.MD
+	rpcVol, err := client.VolumeInspect(
+		metadata.NewOutgoingContext(
+			gocontext.Background(), newTestMetadata(header)),
+		&rpc.VolumeInspectRequest{
+			Service:  "vfs",
+			VolumeId: "vfs-000",
+			ByName:   true,
+		},
+		grpc.Header(&resMD))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// the call is served by the same route with the same middleware and
+	// request headers as the HTTP request
+	assert.Equal(t, httpVol.ID, rpcVol.Id)
+	assert.Equal(t, httpVol.Name, rpcVol.Name)
+	for _, k := range []string{"tx", "instanceID", "byName"} {
+		assert.Equal(t, httpVol.Fields[k], rpcVol.Fields[k], k)
+	}
+	assert.NotEmpty(t, rpcVol.Fields["tx"])
+	assert.NotEmpty(t, rpcVol.Fields["instanceID"])
+	assert.NotEmpty(t, rpcVol.Fields["remote"])
+
+	// the handler's request has the call's context
+	assert.Empty(t, httpVol.Fields["rpc"])
+	assert.Equal(t, "true", rpcVol.Fields["rpc"])
+
+	// the response headers are the call's header metadata
+	for _, k := range []string{testHeader, types.ServerNameHeader} {
+		assert.Equal(t,
+			[]string{res.Header.Get(k)}, resMD[strings.ToLower(k)],
+			k)
+	}
+	assert.NotEmpty(t, res.Header.Get(types.ServerNameHeader))
+}
+
+func TestGRPCHandlerPayload(t *testing.T) {
+	hs, client, closeServer := newTestServer(t)
+	defer closeServer()
+
+	body := []byte(`{"name":"a","size":10,"labels":{"env":"test"}}`)
+	res, err := http.Post(
+		hs.URL+"/volumes/vfs", "application/json", bytes.NewReader(body))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer res.Body.Close()
+	httpBody, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	m, req, err := rpc.NewRequest("POST", "/volumes/vfs", "", body)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	vol, err := client.VolumeCreate(
+		gocontext.Background(), req.(*rpc.VolumeCreateRequest))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "VolumeCreate", m.Name())
+	rpcBody, err := rpc.EncodeReply(vol, http.Header{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(httpBody), string(rpcBody))
+}
+
+func TestGRPCHandlerError(t *testing.T) {
+	hs, client, closeServer := newTestServer(t)
+	defer closeServer()
+
+	res, err := http.Get(hs.URL + "/volumes/vfs/missing")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer res.Body.Close()
+	httpBody, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	_, err = client.VolumeInspect(gocontext.Background(),
+		&rpc.VolumeInspectRequest{Service: "vfs", VolumeId: "missing"})
+	httpStatus, rpcBody, ok := rpc.HTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, res.StatusCode, httpStatus)
+	assert.JSONEq(t, string(httpBody), string(rpcBody))
+
+	// a call that does not map to a route of the mux is not found
+	_, err = client.SnapshotInspect(gocontext.Background(),
+		&rpc.SnapshotInspectRequest{Service: "vfs", SnapshotId: "s-000"})
+	httpStatus, _, ok = rpc.HTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpStatus)
+}
@@ -10,16 +10,20 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/akutz/goof"
 	"github.com/akutz/gotil"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
 
 	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/rpc"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
+	apicnfg "github.com/codedellemc/libstorage/api/utils/config"
 )
 
 const defaultEndpointConfig = `
@@ -34,8 +38,9 @@ func (s *server) initDefaultEndpoint() error {
 
 	var endpointConfig string
 
-	if host := s.config.GetString(types.ConfigHost); host != "" {
+	if hosts := apicnfg.Hosts(s.config); len(hosts) > 0 {
 
+		host := hosts[0]
 		s.ctx.WithField("host", host).Info("initializing default endpoint")
 		endpointConfig = fmt.Sprintf(defaultEndpointConfig, host)
 
@@ -130,9 +135,23 @@ func (s *server) initEndpoints(ctx types.Context) error {
 			return err
 		}
 
+		protocol := s.config.GetString(fmt.Sprintf("%s.protocol", endpoint))
+		logFields["protocol"] = protocol
+
 		ctx.WithFields(logFields).Info("configured endpoint")
 
-		srv, err := s.newHTTPServer(proto, addr, tlsConfig)
+		var srv *HTTPServer
+		switch protocol {
+		case "", "http":
+			srv, err = s.newHTTPServer(proto, addr, tlsConfig)
+		case rpc.Protocol:
+			srv, err = s.newGRPCServer(proto, addr, tlsConfig)
+		default:
+			return goof.WithFields(goof.Fields{
+				"endpoint": endpoint,
+				"protocol": protocol,
+			}, "invalid protocol")
+		}
 		if err != nil {
 			return err
 		}
@@ -168,7 +187,13 @@ func (s *server) makeHTTPHandler(
 	ctx types.Context,
 	route types.Route) http.HandlerFunc {
 
-	return func(w http.ResponseWriter, req *http.Request) {
+	return func(rw http.ResponseWriter, req *http.Request) {
+
+		start := time.Now()
+		w := &statusWriter{ResponseWriter: rw}
+		defer func() {
+			observeRequest(route.GetName(), req.Method, w.status(), start)
+		}()
 
 		w.Header().Set(types.ServerNameHeader, s.name)
 
@@ -263,24 +288,35 @@ func (s *server) newHTTPServer(
 
 // HTTPServer contains an instance of http server and the listener.
 //
-// srv *http.Server, contains configuration to create a http server and a mux
-// router with all api end points.
+// srv  *http.Server, contains configuration to create a http server and a
+// mux router with all api end points.
 //
-// l   net.Listener, is a TCP or Socket listener that dispatches incoming
+// grpc *grpc.Server, is the gRPC server of an endpoint that serves the gRPC
+// API. The server dispatches the calls to the http server's mux router.
+//
+// l    net.Listener, is a TCP or Socket listener that dispatches incoming
 // request to the router.
 type HTTPServer struct {
-	srv *http.Server
-	l   net.Listener
-	ctx types.Context
+	srv  *http.Server
+	grpc *grpc.Server
+	l    net.Listener
+	ctx  types.Context
 }
 
 // Serve starts listening for inbound requests.
 func (s *HTTPServer) Serve() error {
+	if s.grpc != nil {
+		return s.grpc.Serve(s.l)
+	}
 	return s.srv.Serve(s.l)
 }
 
 // Close closes the HTTPServer from listening for the inbound requests.
 func (s *HTTPServer) Close() error {
+	if s.grpc != nil {
+		s.grpc.Stop()
+		return nil
+	}
 	return s.l.Close()
 }
 
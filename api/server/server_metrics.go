@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libstorage_server_http_requests_total",
+		Help: "The number of HTTP requests.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "libstorage_server_http_request_duration_seconds",
+		Help:    "The latency of HTTP requests.",
+		Buckets: append(prometheus.DefBuckets, 30, 60),
+	}, []string{"route", "method", "code"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration)
+}
+
+// observeRequest records the metrics for a completed request.
+func observeRequest(route, method string, code int, start time.Time) {
+	sc := strconv.Itoa(code)
+	httpRequestsTotal.WithLabelValues(route, method, sc).Inc()
+	httpRequestDuration.WithLabelValues(route, method, sc).Observe(
+		time.Since(start).Seconds())
+}
+
+// statusWriter is an http.ResponseWriter that records the response's status
+// code.
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher so that watched tasks may be streamed.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CloseNotify implements http.CloseNotifier. The returned channel never
+// receives a value if the underlying writer is not an http.CloseNotifier.
+func (w *statusWriter) CloseNotify() <-chan bool {
+	if cn, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return cn.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+// status returns the response's status code.
+func (w *statusWriter) status() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
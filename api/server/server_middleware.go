@@ -22,6 +22,9 @@ func (s *server) initGlobalMiddleware() {
 		handlers.NewInstanceIDHandler(services.StorageServices(s.ctx)))
 	s.addGlobalMiddleware(handlers.NewLocalDevicesHandler())
 	s.addGlobalMiddleware(handlers.NewOnRequestHandler())
+	if s.idempotencyTTL > 0 {
+		s.addGlobalMiddleware(handlers.NewIdempotencyHandler(s.idempotencyTTL))
+	}
 }
 
 func (s *server) initRouteMiddleware() {
@@ -7,6 +7,7 @@ import (
 
 	gofig "github.com/akutz/gofig/types"
 	"github.com/akutz/goof"
+	gocontext "golang.org/x/net/context"
 
 	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/types"
@@ -21,6 +22,9 @@ type serviceContainer struct {
 	config          gofig.Config
 	storageServices map[string]types.StorageService
 	taskService     *globalTaskService
+	eventService    *globalEventService
+	lockService     *globalLockService
+	cancel          gocontext.CancelFunc
 }
 
 // Init initializes the types.
@@ -33,12 +37,20 @@ func Init(ctx types.Context, config gofig.Config) error {
 
 	ctx.Info("initializing server services")
 
+	// the services' background work, such as refreshing the volume caches,
+	// runs until the server is closed
+	ctx, cancel := context.WithCancel(ctx)
+
 	sc := &serviceContainer{
 		taskService:     &globalTaskService{name: "global-task-service"},
+		eventService:    &globalEventService{name: "global-event-service"},
+		lockService:     &globalLockService{name: "global-lock-service"},
 		storageServices: map[string]types.StorageService{},
+		cancel:          cancel,
 	}
 
 	if err := sc.Init(ctx, config); err != nil {
+		cancel()
 		return err
 	}
 
@@ -49,6 +61,35 @@ func Init(ctx types.Context, config gofig.Config) error {
 	return nil
 }
 
+// Close delivers the server's queued events and stops the background work
+// of the server's services.
+func Close(ctx types.Context) {
+
+	serverName, ok := context.Server(ctx)
+	if !ok {
+		panic("ctx is missing ServerName")
+	}
+
+	servicesByServerRWL.RLock()
+	defer servicesByServerRWL.RUnlock()
+
+	sc, ok := servicesByServer[serverName]
+	if !ok {
+		return
+	}
+
+	ctx.Info("closing server services")
+
+	// the queued events are delivered before the services' context is
+	// canceled so that a webhook that is being retried is not interrupted
+	if sc.eventService != nil {
+		sc.eventService.Close(ctx)
+	}
+	if sc.cancel != nil {
+		sc.cancel()
+	}
+}
+
 func (sc *serviceContainer) Init(ctx types.Context, config gofig.Config) error {
 	sc.config = config
 
@@ -56,6 +97,14 @@ func (sc *serviceContainer) Init(ctx types.Context, config gofig.Config) error {
 		return err
 	}
 
+	if err := sc.eventService.Init(ctx, config); err != nil {
+		return err
+	}
+
+	if err := sc.lockService.Init(ctx, config); err != nil {
+		return err
+	}
+
 	if err := sc.initStorageServices(ctx); err != nil {
 		return err
 	}
@@ -163,6 +212,39 @@ func getTaskService(ctx types.Context) *globalTaskService {
 	return servicesByServer[serverName].taskService
 }
 
+func getEventService(ctx types.Context) *globalEventService {
+
+	serverName, ok := context.Server(ctx)
+	if !ok {
+		panic("ctx is missing ServerName")
+	}
+
+	servicesByServerRWL.RLock()
+	defer servicesByServerRWL.RUnlock()
+
+	return servicesByServer[serverName].eventService
+}
+
+func getLockService(ctx types.Context) *globalLockService {
+
+	serverName, ok := context.Server(ctx)
+	if !ok {
+		panic("ctx is missing ServerName")
+	}
+
+	servicesByServerRWL.RLock()
+	defer servicesByServerRWL.RUnlock()
+
+	return servicesByServer[serverName].lockService
+}
+
+// EventEmit emits an event to the server's event sinks. The event's time,
+// service, JWT subject, instance ID and transaction ID are set from the
+// context unless they are already set.
+func EventEmit(ctx types.Context, event *types.Event) {
+	getEventService(ctx).EventEmit(ctx, event)
+}
+
 // Tasks returns a channel on which all tasks are received.
 func Tasks(ctx types.Context) <-chan *types.Task {
 	return getTaskService(ctx).Tasks()
@@ -186,6 +268,18 @@ func TaskInspect(ctx types.Context, taskID int) *types.Task {
 	return getTaskService(ctx).TaskInspect(taskID)
 }
 
+// TaskCancel cancels the specified task and returns the task once it is no
+// longer running.
+func TaskCancel(ctx types.Context, taskID int) *types.Task {
+	return getTaskService(ctx).TaskCancel(taskID)
+}
+
+// TaskWatch returns a channel on which a task is received every time its
+// state changes. The channel is closed when the context is done.
+func TaskWatch(ctx types.Context) <-chan *types.Task {
+	return getTaskService(ctx).TaskWatch(ctx)
+}
+
 // TaskWait blocks until the specified task is completed.
 func TaskWait(ctx types.Context, taskID int) {
 	getTaskService(ctx).TaskWait(taskID)
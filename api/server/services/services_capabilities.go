@@ -0,0 +1,122 @@
+package services
+
+import (
+	"time"
+
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+// callCapabilities are the capabilities required by the driver calls. A
+// driver that returns types.ErrNotImplemented from one of these calls does
+// not support the call's capability.
+var callCapabilities = map[string]types.DriverCapability{
+	"VolumeSnapshot":           types.DriverCapabilitySnapshots,
+	"Snapshots":                types.DriverCapabilitySnapshots,
+	"SnapshotInspect":          types.DriverCapabilitySnapshots,
+	"SnapshotRemove":           types.DriverCapabilitySnapshots,
+	"VolumeCopy":               types.DriverCapabilityCopy,
+	"SnapshotCopy":             types.DriverCapabilitySnapshotCopy,
+	"VolumeCreateFromSnapshot": types.DriverCapabilityCreateFromSnapshot,
+}
+
+// unsupportedTTL is how long a capability is treated as unsupported after
+// a driver returns types.ErrNotImplemented from a call that requires it. The
+// next call after that probes the driver again so that a driver that is
+// reconfigured or whose storage platform gains the capability is not
+// rejected until the server restarts.
+var unsupportedTTL = 10 * time.Minute
+
+// probed records the result of a call that requires a capability. A call that
+// returns types.ErrNotImplemented marks the capability unsupported and a call
+// that succeeds marks it no longer unsupported.
+func (d *storageDriverManager) probed(call string, err error) {
+	c, ok := callCapabilities[call]
+	if !ok {
+		return
+	}
+	d.lck.Lock()
+	defer d.lck.Unlock()
+	if err == types.ErrNotImplemented {
+		d.unsupported[c] = time.Now()
+	} else if err == nil {
+		delete(d.unsupported, c)
+	}
+}
+
+// capabilities returns the driver's capabilities. The capabilities of a
+// driver that does not declare them are inferred from the optional
+// interfaces the driver implements and from the calls for which it has
+// recently returned types.ErrNotImplemented. The others are unknown.
+func (d *storageDriverManager) capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+
+	if sd, ok := d.StorageDriver.(types.StorageDriverWithCapabilities); ok {
+		start := time.Now()
+		caps, err := sd.Capabilities(ctx)
+		d.observe("Capabilities", start, err)
+		return caps, err
+	}
+
+	_, resize := d.StorageDriver.(types.StorageDriverWithResize)
+	caps := types.DriverCapabilities{types.DriverCapabilityResize: resize}
+
+	d.lck.RLock()
+	defer d.lck.RUnlock()
+	for c, t := range d.unsupported {
+		if time.Since(t) < unsupportedTTL {
+			caps[c] = false
+		}
+	}
+	return caps, nil
+}
+
+// Capabilities returns the capabilities of the service's driver.
+func Capabilities(
+	ctx types.Context,
+	service types.StorageService) (types.DriverCapabilities, error) {
+
+	d, ok := service.Driver().(interface {
+		capabilities(types.Context) (types.DriverCapabilities, error)
+	})
+	if !ok {
+		return nil, nil
+	}
+	return d.capabilities(ctx)
+}
+
+// RequireCapabilities returns an ErrUnsupportedCapability error if the
+// service's driver does not support one of the capabilities.
+func RequireCapabilities(
+	ctx types.Context,
+	service types.StorageService,
+	caps ...types.DriverCapability) error {
+
+	svcCaps, err := Capabilities(ctx, service)
+	if err != nil {
+		return err
+	}
+	if c := svcCaps.Unsupported(caps...); c != "" {
+		return utils.NewUnsupportedCapabilityErr(service.Name(), c)
+	}
+	return nil
+}
+
+// RequireEncryption returns an ErrUnsupportedCapability error unless the
+// service's driver declares that it supports encryption. Support for
+// encryption is not inferred from the driver's calls since a driver that does
+// not support it may ignore the request and create an unencrypted volume.
+func RequireEncryption(
+	ctx types.Context,
+	service types.StorageService) error {
+
+	svcCaps, err := Capabilities(ctx, service)
+	if err != nil {
+		return err
+	}
+	if !svcCaps[types.DriverCapabilityEncryption] {
+		return utils.NewUnsupportedCapabilityErr(
+			service.Name(), types.DriverCapabilityEncryption)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// fakeCapsDriver is a storage driver that declares its capabilities.
+type fakeCapsDriver struct {
+	types.StorageDriver
+	caps types.DriverCapabilities
+}
+
+func (d *fakeCapsDriver) Name() string {
+	return "fake"
+}
+
+func (d *fakeCapsDriver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return d.caps, nil
+}
+
+// fakeProbeDriver is a storage driver that does not declare its
+// capabilities and that supports copying volumes only when copy is set.
+type fakeProbeDriver struct {
+	types.StorageDriver
+	copy bool
+}
+
+func (d *fakeProbeDriver) Name() string {
+	return "fake"
+}
+
+func (d *fakeProbeDriver) VolumeCopy(
+	ctx types.Context,
+	volumeID, volumeName string,
+	opts types.Store) (*types.Volume, error) {
+	if !d.copy {
+		return nil, types.ErrNotImplemented
+	}
+	return &types.Volume{ID: volumeName}, nil
+}
+
+func newTestCapsService(d types.StorageDriver) *storageService {
+	return &storageService{
+		name:   "s0",
+		driver: newStorageDriverManager("s0", d),
+	}
+}
+
+func TestRequireCapabilitiesDeclared(t *testing.T) {
+	ctx := context.Background()
+	s := newTestCapsService(&fakeCapsDriver{
+		caps: types.NewDriverCapabilities(types.DriverCapabilitySnapshots),
+	})
+
+	caps, err := Capabilities(ctx, s)
+	assert.NoError(t, err)
+	assert.True(t, caps[types.DriverCapabilitySnapshots])
+	assert.False(t, caps[types.DriverCapabilityMultiAttach])
+
+	assert.NoError(t,
+		RequireCapabilities(ctx, s, types.DriverCapabilitySnapshots))
+
+	err = RequireCapabilities(ctx, s,
+		types.DriverCapabilitySnapshots, types.DriverCapabilityMultiAttach)
+	if assert.Error(t, err) {
+		uerr, ok := err.(*types.ErrUnsupportedCapability)
+		if assert.True(t, ok, "unexpected error: %v", err) {
+			assert.EqualValues(t, types.DriverCapabilityMultiAttach,
+				uerr.Fields()["capability"])
+		}
+	}
+}
+
+func TestRequireCapabilitiesProbed(t *testing.T) {
+	ctx := context.Background()
+	s := newTestCapsService(&fakeProbeDriver{})
+
+	// the capabilities of a driver that does not declare them are inferred
+	// from the optional interfaces it implements and are otherwise unknown
+	caps, err := Capabilities(ctx, s)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		types.DriverCapabilities{types.DriverCapabilityResize: false}, caps)
+	assert.NoError(t, RequireCapabilities(ctx, s, types.DriverCapabilityCopy))
+
+	// a call that returns ErrNotImplemented marks its capability unsupported
+	_, err = s.Driver().VolumeCopy(ctx, "vol-000", "v1", nil)
+	assert.Equal(t, types.ErrNotImplemented, err)
+
+	caps, err = Capabilities(ctx, s)
+	assert.NoError(t, err)
+	supported, ok := caps[types.DriverCapabilityCopy]
+	assert.True(t, ok)
+	assert.False(t, supported)
+
+	err = RequireCapabilities(ctx, s, types.DriverCapabilityCopy)
+	if assert.Error(t, err) {
+		_, ok := err.(*types.ErrUnsupportedCapability)
+		assert.True(t, ok, "unexpected error: %v", err)
+	}
+
+	// other capabilities remain unknown
+	assert.NoError(t,
+		RequireCapabilities(ctx, s, types.DriverCapabilitySnapshots))
+}
+
+func TestRequireCapabilitiesReprobed(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeProbeDriver{}
+	s := newTestCapsService(d)
+
+	_, err := s.Driver().VolumeCopy(ctx, "vol-000", "v1", nil)
+	assert.Equal(t, types.ErrNotImplemented, err)
+	assert.Error(t, RequireCapabilities(ctx, s, types.DriverCapabilityCopy))
+
+	// the capability is unknown again once the flag expires
+	defer func(ttl time.Duration) { unsupportedTTL = ttl }(unsupportedTTL)
+	unsupportedTTL = 0
+	assert.NoError(t, RequireCapabilities(ctx, s, types.DriverCapabilityCopy))
+
+	// a call that succeeds clears the flag
+	unsupportedTTL = time.Hour
+	_, err = s.Driver().VolumeCopy(ctx, "vol-000", "v1", nil)
+	assert.Equal(t, types.ErrNotImplemented, err)
+	assert.Error(t, RequireCapabilities(ctx, s, types.DriverCapabilityCopy))
+
+	d.copy = true
+	_, err = s.Driver().VolumeCopy(ctx, "vol-000", "v1", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, RequireCapabilities(ctx, s, types.DriverCapabilityCopy))
+}
+
+func TestRequireEncryption(t *testing.T) {
+	ctx := context.Background()
+
+	s := newTestCapsService(&fakeCapsDriver{
+		caps: types.NewDriverCapabilities(types.DriverCapabilityEncryption),
+	})
+	assert.NoError(t, RequireEncryption(ctx, s))
+
+	// encryption is rejected unless the driver declares it
+	for _, d := range []types.StorageDriver{
+		&fakeCapsDriver{caps: types.NewDriverCapabilities()},
+		&fakeProbeDriver{},
+	} {
+		err := RequireEncryption(ctx, newTestCapsService(d))
+		if assert.Error(t, err) {
+			uerr, ok := err.(*types.ErrUnsupportedCapability)
+			if assert.True(t, ok, "unexpected error: %v", err) {
+				assert.EqualValues(t, types.DriverCapabilityEncryption,
+					uerr.Fields()["capability"])
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	gofig "github.com/akutz/gofig/types"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+
+	// load the event sinks
+	_ "github.com/codedellemc/libstorage/api/server/eventsink"
+)
+
+// eventQueueSize is the number of events that may be queued for a sink
+// before emitting an event blocks until the sink catches up.
+const eventQueueSize = 1024
+
+// globalEventService writes the server's events to the configured event
+// sinks. Each sink has its own queue so that a slow sink, such as a webhook
+// that is being retried, does not delay the other sinks.
+type globalEventService struct {
+	sync.RWMutex
+	name    string
+	ctx     types.Context
+	sinks   []types.EventSink
+	queues  []chan *types.Event
+	drained sync.WaitGroup
+	closed  bool
+}
+
+// Init initializes the service.
+func (s *globalEventService) Init(
+	ctx types.Context, config gofig.Config) error {
+
+	s.ctx = ctx
+
+	for _, name := range eventSinkNames(config) {
+		sink, err := registry.NewEventSink(name)
+		if err != nil {
+			return err
+		}
+		if err := sink.Init(ctx, config); err != nil {
+			return err
+		}
+
+		q := make(chan *types.Event, eventQueueSize)
+		s.sinks = append(s.sinks, sink)
+		s.queues = append(s.queues, q)
+		s.drained.Add(1)
+		go s.drain(ctx, sink, q)
+
+		ctx.WithField("sink", sink.Name()).Info("configured event sink")
+	}
+
+	return nil
+}
+
+func (s *globalEventService) Name() string {
+	return s.name
+}
+
+// Close stops accepting events and blocks until the queued events have been
+// written to the sinks, and then closes the sinks.
+func (s *globalEventService) Close(ctx types.Context) {
+	s.Lock()
+	if s.closed {
+		s.Unlock()
+		return
+	}
+	s.closed = true
+	for _, q := range s.queues {
+		close(q)
+	}
+	s.Unlock()
+
+	ctx.WithField("sinks", len(s.queues)).Info("flushing event sinks")
+	s.drained.Wait()
+}
+
+// EventEmit emits an event to all of the configured sinks.
+func (s *globalEventService) EventEmit(ctx types.Context, event *types.Event) {
+	if len(s.queues) == 0 {
+		return
+	}
+
+	if event.Time == 0 {
+		event.Time = time.Now().Unix()
+	}
+	if event.Service == "" {
+		event.Service, _ = context.ServiceName(ctx)
+	}
+	if event.Subject == "" {
+		if tok, ok := context.AuthToken(ctx); ok {
+			event.Subject = tok.Subject
+		}
+	}
+	if event.InstanceID == "" {
+		if iid, ok := context.InstanceID(ctx); ok {
+			event.InstanceID = iid.ID
+		}
+	}
+	if event.TxID == "" {
+		if tx, ok := context.Transaction(ctx); ok && tx.ID != nil {
+			event.TxID = tx.ID.String()
+		}
+	}
+
+	ctx.WithField("operation", event.Operation).Debug("emitting event")
+
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.closed {
+		for _, sink := range s.sinks {
+			eventsFailed.WithLabelValues(sink.Name()).Inc()
+		}
+		eventLogFields(ctx, event).Error(
+			"event service closed; event not delivered")
+		return
+	}
+
+	for _, q := range s.queues {
+		q <- event
+	}
+}
+
+// drain writes the events received on the queue to the sink until the queue
+// is closed. An event the sink fails to write is logged in its entirety and
+// counted so that the failure is not silent.
+func (s *globalEventService) drain(
+	ctx types.Context, sink types.EventSink, q <-chan *types.Event) {
+
+	defer s.drained.Done()
+
+	for event := range q {
+		if err := sink.Emit(ctx, event); err != nil {
+			eventsFailed.WithLabelValues(sink.Name()).Inc()
+			eventLogFields(ctx, event).WithError(err).WithField(
+				"sink", sink.Name()).Error("event not delivered")
+		}
+	}
+
+	if c, ok := sink.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			ctx.WithError(err).WithField("sink", sink.Name()).Error(
+				"error closing event sink")
+		}
+	}
+}
+
+// eventLogFields returns a logger with the fields of an event that was not
+// delivered so that the event may be recovered from the server's log.
+func eventLogFields(ctx types.Context, event *types.Event) types.LogEntry {
+	fields := log.Fields{"operation": event.Operation}
+	if buf, err := json.Marshal(event); err == nil {
+		fields["event"] = string(buf)
+	}
+	return ctx.WithFields(fields)
+}
+
+// eventSinkNames returns the names of the configured event sinks. The sinks
+// may be configured as a list or as a comma-separated string.
+func eventSinkNames(config gofig.Config) []string {
+	var names []string
+	for _, v := range config.GetStringSlice(types.ConfigServerEventsSinks) {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
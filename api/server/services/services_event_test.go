@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const testEventSinkName = "services-test"
+
+// testEventSink records the events it receives after a delay and fails to
+// write the events for volumes named "fail".
+type testEventSink struct {
+	sync.Mutex
+	events []*types.Event
+	closed bool
+}
+
+var testEventSinkInstance = &testEventSink{}
+
+func init() {
+	registry.RegisterEventSink(testEventSinkName, func() types.EventSink {
+		return testEventSinkInstance
+	})
+}
+
+func (s *testEventSink) Name() string {
+	return testEventSinkName
+}
+
+func (s *testEventSink) Init(ctx types.Context, config gofig.Config) error {
+	return nil
+}
+
+func (s *testEventSink) Emit(ctx types.Context, event *types.Event) error {
+	time.Sleep(10 * time.Millisecond)
+	if event.VolumeID == "fail" {
+		return errors.New("sink unavailable")
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *testEventSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	s.closed = true
+	return nil
+}
+
+func TestEventServiceClose(t *testing.T) {
+	ctx := context.Background()
+	config := gofigCore.New()
+	config.Set(types.ConfigServerEventsSinks, testEventSinkName)
+
+	s := &globalEventService{name: "global-event-service"}
+	if err := s.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"vol-000", "fail", "vol-001"} {
+		s.EventEmit(ctx, &types.Event{
+			Operation: types.EventVolumeCreate,
+			VolumeID:  id,
+		})
+	}
+
+	// the queued events are written before the sink is closed
+	s.Close(ctx)
+	sink := testEventSinkInstance
+	sink.Lock()
+	defer sink.Unlock()
+	assert.True(t, sink.closed)
+	if assert.Len(t, sink.events, 2) {
+		assert.Equal(t, "vol-000", sink.events[0].VolumeID)
+		assert.Equal(t, "vol-001", sink.events[1].VolumeID)
+	}
+
+	// events emitted after the service is closed are not queued
+	s.EventEmit(ctx, &types.Event{
+		Operation: types.EventVolumeRemove,
+		VolumeID:  "vol-000",
+	})
+	assert.Len(t, sink.events, 2)
+}
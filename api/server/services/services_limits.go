@@ -0,0 +1,137 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/api/utils/ratelimit"
+)
+
+// serviceLimits are the rate limits of a storage service.
+type serviceLimits struct {
+	config   *types.LimitsConfig
+	service  *ratelimit.Limiter
+	lck      sync.Mutex
+	subjects map[string]*ratelimit.Limiter
+}
+
+func newServiceLimits(config *types.LimitsConfig) *serviceLimits {
+	l := &serviceLimits{
+		config:   config,
+		subjects: map[string]*ratelimit.Limiter{},
+	}
+	if config.Rate > 0 {
+		l.service = ratelimit.New(config.Rate, config.Burst)
+	}
+	return l
+}
+
+// subject returns the limiter for the subject of the context's security
+// token. Nil is returned if subjects are not limited or if the context does
+// not have a token.
+func (l *serviceLimits) subject(
+	ctx types.Context) (string, *ratelimit.Limiter) {
+
+	if l.config.SubjectRate <= 0 {
+		return "", nil
+	}
+	tok, ok := context.AuthToken(ctx)
+	if !ok || tok.Subject == "" {
+		return "", nil
+	}
+
+	l.lck.Lock()
+	defer l.lck.Unlock()
+
+	if sl, ok := l.subjects[tok.Subject]; ok {
+		return tok.Subject, sl
+	}
+
+	// the limiters of the subjects that have not sent a request for long
+	// enough to be refilled are discarded so that the map does not grow
+	// with every subject ever seen
+	for k, sl := range l.subjects {
+		if sl.Full() {
+			delete(l.subjects, k)
+		}
+	}
+
+	sl := ratelimit.New(l.config.SubjectRate, l.config.SubjectBurst)
+	l.subjects[tok.Subject] = sl
+	return tok.Subject, sl
+}
+
+// take takes a token from the limiters for the context's subject and the
+// service. The subject's limiter is consulted first so that a subject that
+// exceeds its limit does not use up the service's tokens, and the subject's
+// token is returned if the service's limit is exceeded.
+func (l *serviceLimits) take(ctx types.Context, service string) error {
+	subject, sl := l.subject(ctx)
+	if sl != nil {
+		if d := sl.Take(); d > 0 {
+			rateLimited.WithLabelValues(service).Inc()
+			return utils.NewRateLimitedErr(service, subject, d)
+		}
+	}
+	if l.service != nil {
+		if d := l.service.Take(); d > 0 {
+			if sl != nil {
+				sl.Put()
+			}
+			rateLimited.WithLabelValues(service).Inc()
+			return utils.NewRateLimitedErr(service, subject, d)
+		}
+	}
+	return nil
+}
+
+// put returns the tokens taken by take.
+func (l *serviceLimits) put(ctx types.Context) {
+	if _, sl := l.subject(ctx); sl != nil {
+		sl.Put()
+	}
+	if l.service != nil {
+		l.service.Put()
+	}
+}
+
+// wait waits for tokens from the limiters for the context's subject and the
+// service.
+func (l *serviceLimits) wait(ctx types.Context) error {
+	if _, sl := l.subject(ctx); sl != nil {
+		if err := sl.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if l.service != nil {
+		return l.service.Wait(ctx)
+	}
+	return nil
+}
+
+// RateLimit takes a token from the rate limits of each of the services for
+// the request with the context. An ErrRateLimited error is returned if the
+// request exceeds a limit of any of the services, in which case the tokens
+// taken from the other services are returned so that a rejected request does
+// not count against any limit. Services that are configured to queue the
+// requests that exceed their limits are skipped since the request's tasks
+// wait for the tokens instead.
+func RateLimit(ctx types.Context, services ...types.StorageService) error {
+	var taken []*serviceLimits
+	for _, service := range services {
+		s, ok := service.(*storageService)
+		if !ok || s.limits == nil || s.limits.config.Queue {
+			continue
+		}
+		if err := s.limits.take(ctx, s.name); err != nil {
+			for _, l := range taken {
+				l.put(ctx)
+			}
+			return err
+		}
+		taken = append(taken, s.limits)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func newTestLimitedService(
+	name string, config *types.LimitsConfig) *storageService {
+
+	return &storageService{name: name, limits: newServiceLimits(config)}
+}
+
+func assertRateLimited(t *testing.T, err error) {
+	if assert.Error(t, err) {
+		_, ok := err.(*types.ErrRateLimited)
+		assert.True(t, ok, "unexpected error: %v", err)
+	}
+}
+
+func TestRateLimitService(t *testing.T) {
+	ctx := context.Background()
+	s0 := newTestLimitedService("s0", &types.LimitsConfig{Rate: 1, Burst: 2})
+	s1 := newTestLimitedService("s1", &types.LimitsConfig{Rate: 1, Burst: 1})
+
+	assert.NoError(t, RateLimit(ctx, s0))
+	assert.NoError(t, RateLimit(ctx, s0))
+	assertRateLimited(t, RateLimit(ctx, s0))
+
+	// each service has its own limits
+	assert.NoError(t, RateLimit(ctx, s1))
+	assertRateLimited(t, RateLimit(ctx, s1))
+}
+
+func TestRateLimitServiceQueue(t *testing.T) {
+	ctx := context.Background()
+	s0 := newTestLimitedService(
+		"s0", &types.LimitsConfig{Rate: 1, Burst: 1, Queue: true})
+
+	// a service that queues requests does not reject them
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, RateLimit(ctx, s0))
+	}
+}
+
+func TestRateLimitServiceSubject(t *testing.T) {
+	s0 := newTestLimitedService("s0", &types.LimitsConfig{
+		Rate: 1, Burst: 1, SubjectRate: 1, SubjectBurst: 2})
+	ctx := context.Background().WithValue(
+		context.AuthTokenKey, &types.AuthToken{Subject: "user1"})
+
+	assert.NoError(t, RateLimit(ctx, s0))
+	assertRateLimited(t, RateLimit(ctx, s0))
+
+	// the subject's token is returned when the service's limit is exceeded,
+	// so only the first request counts against the subject's limit
+	_, sl := s0.limits.subject(ctx)
+	assert.Zero(t, sl.Take())
+	assert.NotZero(t, sl.Take())
+}
+
+func TestRateLimitAllServices(t *testing.T) {
+	ctx := context.Background()
+	s0 := newTestLimitedService("s0", &types.LimitsConfig{Rate: 1, Burst: 1})
+	s1 := newTestLimitedService("s1", &types.LimitsConfig{Rate: 1, Burst: 1})
+	s2 := &storageService{name: "s2"}
+
+	assert.NoError(t, RateLimit(ctx, s1))
+
+	// the request exceeds the limit of s1, so no token is taken from s0
+	assertRateLimited(t, RateLimit(ctx, s0, s1, s2))
+	assert.True(t, s0.limits.service.Full())
+	assert.NoError(t, RateLimit(ctx, s0))
+}
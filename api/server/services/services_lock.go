@@ -0,0 +1,204 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	gofig "github.com/akutz/gofig/types"
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/lockmgr"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+// lockKeysKey is the context key for the keys of the locks a task acquires
+// before it runs.
+type lockKeysKey struct{}
+
+// VolumeLockKey returns the key of the lock for a volume.
+func VolumeLockKey(service, volumeID string) string {
+	return fmt.Sprintf("%s/volumes/%s", service, volumeID)
+}
+
+// SnapshotLockKey returns the key of the lock for a snapshot.
+func SnapshotLockKey(service, snapshotID string) string {
+	return fmt.Sprintf("%s/snapshots/%s", service, snapshotID)
+}
+
+// WithLocks returns a context that causes a task that is enqueued with it to
+// acquire the locks with the specified keys before the task runs. The locks
+// are released once the task's function returns, even if the task was
+// canceled before then.
+func WithLocks(ctx types.Context, keys ...string) types.Context {
+	if v, ok := ctx.Value(lockKeysKey{}).([]string); ok {
+		keys = append(append([]string{}, v...), keys...)
+	}
+	return ctx.WithValue(lockKeysKey{}, keys)
+}
+
+// Lock acquires the lock with the specified key on behalf of the task that
+// is running with the context and returns a function that releases the lock.
+// Lock is used by tasks that do not know which resources they operate on
+// until they run, ex. a task that detaches all volumes.
+func Lock(ctx types.Context, key string) (func(), error) {
+	t, err := getTaskService(ctx).taskFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getLockService(ctx).lock(t, key)
+}
+
+type globalLockService struct {
+	name    string
+	server  string
+	manager types.LockManager
+	timeout time.Duration
+}
+
+// Init initializes the service.
+func (s *globalLockService) Init(ctx types.Context, config gofig.Config) error {
+	s.server, _ = context.Server(ctx)
+
+	managerType := config.GetString(types.ConfigServerLocksType)
+	if managerType == "" {
+		managerType = lockmgr.MemLockManagerName
+	}
+	manager, err := registry.NewLockManager(managerType)
+	if err != nil {
+		return err
+	}
+	if err := manager.Init(ctx, config); err != nil {
+		return err
+	}
+	s.manager = manager
+
+	s.timeout, err = time.ParseDuration(
+		config.GetString(types.ConfigServerLocksTimeout))
+	if err != nil {
+		s.timeout = time.Duration(time.Minute * 1)
+	}
+
+	ctx.WithField("type", managerType).WithField(
+		"timeout", s.timeout).Debug("configured lock manager")
+	return nil
+}
+
+func (s *globalLockService) Name() string {
+	return s.name
+}
+
+// lock acquires the locks with the specified keys on behalf of the task and
+// returns a function that releases them. The keys are sorted so that tasks
+// that acquire the same locks cannot deadlock. The task's locks are updated
+// as the locks are acquired and released.
+func (s *globalLockService) lock(
+	t *task, keys ...string) (func(), error) {
+
+	if len(keys) == 0 {
+		return func() {}, nil
+	}
+
+	keys = append([]string{}, keys...)
+	sort.Strings(keys)
+
+	holder := fmt.Sprintf("%s/%d", s.server, t.ID)
+
+	ctx := t.ctx
+	if s.timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(t.ctx, s.timeout)
+		defer cancel()
+	}
+
+	var held []string
+	release := func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			key := held[i]
+			if err := s.manager.Unlock(t.ctx, key, holder); err != nil {
+				t.ctx.WithError(err).WithField("lockKey", key).Error(
+					"error releasing lock")
+			}
+			t.setLockState(key, types.TaskLockStateReleased)
+		}
+	}
+
+	for _, key := range keys {
+		t.setLockState(key, types.TaskLockStateWaiting)
+		t.ctx.WithField("lockKey", key).Debug("acquiring lock")
+
+		if err := s.manager.Lock(ctx, key, holder); err != nil {
+			release()
+			if t.ctx.Err() != nil {
+				return nil, t.ctx.Err()
+			}
+			if ctx.Err() != nil {
+				t.setLockState(key, types.TaskLockStateTimedOut)
+				return nil, utils.NewLockTimeoutErr(key, s.timeout.String())
+			}
+			return nil, goof.WithFieldE(
+				"lockKey", key, "error acquiring lock", err)
+		}
+
+		held = append(held, key)
+		t.setLockState(key, types.TaskLockStateHeld)
+	}
+
+	return release, nil
+}
+
+// lock acquires the locks with which the task was enqueued.
+func (t *task) lock() (func(), error) {
+	keys, _ := t.ctx.Value(lockKeysKey{}).([]string)
+	if len(keys) == 0 {
+		return func() {}, nil
+	}
+	return getLockService(t.ctx).lock(t, keys...)
+}
+
+// setLockState updates the state of the task's lock with the specified key.
+func (t *task) setLockState(key string, state types.TaskLockState) {
+	// the locks are copied since the task's copies that were saved to the
+	// store share the locks' pointers
+	t.svc.taskUpdate(t, func() {
+		var (
+			found bool
+			locks = make([]*types.TaskLock, 0, len(t.Locks)+1)
+		)
+		for _, l := range t.Locks {
+			if l.Key == key {
+				l = &types.TaskLock{Key: key, State: state}
+				found = true
+			}
+			locks = append(locks, l)
+		}
+		if !found {
+			locks = append(locks, &types.TaskLock{Key: key, State: state})
+		}
+		t.Locks = locks
+	})
+}
+
+// taskFromContext returns the task that is running with the context.
+func (s *globalTaskService) taskFromContext(ctx types.Context) (*task, error) {
+	szTaskID, ok := ctx.Value(context.TaskKey).(string)
+	if !ok {
+		return nil, goof.New("missing task")
+	}
+	taskID, err := strconv.Atoi(szTaskID)
+	if err != nil {
+		return nil, goof.WithFieldE("taskID", szTaskID, "invalid task id", err)
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+	t, ok := s.tasks[taskID]
+	if !ok {
+		return nil, utils.NewNotFoundError(szTaskID)
+	}
+	return t, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// latencyBuckets are the histogram buckets, in seconds, for the latency of
+// tasks and storage operations, which may take minutes.
+var latencyBuckets = append(prometheus.DefBuckets, 30, 60)
+
+var (
+	tasksQueued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "libstorage_server_tasks_queued",
+		Help: "The number of tasks waiting to execute.",
+	})
+
+	tasksRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "libstorage_server_tasks_running",
+		Help: "The number of executing tasks.",
+	})
+
+	taskWaitDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "libstorage_server_task_wait_seconds",
+		Help:    "The time tasks spend waiting to execute.",
+		Buckets: latencyBuckets,
+	})
+
+	taskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "libstorage_server_task_duration_seconds",
+		Help:    "The time tasks spend executing.",
+		Buckets: latencyBuckets,
+	}, []string{"state"})
+
+	driverCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "libstorage_server_driver_call_duration_seconds",
+		Help:    "The latency of storage driver calls.",
+		Buckets: latencyBuckets,
+	}, []string{"service", "driver", "call"})
+
+	driverCallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libstorage_server_driver_call_errors_total",
+		Help: "The number of storage driver calls that failed.",
+	}, []string{"service", "driver", "call"})
+
+	volumeCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libstorage_server_volume_cache_requests_total",
+		Help: "The number of volume listings served by the volume cache.",
+	}, []string{"service", "result"})
+
+	eventsFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libstorage_server_events_failed_total",
+		Help: "The number of events that were not delivered to an event sink.",
+	}, []string{"sink"})
+
+	rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libstorage_server_rate_limited_total",
+		Help: "The number of requests rejected for exceeding a rate limit.",
+	}, []string{"service"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		tasksQueued,
+		tasksRunning,
+		taskWaitDuration,
+		taskDuration,
+		driverCallDuration,
+		driverCallErrors,
+		volumeCacheRequests,
+		eventsFailed,
+		rateLimited)
+}
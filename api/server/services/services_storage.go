@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"time"
 
 	gofig "github.com/akutz/gofig/types"
 	"github.com/akutz/goof"
@@ -17,6 +18,7 @@ type storageService struct {
 	driver        types.StorageDriver
 	config        gofig.Config
 	authConfig    *types.AuthConfig
+	limits        *serviceLimits
 	taskExecQueue chan *task
 }
 
@@ -27,12 +29,20 @@ func (s *storageService) Init(ctx types.Context, config gofig.Config) error {
 		return err
 	}
 
+	limitsFields := map[string]interface{}{}
+	limitsConfig, err := utils.ParseLimitsConfig(
+		ctx, config, limitsFields,
+		fmt.Sprintf("libstorage.server.services.%s", s.name))
+	if err != nil {
+		return err
+	}
+	if limitsConfig != nil {
+		s.limits = newServiceLimits(limitsConfig)
+		ctx.WithFields(limitsFields).Info("configured service limits")
+	}
+
 	s.taskExecQueue = make(chan *task)
-	go func() {
-		for t := range s.taskExecQueue {
-			execTask(t)
-		}
-	}()
+	go s.execTasks()
 
 	authFields := map[string]interface{}{}
 	authConfig, err := utils.ParseAuthConfig(
@@ -49,6 +59,25 @@ func (s *storageService) Init(ctx types.Context, config gofig.Config) error {
 	return nil
 }
 
+// execTasks executes the service's tasks. Unless the service specifies the
+// maximum number of tasks that may run at once, the tasks run one at a time.
+// A task occupies its slot until its function returns, even if the task is
+// canceled before then, so that a canceled task still counts against the
+// maximum while it uses the storage platform.
+func (s *storageService) execTasks() {
+	maxInFlight := 1
+	if s.limits != nil && s.limits.config.MaxInFlight > 0 {
+		maxInFlight = s.limits.config.MaxInFlight
+	}
+
+	inFlight := make(chan struct{}, maxInFlight)
+	for t := range s.taskExecQueue {
+		inFlight <- struct{}{}
+		t.ran = func() { <-inFlight }
+		go execTask(t)
+	}
+}
+
 func (s *storageService) initStorageDriver(ctx types.Context) error {
 	driverName := s.config.GetString("driver")
 	if driverName == "" {
@@ -74,7 +103,21 @@ func (s *storageService) initStorageDriver(ctx types.Context) error {
 		return err
 	}
 
-	s.driver = driver
+	s.driver = newStorageDriverManager(s.name, driver)
+
+	if s.config.GetBool(types.ConfigServerVolumeCacheEnabled) {
+		interval, err := time.ParseDuration(
+			s.config.GetString(types.ConfigServerVolumeCacheRefreshInterval))
+		if err != nil || interval <= 0 {
+			interval = time.Duration(time.Minute * 1)
+		}
+		d := newVolumeCacheDriver(s.driver.(*storageDriverManager), interval)
+		s.driver = d
+		go d.refresh(context.WithStorageService(ctx, s))
+		ctx.WithField("refreshInterval", interval).Info(
+			"configured volume cache")
+	}
+
 	return nil
 }
 
@@ -96,7 +139,21 @@ func (s *storageService) TaskEnqueue(
 	schema []byte) *types.Task {
 
 	t := newStorageServiceTask(ctx, run, s, schema)
-	go func() { s.taskExecQueue <- t }()
+	go func() {
+		// when the service queues the requests that exceed its rate limits
+		// the task remains queued until the tokens are available
+		if s.limits != nil && s.limits.config.Queue {
+			if err := s.limits.wait(t.ctx); err != nil {
+				t.ctx.WithError(err).Debug("stopped waiting for rate limit")
+			}
+			// a task canceled while it waited for the rate limit is not run
+			if t.ctx.Err() != nil {
+				cancelQueuedTask(t)
+				return
+			}
+		}
+		s.taskExecQueue <- t
+	}()
 	return &t.Task
 }
 
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// storageDriverManager is a types.StorageDriverManager that records the
+// latency and errors of a service's storage driver calls.
+//
+// The manager implements the functions of the optional StorageDriverWith*
+// interfaces. If the underlying driver does not implement one of those
+// interfaces then the manager's function returns types.ErrNotImplemented.
+// StorageDriverWithLogin is the exception; callers that log into the storage
+// platform must inspect the underlying driver returned by Driver.
+type storageDriverManager struct {
+	types.StorageDriver
+	service string
+
+	// unsupported are the capabilities the driver was found not to support
+	// when it returned types.ErrNotImplemented from a call, and the times
+	// at which it last did so
+	lck         sync.RWMutex
+	unsupported map[types.DriverCapability]time.Time
+}
+
+func newStorageDriverManager(
+	service string, driver types.StorageDriver) *storageDriverManager {
+
+	return &storageDriverManager{
+		StorageDriver: driver,
+		service:       service,
+		unsupported:   map[types.DriverCapability]time.Time{},
+	}
+}
+
+// Driver returns the underlying driver.
+func (d *storageDriverManager) Driver() types.StorageDriver {
+	return d.StorageDriver
+}
+
+// observe records the metrics for a driver call. A types.ErrNotImplemented
+// error is not counted as a failed call, but it does indicate the driver does
+// not support the capability the call requires.
+func (d *storageDriverManager) observe(
+	call string, start time.Time, err error) {
+
+	driver := d.StorageDriver.Name()
+	driverCallDuration.WithLabelValues(d.service, driver, call).Observe(
+		time.Since(start).Seconds())
+	d.probed(call, err)
+	if err != nil && err != types.ErrNotImplemented {
+		driverCallErrors.WithLabelValues(d.service, driver, call).Inc()
+	}
+}
+
+func (d *storageDriverManager) NextDeviceInfo(
+	ctx types.Context) (*types.NextDeviceInfo, error) {
+
+	start := time.Now()
+	ndi, err := d.StorageDriver.NextDeviceInfo(ctx)
+	d.observe("NextDeviceInfo", start, err)
+	return ndi, err
+}
+
+func (d *storageDriverManager) Type(
+	ctx types.Context) (types.StorageType, error) {
+
+	start := time.Now()
+	st, err := d.StorageDriver.Type(ctx)
+	d.observe("Type", start, err)
+	return st, err
+}
+
+func (d *storageDriverManager) InstanceInspect(
+	ctx types.Context,
+	opts types.Store) (*types.Instance, error) {
+
+	start := time.Now()
+	i, err := d.StorageDriver.InstanceInspect(ctx, opts)
+	d.observe("InstanceInspect", start, err)
+	return i, err
+}
+
+func (d *storageDriverManager) Volumes(
+	ctx types.Context,
+	opts *types.VolumesOpts) ([]*types.Volume, error) {
+
+	start := time.Now()
+	vols, err := d.StorageDriver.Volumes(ctx, opts)
+	d.observe("Volumes", start, err)
+	return vols, err
+}
+
+func (d *storageDriverManager) VolumeInspect(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeInspectOpts) (*types.Volume, error) {
+
+	start := time.Now()
+	v, err := d.StorageDriver.VolumeInspect(ctx, volumeID, opts)
+	d.observe("VolumeInspect", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) VolumeCreate(
+	ctx types.Context,
+	name string,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
+	start := time.Now()
+	v, err := d.StorageDriver.VolumeCreate(ctx, name, opts)
+	d.observe("VolumeCreate", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) VolumeCreateFromSnapshot(
+	ctx types.Context,
+	snapshotID,
+	volumeName string,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
+	start := time.Now()
+	v, err := d.StorageDriver.VolumeCreateFromSnapshot(
+		ctx, snapshotID, volumeName, opts)
+	d.observe("VolumeCreateFromSnapshot", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) VolumeCopy(
+	ctx types.Context,
+	volumeID,
+	volumeName string,
+	opts types.Store) (*types.Volume, error) {
+
+	start := time.Now()
+	v, err := d.StorageDriver.VolumeCopy(ctx, volumeID, volumeName, opts)
+	d.observe("VolumeCopy", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) VolumeSnapshot(
+	ctx types.Context,
+	volumeID,
+	snapshotName string,
+	opts types.Store) (*types.Snapshot, error) {
+
+	start := time.Now()
+	s, err := d.StorageDriver.VolumeSnapshot(ctx, volumeID, snapshotName, opts)
+	d.observe("VolumeSnapshot", start, err)
+	return s, err
+}
+
+func (d *storageDriverManager) VolumeRemove(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeRemoveOpts) error {
+
+	start := time.Now()
+	err := d.StorageDriver.VolumeRemove(ctx, volumeID, opts)
+	d.observe("VolumeRemove", start, err)
+	return err
+}
+
+func (d *storageDriverManager) VolumeAttach(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeAttachOpts) (*types.Volume, string, error) {
+
+	start := time.Now()
+	v, tok, err := d.StorageDriver.VolumeAttach(ctx, volumeID, opts)
+	d.observe("VolumeAttach", start, err)
+	return v, tok, err
+}
+
+func (d *storageDriverManager) VolumeDetach(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeDetachOpts) (*types.Volume, error) {
+
+	start := time.Now()
+	v, err := d.StorageDriver.VolumeDetach(ctx, volumeID, opts)
+	d.observe("VolumeDetach", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) Snapshots(
+	ctx types.Context,
+	opts types.Store) ([]*types.Snapshot, error) {
+
+	start := time.Now()
+	snaps, err := d.StorageDriver.Snapshots(ctx, opts)
+	d.observe("Snapshots", start, err)
+	return snaps, err
+}
+
+func (d *storageDriverManager) SnapshotInspect(
+	ctx types.Context,
+	snapshotID string,
+	opts types.Store) (*types.Snapshot, error) {
+
+	start := time.Now()
+	s, err := d.StorageDriver.SnapshotInspect(ctx, snapshotID, opts)
+	d.observe("SnapshotInspect", start, err)
+	return s, err
+}
+
+func (d *storageDriverManager) SnapshotCopy(
+	ctx types.Context,
+	snapshotID,
+	snapshotName,
+	destinationID string,
+	opts types.Store) (*types.Snapshot, error) {
+
+	start := time.Now()
+	s, err := d.StorageDriver.SnapshotCopy(
+		ctx, snapshotID, snapshotName, destinationID, opts)
+	d.observe("SnapshotCopy", start, err)
+	return s, err
+}
+
+func (d *storageDriverManager) SnapshotRemove(
+	ctx types.Context,
+	snapshotID string,
+	opts types.Store) error {
+
+	start := time.Now()
+	err := d.StorageDriver.SnapshotRemove(ctx, snapshotID, opts)
+	d.observe("SnapshotRemove", start, err)
+	return err
+}
+
+func (d *storageDriverManager) VolumeInspectByName(
+	ctx types.Context,
+	volumeName string,
+	opts *types.VolumeInspectOpts) (*types.Volume, error) {
+
+	sd, ok := d.StorageDriver.(types.StorageDriverVolInspectByName)
+	if !ok {
+		return nil, types.ErrNotImplemented
+	}
+
+	start := time.Now()
+	v, err := sd.VolumeInspectByName(ctx, volumeName, opts)
+	d.observe("VolumeInspectByName", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	sd, ok := d.StorageDriver.(types.StorageDriverWithResize)
+	if !ok {
+		return nil, types.ErrNotImplemented
+	}
+
+	start := time.Now()
+	v, err := sd.VolumeResize(ctx, volumeID, opts)
+	d.observe("VolumeResize", start, err)
+	return v, err
+}
+
+func (d *storageDriverManager) VolumeDetachAll(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeDetachOpts) error {
+
+	sd, ok := d.StorageDriver.(types.StorageDriverWithDetachAll)
+	if !ok {
+		return types.ErrNotImplemented
+	}
+
+	start := time.Now()
+	err := sd.VolumeDetachAll(ctx, volumeID, opts)
+	d.observe("VolumeDetachAll", start, err)
+	return err
+}
+
+func (d *storageDriverManager) VolumesPage(
+	ctx types.Context,
+	opts *types.VolumesOpts,
+	page *types.PageOpts) ([]*types.Volume, string, error) {
+
+	sd, ok := d.StorageDriver.(types.StorageDriverWithPaging)
+	if !ok {
+		return nil, "", types.ErrNotImplemented
+	}
+
+	start := time.Now()
+	vols, next, err := sd.VolumesPage(ctx, opts, page)
+	d.observe("VolumesPage", start, err)
+	return vols, next, err
+}
+
+func (d *storageDriverManager) SnapshotsPage(
+	ctx types.Context,
+	opts types.Store,
+	page *types.PageOpts) ([]*types.Snapshot, string, error) {
+
+	sd, ok := d.StorageDriver.(types.StorageDriverWithPaging)
+	if !ok {
+		return nil, "", types.ErrNotImplemented
+	}
+
+	start := time.Now()
+	snaps, next, err := sd.SnapshotsPage(ctx, opts, page)
+	d.observe("SnapshotsPage", start, err)
+	return snaps, next, err
+}
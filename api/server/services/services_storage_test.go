@@ -0,0 +1,171 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// newTestServices registers a service container with only a task service
+// for a server with the specified name and returns a context for the server.
+func newTestServices(t *testing.T, serverName string) types.Context {
+	ctx := context.Background().WithValue(context.ServerKey, serverName)
+
+	ts := &globalTaskService{name: "global-task-service"}
+	if err := ts.Init(ctx, gofigCore.New()); err != nil {
+		t.Fatal(err)
+	}
+
+	servicesByServerRWL.Lock()
+	servicesByServer[serverName] = &serviceContainer{
+		taskService:     ts,
+		storageServices: map[string]types.StorageService{},
+	}
+	servicesByServerRWL.Unlock()
+
+	return ctx
+}
+
+func TestStorageServiceCanceledTaskHoldsQueue(t *testing.T) {
+	ctx := newTestServices(t, t.Name())
+
+	s := &storageService{name: "s0", taskExecQueue: make(chan *task)}
+	go s.execTasks()
+
+	var (
+		started  = make(chan int, 2)
+		release  = make(chan int)
+		returned = make(chan int)
+	)
+
+	// the first task ignores its context and runs until it is released
+	t0 := s.TaskEnqueue(ctx,
+		func(types.Context, types.StorageService) (interface{}, error) {
+			started <- 0
+			<-release
+			close(returned)
+			return nil, nil
+		}, nil)
+	<-started
+
+	t0 = TaskCancel(ctx, t0.ID)
+	assert.EqualValues(t, types.TaskStateCanceled, t0.State)
+
+	t1 := s.TaskEnqueue(ctx,
+		func(types.Context, types.StorageService) (interface{}, error) {
+			select {
+			case <-returned:
+			default:
+				t.Error("task started before the canceled task returned")
+			}
+			started <- 1
+			return nil, nil
+		}, nil)
+
+	// the next task does not start while the canceled task's function is
+	// still running
+	select {
+	case <-started:
+		t.Fatal("task started while the queue was occupied")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task did not start after the queue was released")
+	}
+
+	TaskWait(ctx, t1.ID)
+	assert.EqualValues(t, types.TaskStateSuccess, TaskInspect(ctx, t1.ID).State)
+}
+
+func TestStorageServiceCanceledQueuedTask(t *testing.T) {
+	ctx := newTestServices(t, t.Name())
+
+	s := &storageService{name: "s0", taskExecQueue: make(chan *task)}
+	go s.execTasks()
+
+	var (
+		started = make(chan int)
+		release = make(chan int)
+	)
+
+	// the first task occupies the service's only slot
+	t0 := s.TaskEnqueue(ctx,
+		func(types.Context, types.StorageService) (interface{}, error) {
+			close(started)
+			<-release
+			return nil, nil
+		}, nil)
+	<-started
+
+	t1 := s.TaskEnqueue(ctx,
+		func(types.Context, types.StorageService) (interface{}, error) {
+			t.Error("canceled task ran")
+			return nil, nil
+		}, nil)
+
+	canceled := make(chan *types.Task)
+	go func() { canceled <- TaskCancel(ctx, t1.ID) }()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	// the queued task is completed as canceled without running
+	select {
+	case t1 = <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("canceled task did not complete")
+	}
+	assert.EqualValues(t, types.TaskStateCanceled, t1.State)
+	assert.NotZero(t, t1.CompleteTime)
+	assert.Zero(t, t1.StartTime)
+
+	TaskWait(ctx, t0.ID)
+	assert.EqualValues(t, types.TaskStateSuccess, TaskInspect(ctx, t0.ID).State)
+}
+
+func TestStorageServiceCanceledRateLimitedTask(t *testing.T) {
+	ctx := newTestServices(t, t.Name())
+
+	s := newTestLimitedService(
+		"s0", &types.LimitsConfig{Rate: 0.01, Burst: 1, Queue: true})
+	s.taskExecQueue = make(chan *task)
+	go s.execTasks()
+
+	// the service's only token is taken so the task waits for the rate limit
+	assert.NoError(t, s.limits.take(ctx, s.name))
+	t0 := s.TaskEnqueue(ctx,
+		func(types.Context, types.StorageService) (interface{}, error) {
+			t.Error("canceled task ran")
+			return nil, nil
+		}, nil)
+
+	t0 = TaskCancel(ctx, t0.ID)
+	assert.EqualValues(t, types.TaskStateCanceled, t0.State)
+	assert.Zero(t, t0.StartTime)
+}
+
+func TestTaskWaitRetainsTask(t *testing.T) {
+	ctx := newTestServices(t, t.Name())
+
+	t0 := TaskEnqueue(ctx,
+		func(types.Context) (interface{}, error) {
+			return "ok", nil
+		}, nil)
+	TaskWait(ctx, t0.ID)
+
+	// a task that was waited on is retained until it is pruned
+	t0 = TaskInspect(ctx, t0.ID)
+	if assert.NotNil(t, t0) {
+		assert.EqualValues(t, types.TaskStateSuccess, t0.State)
+	}
+	getTaskService(ctx).taskPrune(ctx, 0)
+	assert.Nil(t, TaskInspect(ctx, t0.ID))
+}
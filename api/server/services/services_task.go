@@ -12,6 +12,8 @@ import (
 	"github.com/akutz/goof"
 
 	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/server/taskstore"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils/schema"
 )
@@ -19,18 +21,24 @@ import (
 type task struct {
 	types.Task
 	ctx                           types.Context
+	cancel                        func()
 	runFunc                       types.TaskRunFunc
 	storRunFunc                   types.StorageTaskRunFunc
 	storService                   types.StorageService
 	resultSchema                  []byte
 	resultSchemaValidationEnabled bool
+	svc                           *globalTaskService
 	done                          chan int
+	queued                        time.Time
+	ran                           func()
 }
 
 func newTask(ctx types.Context, schema []byte) *task {
 	t := getTaskService(ctx).taskTrack(ctx)
 	t.resultSchema = schema
 	t.done = make(chan int)
+	t.queued = time.Now()
+	tasksQueued.Inc()
 	return t
 }
 
@@ -56,30 +64,107 @@ func newStorageServiceTask(
 	return t
 }
 
-func execTask(t *task) {
-	defer func() {
+// cancelQueuedTask completes a task that was canceled before it ran.
+func cancelQueuedTask(t *task) {
+	tasksQueued.Dec()
+	t.ctx.Info("task canceled before it ran")
+	t.svc.taskUpdate(t, func() {
+		t.Error = types.ErrTaskCanceled
+		t.State = types.TaskStateCanceled
 		t.CompleteTime = time.Now().Unix()
-		if t.Error != nil {
-			t.ctx.Error(t.Error)
-			t.State = types.TaskStateError
-		} else {
-			t.State = types.TaskStateSuccess
+	})
+	t.cancel()
+	close(t.done)
+}
+
+func execTask(t *task) {
+	// a task canceled while it was queued releases its slot and is never run
+	if t.ctx.Err() != nil {
+		if t.ran != nil {
+			t.ran()
 		}
+		cancelQueuedTask(t)
+		return
+	}
+
+	started := time.Now()
+	tasksQueued.Dec()
+	tasksRunning.Inc()
+	taskWaitDuration.Observe(started.Sub(t.queued).Seconds())
+
+	defer func() {
+		t.svc.taskUpdate(t, func() {
+			t.CompleteTime = time.Now().Unix()
+			if t.Error == types.ErrTaskCanceled {
+				t.State = types.TaskStateCanceled
+			} else if t.Error != nil {
+				t.ctx.Error(t.Error)
+				t.State = types.TaskStateError
+			} else {
+				t.State = types.TaskStateSuccess
+			}
+		})
+		tasksRunning.Dec()
+		taskDuration.WithLabelValues(string(t.State)).Observe(
+			time.Since(started).Seconds())
+		t.cancel()
 		close(t.done)
 		t.ctx.Debug("task completed")
 	}()
 
-	t.State = types.TaskStateRunning
-	t.StartTime = time.Now().Unix()
+	t.svc.taskUpdate(t, func() {
+		t.State = types.TaskStateRunning
+		t.StartTime = time.Now().Unix()
+	})
 
 	t.ctx.Info("executing task")
 
-	if t.storRunFunc != nil && t.storService != nil {
-		t.Result, t.Error = t.storRunFunc(t.ctx, t.storService)
-	} else if t.runFunc != nil {
-		t.Result, t.Error = t.runFunc(t.ctx)
-	} else {
-		t.Error = goof.New("invalid task")
+	var (
+		result interface{}
+		err    error
+		ranC   = make(chan int)
+	)
+
+	// the task is executed in its own goroutine so that a canceled task
+	// returns immediately even if the task's function ignores its context
+	go func() {
+		defer close(ranC)
+		if t.ran != nil {
+			defer t.ran()
+		}
+
+		// the locks are held until the task's function returns, even if the
+		// task is canceled, so another task cannot operate on the same
+		// resources while the function is still running
+		unlock, lerr := t.lock()
+		if lerr != nil {
+			err = lerr
+			return
+		}
+		defer unlock()
+
+		// the task may be canceled while it waits for its locks
+		if t.ctx.Err() != nil {
+			err = types.ErrTaskCanceled
+			return
+		}
+
+		if t.storRunFunc != nil && t.storService != nil {
+			result, err = t.storRunFunc(t.ctx, t.storService)
+		} else if t.runFunc != nil {
+			result, err = t.runFunc(t.ctx)
+		} else {
+			err = goof.New("invalid task")
+		}
+	}()
+
+	select {
+	case <-ranC:
+		t.Result, t.Error = result, err
+	case <-t.ctx.Done():
+		t.ctx.Info("task canceled")
+		t.Error = types.ErrTaskCanceled
+		return
 	}
 
 	if t.Error != nil {
@@ -112,17 +197,26 @@ func execTask(t *task) {
 	}
 }
 
+// taskWatchBufferSize is the number of task updates that may be queued for a
+// watcher before updates are dropped for that watcher.
+const taskWatchBufferSize = 64
+
 type globalTaskService struct {
 	sync.RWMutex
 	name                          string
+	ctx                           types.Context
 	config                        gofig.Config
+	store                         types.TaskStore
 	tasks                         map[int]*task
+	watchers                      map[chan *types.Task]struct{}
 	resultSchemaValidationEnabled bool
 }
 
 // Init initializes the service.
 func (s *globalTaskService) Init(ctx types.Context, config gofig.Config) error {
 	s.tasks = map[int]*task{}
+	s.watchers = map[chan *types.Task]struct{}{}
+	s.ctx = ctx
 	s.config = config
 
 	s.resultSchemaValidationEnabled = config.GetBool(
@@ -130,6 +224,33 @@ func (s *globalTaskService) Init(ctx types.Context, config gofig.Config) error {
 	ctx.WithField("enabled", s.resultSchemaValidationEnabled).Debug(
 		"configured result schema validation")
 
+	storeType := config.GetString(types.ConfigServerTasksStoreType)
+	if storeType == "" {
+		storeType = taskstore.MemStoreName
+	}
+	store, err := registry.NewTaskStore(storeType)
+	if err != nil {
+		return err
+	}
+	if err := store.Init(ctx, config); err != nil {
+		return err
+	}
+	s.store = store
+	ctx.WithField("store", storeType).Debug("configured task store")
+
+	if err := s.taskInterruptAll(ctx); err != nil {
+		return err
+	}
+
+	ttl, err := time.ParseDuration(
+		config.GetString(types.ConfigServerTasksTTL))
+	if err != nil {
+		ttl = time.Duration(time.Hour * 1)
+	}
+	if ttl > 0 {
+		go s.taskPruneEvery(ctx, ttl)
+	}
+
 	return nil
 }
 
@@ -139,12 +260,10 @@ func (s *globalTaskService) Name() string {
 
 // Tasks returns a channel on which all tasks are received.
 func (s *globalTaskService) Tasks() <-chan *types.Task {
-	tasks := []*types.Task{}
-	s.RLock()
-	for _, v := range s.tasks {
-		tasks = append(tasks, &v.Task)
+	tasks, err := s.store.List(s.ctx)
+	if err != nil {
+		s.ctx.WithError(err).Error("error listing tasks")
 	}
-	s.RUnlock()
 
 	c := make(chan *types.Task)
 	go func() {
@@ -163,26 +282,52 @@ func (s *globalTaskService) TaskTrack(ctx types.Context) *types.Task {
 func (s *globalTaskService) taskTrack(ctx types.Context) *task {
 
 	now := time.Now().Unix()
-	s.RLock()
-	taskID := len(s.tasks)
-	s.RUnlock()
+	taskID := s.store.NextID(ctx)
 
 	t := &task{
 		Task: types.Task{
 			ID:        taskID,
 			QueueTime: now,
+			State:     types.TaskStateQueued,
 		},
 		resultSchemaValidationEnabled: s.resultSchemaValidationEnabled,
-		ctx: ctx.WithValue(context.TaskKey, fmt.Sprintf("%d", taskID)),
+		svc:                           s,
 	}
+	t.ctx, t.cancel = context.WithCancel(
+		ctx.WithValue(context.TaskKey, fmt.Sprintf("%d", taskID)))
 
 	s.Lock()
 	s.tasks[taskID] = t
 	s.Unlock()
 
+	s.taskUpdate(t, func() {})
+
 	return t
 }
 
+// taskUpdate applies an update to a task and then persists the task and
+// notifies the task's watchers of the change.
+func (s *globalTaskService) taskUpdate(t *task, update func()) {
+	s.Lock()
+	update()
+	tc := t.Task
+	s.Unlock()
+
+	if err := s.store.Save(t.ctx, &tc); err != nil {
+		t.ctx.WithError(err).Error("error saving task")
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+	for c := range s.watchers {
+		select {
+		case c <- &tc:
+		default:
+			t.ctx.Warn("task watcher is full; dropped task update")
+		}
+	}
+}
+
 // TaskExecute enqueues a task for execution.
 func (s *globalTaskService) TaskEnqueue(
 	ctx types.Context,
@@ -196,12 +341,49 @@ func (s *globalTaskService) TaskEnqueue(
 
 // TaskInspect returns the task with the specified ID.
 func (s *globalTaskService) TaskInspect(taskID int) *types.Task {
+	t, err := s.store.Get(s.ctx, taskID)
+	if err != nil {
+		s.ctx.WithError(err).WithField("taskID", taskID).Error(
+			"error getting task")
+		return nil
+	}
+	return t
+}
+
+// TaskCancel cancels the task with the specified ID and returns the task
+// once it is no longer running. A nil value is returned if the task does not
+// exist.
+func (s *globalTaskService) TaskCancel(taskID int) *types.Task {
 	s.RLock()
-	defer s.RUnlock()
-	if t, ok := s.tasks[taskID]; ok {
-		return &t.Task
+	t, ok := s.tasks[taskID]
+	s.RUnlock()
+
+	if ok && t.done != nil {
+		t.cancel()
+		<-t.done
 	}
-	return nil
+
+	return s.TaskInspect(taskID)
+}
+
+// TaskWatch returns a channel on which a task is received every time its
+// state changes. The channel is closed when the context is done.
+func (s *globalTaskService) TaskWatch(ctx types.Context) <-chan *types.Task {
+	c := make(chan *types.Task, taskWatchBufferSize)
+
+	s.Lock()
+	s.watchers[c] = struct{}{}
+	s.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.Lock()
+		defer s.Unlock()
+		delete(s.watchers, c)
+		close(c)
+	}()
+
+	return c
 }
 
 // TaskWait blocks until the specified task is completed.
@@ -225,9 +407,6 @@ func (s *globalTaskService) TaskWaitC(taskID int) <-chan int {
 			return
 		}
 
-		// remove the task from the queue after a configured about of time
-		defer s.taskRemoveAfter(t)
-
 		// signal that the task is complete
 		<-t.done
 	}()
@@ -235,34 +414,97 @@ func (s *globalTaskService) TaskWaitC(taskID int) <-chan int {
 	return c
 }
 
-// taskRemoveAfter tells the task service to remove the task after the duration
-// specified by `libstorage.server.tasks.logTimeout`.
-func (s *globalTaskService) taskRemoveAfter(t *task) {
-	go func() {
-		logTimeoutDur, err := time.ParseDuration(
-			s.config.GetString(types.ConfigServerTasksLogTimeout))
-		if err != nil {
-			logTimeoutDur = time.Duration(time.Second * 60)
+// taskRemove removes a task from the store and from the service's map of
+// tasks created by this process.
+func (s *globalTaskService) taskRemove(ctx types.Context, taskID int) {
+	// sync access to the task map for querying its size before and after
+	// executing the delete operation on it
+	s.Lock()
+	defer s.Unlock()
+
+	ctx.WithFields(log.Fields{
+		"taskID":   taskID,
+		"tasksLen": len(s.tasks),
+	}).Debug("removing task")
+
+	if err := s.store.Remove(ctx, taskID); err != nil {
+		ctx.WithError(err).WithField("taskID", taskID).Error(
+			"error removing task")
+		return
+	}
+	delete(s.tasks, taskID)
+
+	ctx.WithField("tasksLen", len(s.tasks)).Debug("removed task")
+}
+
+// taskPruneEvery removes completed tasks that are older than the duration
+// specified by `libstorage.server.tasks.ttl` until the context is done.
+func (s *globalTaskService) taskPruneEvery(
+	ctx types.Context, ttl time.Duration) {
+
+	interval := ttl
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.taskPrune(ctx, ttl)
+		case <-ctx.Done():
+			return
 		}
+	}
+}
 
-		// wait to remove the task
-		time.Sleep(logTimeoutDur)
+// taskPrune removes completed tasks that are older than the provided TTL.
+func (s *globalTaskService) taskPrune(ctx types.Context, ttl time.Duration) {
+	tasks, err := s.store.List(ctx)
+	if err != nil {
+		ctx.WithError(err).Error("error listing tasks for pruning")
+		return
+	}
 
-		// sync access to the task map for querying its size before and after
-		// executing the delete operation on it
-		s.Lock()
-		defer s.Unlock()
+	expired := time.Now().Add(-ttl).Unix()
+	for _, t := range tasks {
+		if t.CompleteTime == 0 || t.CompleteTime > expired {
+			continue
+		}
+		ctx.WithFields(log.Fields{
+			"taskID": t.ID,
+			"ttl":    ttl,
+		}).Debug("pruning task")
+		s.taskRemove(ctx, t.ID)
+	}
+}
 
-		t.ctx.WithFields(log.Fields{
-			"removedAfter": logTimeoutDur,
-			"tasksLen":     len(s.tasks),
-		}).Debug("removing task")
+// taskInterruptAll marks the stored tasks that never completed as failed.
+// Such tasks were running when the server was stopped and will never
+// complete.
+func (s *globalTaskService) taskInterruptAll(ctx types.Context) error {
+	tasks, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
 
-		// delete the task
-		delete(s.tasks, t.ID)
+	now := time.Now().Unix()
+	for _, t := range tasks {
+		if t.State != types.TaskStateQueued &&
+			t.State != types.TaskStateRunning {
+			continue
+		}
+		ctx.WithField("taskID", t.ID).Info("marking interrupted task as failed")
+		t.State = types.TaskStateError
+		t.Error = goof.New("task interrupted")
+		t.CompleteTime = now
+		if err := s.store.Save(ctx, t); err != nil {
+			return err
+		}
+	}
 
-		t.ctx.WithField("tasksLen", len(s.tasks)).Debug("removed task")
-	}()
+	return nil
 }
 
 // TaskWaitAll blocks until all the specified task are complete.
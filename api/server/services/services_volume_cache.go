@@ -0,0 +1,310 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+const (
+	volumeCacheHit  = "hit"
+	volumeCacheMiss = "miss"
+)
+
+// volumeCacheDriver is a storage driver manager that keeps an inventory of
+// a service's volumes and refreshes it in the background.
+//
+// An inventory is kept for each attachments mask with which the volumes are
+// listed, except for masks that require an instance ID since the volumes
+// listed with those depend on the instance that lists them. The inventories
+// are discarded whenever a volume is modified via the server.
+type volumeCacheDriver struct {
+	*storageDriverManager
+	interval time.Duration
+
+	lck     sync.Mutex
+	gen     int
+	entries map[types.VolumeAttachmentsTypes]*volumeCacheEntry
+}
+
+type volumeCacheEntry struct {
+	volumes []*types.Volume
+	updated time.Time
+}
+
+func newVolumeCacheDriver(
+	m *storageDriverManager, interval time.Duration) *volumeCacheDriver {
+
+	d := &volumeCacheDriver{storageDriverManager: m, interval: interval}
+	d.invalidate()
+	return d
+}
+
+// refresh refreshes the inventories every interval until the context is
+// done.
+func (d *volumeCacheDriver) refresh(ctx types.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		d.lck.Lock()
+		masks := make([]types.VolumeAttachmentsTypes, 0, len(d.entries))
+		for mask := range d.entries {
+			masks = append(masks, mask)
+		}
+		d.lck.Unlock()
+
+		if len(masks) == 0 {
+			continue
+		}
+
+		sctx, err := context.WithStorageSession(ctx)
+		if err != nil {
+			ctx.WithError(err).Error("error refreshing volume cache")
+			continue
+		}
+		for _, mask := range masks {
+			if _, err := d.list(sctx, &types.VolumesOpts{
+				Attachments: mask,
+				Opts:        utils.NewStore(),
+			}); err != nil {
+				sctx.WithError(err).WithField("attachments", mask).Error(
+					"error refreshing volume cache")
+			}
+		}
+	}
+}
+
+// list lists the volumes with the underlying driver and saves them to the
+// cache, unless the cache was invalidated while they were listed.
+func (d *volumeCacheDriver) list(
+	ctx types.Context,
+	opts *types.VolumesOpts) ([]*types.Volume, error) {
+
+	d.lck.Lock()
+	gen := d.gen
+	d.lck.Unlock()
+
+	vols, err := d.storageDriverManager.Volumes(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	d.lck.Lock()
+	defer d.lck.Unlock()
+	if gen == d.gen {
+		d.entries[opts.Attachments] = &volumeCacheEntry{
+			volumes: copyVolumes(vols),
+			updated: time.Now(),
+		}
+	}
+	return vols, nil
+}
+
+// invalidate discards the inventories.
+func (d *volumeCacheDriver) invalidate() {
+	d.lck.Lock()
+	defer d.lck.Unlock()
+	d.gen++
+	d.entries = map[types.VolumeAttachmentsTypes]*volumeCacheEntry{}
+}
+
+// Volumes returns the inventory for the attachments mask if it is no older
+// than twice the refresh interval. Otherwise, or when the fresh option is
+// set, the volumes are listed with the underlying driver.
+func (d *volumeCacheDriver) Volumes(
+	ctx types.Context,
+	opts *types.VolumesOpts) ([]*types.Volume, error) {
+
+	if opts.Attachments.RequiresInstanceID() {
+		return d.storageDriverManager.Volumes(ctx, opts)
+	}
+
+	fresh := opts.Opts != nil && opts.Opts.GetBool("fresh")
+
+	if !fresh {
+		d.lck.Lock()
+		e, ok := d.entries[opts.Attachments]
+		d.lck.Unlock()
+		if ok {
+			if age := time.Since(e.updated); age <= 2*d.interval {
+				volumeCacheRequests.WithLabelValues(
+					d.service, volumeCacheHit).Inc()
+				recordVolumeCacheStatus(ctx, true, age)
+				return copyVolumes(e.volumes), nil
+			}
+		}
+	}
+
+	volumeCacheRequests.WithLabelValues(d.service, volumeCacheMiss).Inc()
+	recordVolumeCacheStatus(ctx, false, 0)
+	return d.list(ctx, opts)
+}
+
+func (d *volumeCacheDriver) VolumeCreate(
+	ctx types.Context,
+	name string,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeCreate(ctx, name, opts)
+}
+
+func (d *volumeCacheDriver) VolumeCreateFromSnapshot(
+	ctx types.Context,
+	snapshotID,
+	volumeName string,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeCreateFromSnapshot(
+		ctx, snapshotID, volumeName, opts)
+}
+
+func (d *volumeCacheDriver) VolumeCopy(
+	ctx types.Context,
+	volumeID,
+	volumeName string,
+	opts types.Store) (*types.Volume, error) {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeCopy(ctx, volumeID, volumeName, opts)
+}
+
+func (d *volumeCacheDriver) VolumeRemove(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeRemoveOpts) error {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeRemove(ctx, volumeID, opts)
+}
+
+func (d *volumeCacheDriver) VolumeAttach(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeAttachOpts) (*types.Volume, string, error) {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeAttach(ctx, volumeID, opts)
+}
+
+func (d *volumeCacheDriver) VolumeDetach(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeDetachOpts) (*types.Volume, error) {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeDetach(ctx, volumeID, opts)
+}
+
+func (d *volumeCacheDriver) VolumeDetachAll(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeDetachOpts) error {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeDetachAll(ctx, volumeID, opts)
+}
+
+func (d *volumeCacheDriver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	defer d.invalidate()
+	return d.storageDriverManager.VolumeResize(ctx, volumeID, opts)
+}
+
+// copyVolumes copies the volumes so the cached volumes are not modified by
+// callers.
+func copyVolumes(vols []*types.Volume) []*types.Volume {
+	if vols == nil {
+		return nil
+	}
+	copies := make([]*types.Volume, len(vols))
+	for i, v := range vols {
+		vc := *v
+		if v.Attachments != nil {
+			vc.Attachments = make(
+				[]*types.VolumeAttachment, len(v.Attachments))
+			for j, a := range v.Attachments {
+				ac := *a
+				vc.Attachments[j] = &ac
+			}
+		}
+		copies[i] = &vc
+	}
+	return copies
+}
+
+// volumeCacheStatusKey is the context key for a request's volume cache
+// status.
+type volumeCacheStatusKey struct{}
+
+// volumeCacheStatus records whether the volumes listed while handling a
+// request were read from the volume cache.
+type volumeCacheStatus struct {
+	sync.Mutex
+	used bool
+	miss bool
+	age  time.Duration
+}
+
+// WithVolumeCacheStatus returns a context that records whether the volumes
+// listed with it were read from the volume cache.
+func WithVolumeCacheStatus(ctx types.Context) types.Context {
+	return ctx.WithValue(volumeCacheStatusKey{}, &volumeCacheStatus{})
+}
+
+func recordVolumeCacheStatus(
+	ctx types.Context, hit bool, age time.Duration) {
+
+	s, ok := ctx.Value(volumeCacheStatusKey{}).(*volumeCacheStatus)
+	if !ok {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.used = true
+	if !hit {
+		s.miss = true
+	}
+	if age > s.age {
+		s.age = age
+	}
+}
+
+// SetVolumeCacheHeaders sets the headers that indicate whether the volumes in
+// the response to a request were read from the volume cache. The context
+// must have been returned by WithVolumeCacheStatus. The response is a hit
+// only if the volumes for every service were read from the cache. No headers
+// are set if no volume cache was used.
+func SetVolumeCacheHeaders(ctx types.Context, h http.Header) {
+	s, ok := ctx.Value(volumeCacheStatusKey{}).(*volumeCacheStatus)
+	if !ok {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	if !s.used {
+		return
+	}
+	if s.miss {
+		h.Set(types.VolumeCacheHeader, volumeCacheMiss)
+	} else {
+		h.Set(types.VolumeCacheHeader, volumeCacheHit)
+	}
+	h.Set(types.VolumeCacheAgeHeader,
+		fmt.Sprintf("%d", int64(s.age/time.Second)))
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+// fakeVolumesDriver is a storage driver that counts the number of times its
+// volumes are listed.
+type fakeVolumesDriver struct {
+	types.StorageDriver
+
+	sync.Mutex
+	lists   int
+	volumes []*types.Volume
+}
+
+func (d *fakeVolumesDriver) Name() string {
+	return "fake"
+}
+
+func (d *fakeVolumesDriver) Volumes(
+	ctx types.Context,
+	opts *types.VolumesOpts) ([]*types.Volume, error) {
+
+	d.Lock()
+	defer d.Unlock()
+	d.lists++
+	return copyVolumes(d.volumes), nil
+}
+
+func (d *fakeVolumesDriver) VolumeCreate(
+	ctx types.Context,
+	name string,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
+	d.Lock()
+	defer d.Unlock()
+	v := &types.Volume{ID: name, Name: name}
+	d.volumes = append(d.volumes, v)
+	return v, nil
+}
+
+func (d *fakeVolumesDriver) listCount() int {
+	d.Lock()
+	defer d.Unlock()
+	return d.lists
+}
+
+func newTestVolumeCacheDriver(
+	interval time.Duration) (*volumeCacheDriver, *fakeVolumesDriver) {
+
+	fd := &fakeVolumesDriver{
+		volumes: []*types.Volume{{ID: "vol-000", Name: "v0"}},
+	}
+	return newVolumeCacheDriver(
+		newStorageDriverManager("s0", fd), interval), fd
+}
+
+// listVolumes lists the volumes with the cache and returns the volume cache
+// header that would be set on the response.
+func listVolumes(
+	t *testing.T,
+	d *volumeCacheDriver,
+	opts types.Store) ([]*types.Volume, string) {
+
+	ctx := WithVolumeCacheStatus(context.Background())
+	vols, err := d.Volumes(ctx, &types.VolumesOpts{
+		Attachments: types.VolAttReq,
+		Opts:        opts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := http.Header{}
+	SetVolumeCacheHeaders(ctx, h)
+	return vols, h.Get(types.VolumeCacheHeader)
+}
+
+func TestVolumeCacheMissThenHit(t *testing.T) {
+	d, fd := newTestVolumeCacheDriver(time.Minute)
+
+	vols, status := listVolumes(t, d, utils.NewStore())
+	assert.Len(t, vols, 1)
+	assert.Equal(t, volumeCacheMiss, status)
+	assert.Equal(t, 1, fd.listCount())
+
+	vols, status = listVolumes(t, d, utils.NewStore())
+	assert.Len(t, vols, 1)
+	assert.Equal(t, volumeCacheHit, status)
+	assert.Equal(t, 1, fd.listCount())
+
+	// the cached volumes are not modified by callers
+	vols[0].Name = "modified"
+	vols, _ = listVolumes(t, d, utils.NewStore())
+	assert.Equal(t, "v0", vols[0].Name)
+}
+
+func TestVolumeCacheInstanceIDMaskNotCached(t *testing.T) {
+	d, fd := newTestVolumeCacheDriver(time.Minute)
+	ctx := context.Background()
+	opts := &types.VolumesOpts{
+		Attachments: types.VolAttReqForInstance,
+		Opts:        utils.NewStore(),
+	}
+
+	for i := 1; i <= 2; i++ {
+		_, err := d.Volumes(ctx, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, i, fd.listCount())
+	}
+}
+
+func TestVolumeCacheInvalidatedByMutation(t *testing.T) {
+	d, fd := newTestVolumeCacheDriver(time.Minute)
+
+	listVolumes(t, d, utils.NewStore())
+	assert.Equal(t, 1, fd.listCount())
+
+	_, err := d.VolumeCreate(context.Background(), "v1", nil)
+	assert.NoError(t, err)
+
+	vols, status := listVolumes(t, d, utils.NewStore())
+	assert.Len(t, vols, 2)
+	assert.Equal(t, volumeCacheMiss, status)
+	assert.Equal(t, 2, fd.listCount())
+}
+
+func TestVolumeCacheFresh(t *testing.T) {
+	d, fd := newTestVolumeCacheDriver(time.Minute)
+
+	listVolumes(t, d, utils.NewStore())
+	assert.Equal(t, 1, fd.listCount())
+
+	fresh := utils.NewStoreWithData(map[string]interface{}{"fresh": true})
+	_, status := listVolumes(t, d, fresh)
+	assert.Equal(t, volumeCacheMiss, status)
+	assert.Equal(t, 2, fd.listCount())
+
+	// the fresh listing is saved to the cache
+	_, status = listVolumes(t, d, utils.NewStore())
+	assert.Equal(t, volumeCacheHit, status)
+	assert.Equal(t, 2, fd.listCount())
+}
+
+func TestVolumeCacheExpired(t *testing.T) {
+	d, fd := newTestVolumeCacheDriver(10 * time.Millisecond)
+
+	listVolumes(t, d, utils.NewStore())
+	time.Sleep(30 * time.Millisecond)
+
+	_, status := listVolumes(t, d, utils.NewStore())
+	assert.Equal(t, volumeCacheMiss, status)
+	assert.Equal(t, 2, fd.listCount())
+}
+
+func TestVolumeCacheRefreshStopsOnClose(t *testing.T) {
+	d, fd := newTestVolumeCacheDriver(10 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.WithStorageService(
+		context.Background(), &storageService{name: "s0", driver: d}))
+
+	// only the inventories that have been listed are refreshed
+	listVolumes(t, d, utils.NewStore())
+
+	stopped := make(chan struct{})
+	go func() {
+		d.refresh(ctx)
+		close(stopped)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for fd.listCount() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.True(t, fd.listCount() >= 2, "volume cache not refreshed")
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("volume cache refresh did not stop")
+	}
+}
+
+func TestCloseCancelsServices(t *testing.T) {
+	ctx := newTestServices(t, t.Name())
+	sctx, cancel := context.WithCancel(ctx)
+
+	servicesByServerRWL.Lock()
+	servicesByServer[t.Name()].cancel = cancel
+	servicesByServerRWL.Unlock()
+
+	Close(ctx)
+	select {
+	case <-sctx.Done():
+	default:
+		t.Fatal("services context not canceled")
+	}
+}
+
+func TestVolumeDetachAllNotImplemented(t *testing.T) {
+	d, _ := newTestVolumeCacheDriver(time.Minute)
+
+	// the detach-all routes detach each volume with VolumeDetach instead
+	err := d.VolumeDetachAll(context.Background(), "vol-000", nil)
+	assert.Equal(t, types.ErrNotImplemented, err)
+}
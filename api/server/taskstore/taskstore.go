@@ -0,0 +1,75 @@
+// Package taskstore provides the TaskStore implementations used by the
+// server's task service to persist tasks.
+package taskstore
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// tasks is a thread-safe, in-memory collection of tasks that is shared by
+// the task stores in this package.
+type tasks struct {
+	sync.RWMutex
+	nextID int
+	tasks  map[int]*types.Task
+}
+
+func newTasks() *tasks {
+	return &tasks{tasks: map[int]*types.Task{}}
+}
+
+func (t *tasks) NextID() int {
+	t.Lock()
+	defer t.Unlock()
+	id := t.nextID
+	t.nextID++
+	return id
+}
+
+func (t *tasks) Save(task *types.Task) {
+	t.Lock()
+	defer t.Unlock()
+	tc := *task
+	t.tasks[task.ID] = &tc
+	if task.ID >= t.nextID {
+		t.nextID = task.ID + 1
+	}
+}
+
+func (t *tasks) Get(taskID int) *types.Task {
+	t.RLock()
+	defer t.RUnlock()
+	task, ok := t.tasks[taskID]
+	if !ok {
+		return nil
+	}
+	tc := *task
+	return &tc
+}
+
+func (t *tasks) List() []*types.Task {
+	t.RLock()
+	defer t.RUnlock()
+	list := make([]*types.Task, 0, len(t.tasks))
+	for _, task := range t.tasks {
+		tc := *task
+		list = append(list, &tc)
+	}
+	sort.Sort(byTaskID(list))
+	return list
+}
+
+func (t *tasks) Remove(taskID int) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.tasks, taskID)
+}
+
+type byTaskID []*types.Task
+
+func (s byTaskID) Len() int           { return len(s) }
+func (s byTaskID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byTaskID) Less(i, j int) bool { return s[i].ID < s[j].ID }
@@ -0,0 +1,178 @@
+package taskstore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+
+	gofig "github.com/akutz/gofig/types"
+	"github.com/akutz/goof"
+	"github.com/akutz/gotil"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// FileStoreName is the name of the file-backed task store.
+	FileStoreName = "file"
+
+	fileStoreDefaultFileName = "tasks.json"
+)
+
+func init() {
+	registry.RegisterTaskStore(FileStoreName, newFileStore)
+}
+
+// fileStore is a task store that keeps its tasks in memory and writes them
+// to a JSON file every time the store is modified so that tasks and task IDs
+// survive a server restart.
+type fileStore struct {
+	filePath string
+	tasks    *tasks
+	writeLck sync.Mutex
+}
+
+// fileStoreData is the format of the file-backed task store's data file.
+type fileStoreData struct {
+	NextID int         `json:"nextID"`
+	Tasks  []*fileTask `json:"tasks"`
+}
+
+// fileTask is the format of a persisted task. A task's error is persisted as
+// its message since the error's original type cannot be recovered.
+type fileTask struct {
+	*types.Task
+	Error string `json:"error,omitempty"`
+}
+
+func newFileStore() types.TaskStore {
+	return &fileStore{}
+}
+
+func (s *fileStore) Name() string {
+	return FileStoreName
+}
+
+func (s *fileStore) Init(ctx types.Context, config gofig.Config) error {
+	s.tasks = newTasks()
+
+	s.filePath = config.GetString(types.ConfigServerTasksStoreFile)
+	if s.filePath == "" {
+		pathConfig, ok := context.PathConfig(ctx)
+		if !ok {
+			return goof.WithField(
+				"configKey", types.ConfigServerTasksStoreFile,
+				"task store file path required")
+		}
+		s.filePath = path.Join(pathConfig.Lib, fileStoreDefaultFileName)
+	}
+
+	ctx.WithField("filePath", s.filePath).Debug("initializing file task store")
+	return s.load(ctx)
+}
+
+// load reads the tasks from the store's data file if the file exists.
+func (s *fileStore) load(ctx types.Context) error {
+	if !gotil.FileExists(s.filePath) {
+		return nil
+	}
+
+	buf, err := ioutil.ReadFile(s.filePath)
+	if err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error reading task store", err)
+	}
+
+	data := &fileStoreData{}
+	if err := json.Unmarshal(buf, data); err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error decoding task store", err)
+	}
+
+	for _, ft := range data.Tasks {
+		if ft.Task == nil {
+			continue
+		}
+		if ft.Error != "" {
+			ft.Task.Error = goof.New(ft.Error)
+		}
+		s.tasks.Save(ft.Task)
+	}
+	if data.NextID > s.tasks.nextID {
+		s.tasks.nextID = data.NextID
+	}
+
+	ctx.WithField("count", len(data.Tasks)).Debug("loaded tasks from file")
+	return nil
+}
+
+func (s *fileStore) NextID(ctx types.Context) int {
+	id := s.tasks.NextID()
+	if err := s.write(); err != nil {
+		ctx.WithError(err).Error("error persisting next task ID")
+	}
+	return id
+}
+
+func (s *fileStore) Save(ctx types.Context, task *types.Task) error {
+	s.tasks.Save(task)
+	return s.write()
+}
+
+func (s *fileStore) Get(ctx types.Context, taskID int) (*types.Task, error) {
+	return s.tasks.Get(taskID), nil
+}
+
+func (s *fileStore) List(ctx types.Context) ([]*types.Task, error) {
+	return s.tasks.List(), nil
+}
+
+func (s *fileStore) Remove(ctx types.Context, taskID int) error {
+	s.tasks.Remove(taskID)
+	return s.write()
+}
+
+// write persists the store's tasks by writing them to a temporary file that
+// then replaces the store's data file.
+func (s *fileStore) write() error {
+	s.writeLck.Lock()
+	defer s.writeLck.Unlock()
+
+	s.tasks.RLock()
+	data := &fileStoreData{NextID: s.tasks.nextID}
+	s.tasks.RUnlock()
+
+	for _, t := range s.tasks.List() {
+		ft := &fileTask{Task: t}
+		if t.Error != nil {
+			ft.Error = t.Error.Error()
+		}
+		data.Tasks = append(data.Tasks, ft)
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return goof.WithError("error encoding task store", err)
+	}
+
+	if err := os.MkdirAll(path.Dir(s.filePath), 0755); err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error creating task store dir", err)
+	}
+
+	tmpPath := s.filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, buf, 0600); err != nil {
+		return goof.WithFieldE(
+			"filePath", tmpPath, "error writing task store", err)
+	}
+	if err := os.Rename(tmpPath, s.filePath); err != nil {
+		return goof.WithFieldE(
+			"filePath", s.filePath, "error writing task store", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package taskstore
+
+import (
+	gofig "github.com/akutz/gofig/types"
+
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// MemStoreName is the name of the in-memory task store.
+	MemStoreName = "mem"
+)
+
+func init() {
+	registry.RegisterTaskStore(MemStoreName, newMemStore)
+}
+
+type memStore struct {
+	tasks *tasks
+}
+
+func newMemStore() types.TaskStore {
+	return &memStore{}
+}
+
+func (s *memStore) Name() string {
+	return MemStoreName
+}
+
+func (s *memStore) Init(ctx types.Context, config gofig.Config) error {
+	s.tasks = newTasks()
+	return nil
+}
+
+func (s *memStore) NextID(ctx types.Context) int {
+	return s.tasks.NextID()
+}
+
+func (s *memStore) Save(ctx types.Context, task *types.Task) error {
+	s.tasks.Save(task)
+	return nil
+}
+
+func (s *memStore) Get(ctx types.Context, taskID int) (*types.Task, error) {
+	return s.tasks.Get(taskID), nil
+}
+
+func (s *memStore) List(ctx types.Context) ([]*types.Task, error) {
+	return s.tasks.List(), nil
+}
+
+func (s *memStore) Remove(ctx types.Context, taskID int) error {
+	s.tasks.Remove(taskID)
+	return nil
+}
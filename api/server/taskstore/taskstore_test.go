@@ -0,0 +1,99 @@
+package taskstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/akutz/goof"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func TestMemStore(t *testing.T) {
+	ctx := context.Background()
+	s := &memStore{tasks: newTasks()}
+
+	assert.Equal(t, 0, s.NextID(ctx))
+	assert.Equal(t, 1, s.NextID(ctx))
+
+	task := &types.Task{ID: 1, State: types.TaskStateRunning}
+	assert.NoError(t, s.Save(ctx, task))
+
+	// the store should keep a copy of the task
+	task.State = types.TaskStateSuccess
+	st, err := s.Get(ctx, 1)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, st) {
+		t.FailNow()
+	}
+	assert.EqualValues(t, types.TaskStateRunning, st.State)
+
+	assert.NoError(t, s.Save(ctx, &types.Task{ID: 0}))
+	tasks, err := s.List(ctx)
+	assert.NoError(t, err)
+	if !assert.Len(t, tasks, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, 0, tasks[0].ID)
+	assert.Equal(t, 1, tasks[1].ID)
+
+	assert.NoError(t, s.Remove(ctx, 1))
+	st, err = s.Get(ctx, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, st)
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "taskstore")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+	filePath := path.Join(dir, fileStoreDefaultFileName)
+
+	s1 := &fileStore{tasks: newTasks(), filePath: filePath}
+	assert.NoError(t, s1.load(ctx))
+	assert.Equal(t, 0, s1.NextID(ctx))
+	assert.Equal(t, 1, s1.NextID(ctx))
+	assert.NoError(t, s1.Save(ctx, &types.Task{
+		ID:    0,
+		State: types.TaskStateSuccess,
+	}))
+	assert.NoError(t, s1.Save(ctx, &types.Task{
+		ID:    1,
+		State: types.TaskStateError,
+		Error: goof.New("bad things"),
+	}))
+
+	// a new store reading the same file should see the same tasks and
+	// should not reuse task IDs
+	s2 := &fileStore{tasks: newTasks(), filePath: filePath}
+	assert.NoError(t, s2.load(ctx))
+	assert.Equal(t, 2, s2.NextID(ctx))
+
+	tasks, err := s2.List(ctx)
+	assert.NoError(t, err)
+	if !assert.Len(t, tasks, 2) {
+		t.FailNow()
+	}
+	assert.EqualValues(t, types.TaskStateSuccess, tasks[0].State)
+	assert.Nil(t, tasks[0].Error)
+	assert.EqualValues(t, types.TaskStateError, tasks[1].State)
+	if assert.Error(t, tasks[1].Error) {
+		assert.EqualError(t, tasks[1].Error, "bad things")
+	}
+
+	assert.NoError(t, s2.Remove(ctx, 0))
+	s3 := &fileStore{tasks: newTasks(), filePath: filePath}
+	assert.NoError(t, s3.load(ctx))
+	tasks, err = s3.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, 3, s3.NextID(ctx))
+}
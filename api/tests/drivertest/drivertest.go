@@ -0,0 +1,241 @@
+// Package drivertest provides the tests that the storage drivers' unit tests
+// run against the stand-ins for their storage platforms. The tests assert the
+// behavior every driver shares so that the drivers' own tests need only cover
+// what is particular to each driver.
+package drivertest
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// Driver is a storage driver under test.
+type Driver struct {
+	types.StorageDriver
+
+	// Context is the context with which the driver's functions are called.
+	Context types.Context
+
+	// VolumeSize is the size of the volumes the tests create. It must be at
+	// least two so that a volume half its size is smaller than a snapshot.
+	VolumeSize int64
+
+	// MissingVolumeID is an ID in the format of the driver's volume IDs
+	// that does not belong to a volume.
+	MissingVolumeID string
+
+	// Close releases the resources of the driver's stand-in, if set.
+	Close func()
+}
+
+// NewDriverFunc returns a new driver under test whose storage platform does
+// not have any volumes or snapshots.
+type NewDriverFunc func(t *testing.T) *Driver
+
+// Run runs the tests, each against a new driver. The tests of the snapshot
+// and copy operations are only run if the driver declares the operations'
+// capabilities.
+func Run(t *testing.T, newDriver NewDriverFunc) {
+	for _, test := range []func(*testing.T, *Driver){
+		testCapabilities,
+		testVolumeSnapshot,
+		testSnapshots,
+		testVolumeCreateFromSnapshot,
+		testVolumeCopy,
+	} {
+		d := newDriver(t)
+		test(t, d)
+		if d.Close != nil {
+			d.Close()
+		}
+	}
+}
+
+func (d *Driver) capabilities(t *testing.T) types.DriverCapabilities {
+	sd, ok := d.StorageDriver.(types.StorageDriverWithCapabilities)
+	if !assert.True(t, ok, "driver does not declare its capabilities") {
+		t.FailNow()
+	}
+	caps, err := sd.Capabilities(d.Context)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return caps
+}
+
+func (d *Driver) newVolume(t *testing.T, name string) *types.Volume {
+	size := d.VolumeSize
+	v, err := d.VolumeCreate(d.Context, name, &types.VolumeCreateOpts{
+		Size: &size,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func (d *Driver) newSnapshot(
+	t *testing.T, v *types.Volume, name string) *types.Snapshot {
+
+	s, err := d.VolumeSnapshot(d.Context, v.ID, name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// testCapabilities asserts that the operations of the capabilities the
+// driver does not support return types.ErrNotImplemented.
+func testCapabilities(t *testing.T, d *Driver) {
+	caps := d.capabilities(t)
+	ctx := d.Context
+
+	snapshotID := "missing"
+	if caps[types.DriverCapabilitySnapshots] {
+		v := d.newVolume(t, "v0")
+		snapshotID = d.newSnapshot(t, v, "s0").ID
+	} else {
+		_, err := d.Snapshots(ctx, nil)
+		assert.Equal(t, types.ErrNotImplemented, err)
+		_, err = d.VolumeSnapshot(ctx, d.MissingVolumeID, "s0", nil)
+		assert.Equal(t, types.ErrNotImplemented, err)
+	}
+
+	if !caps[types.DriverCapabilitySnapshotCopy] {
+		_, err := d.SnapshotCopy(ctx, snapshotID, "s1", "", nil)
+		assert.Equal(t, types.ErrNotImplemented, err)
+	}
+	if !caps[types.DriverCapabilityCreateFromSnapshot] {
+		_, err := d.VolumeCreateFromSnapshot(
+			ctx, snapshotID, "v1", &types.VolumeCreateOpts{})
+		assert.Equal(t, types.ErrNotImplemented, err)
+	}
+	if !caps[types.DriverCapabilityCopy] {
+		_, err := d.VolumeCopy(ctx, d.MissingVolumeID, "v1", nil)
+		assert.Equal(t, types.ErrNotImplemented, err)
+	}
+
+	_, resize := d.StorageDriver.(types.StorageDriverWithResize)
+	assert.Equal(t, resize, caps[types.DriverCapabilityResize],
+		"the resize capability does not match the driver's functions")
+}
+
+func testVolumeSnapshot(t *testing.T, d *Driver) {
+	if !d.capabilities(t)[types.DriverCapabilitySnapshots] {
+		return
+	}
+	ctx := d.Context
+	v := d.newVolume(t, "v0")
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "s0", s.Name)
+	assert.Equal(t, v.ID, s.VolumeID)
+	assert.Equal(t, v.Size, s.VolumeSize)
+	assert.NotZero(t, s.StartTime)
+
+	snap, err := d.SnapshotInspect(ctx, s.ID, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, s.ID, snap.ID)
+		assert.Equal(t, "s0", snap.Name)
+	}
+
+	_, err = d.VolumeSnapshot(ctx, d.MissingVolumeID, "s1", nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, d.SnapshotRemove(ctx, s.ID, nil))
+	_, err = d.SnapshotInspect(ctx, s.ID, nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+	assert.Error(t, d.SnapshotRemove(ctx, s.ID, nil))
+}
+
+func testSnapshots(t *testing.T, d *Driver) {
+	if !d.capabilities(t)[types.DriverCapabilitySnapshots] {
+		return
+	}
+	v := d.newVolume(t, "v0")
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		ids = append(ids, d.newSnapshot(t, v, fmt.Sprintf("s%d", i)).ID)
+	}
+
+	snaps, err := d.Snapshots(d.Context, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var snapIDs []string
+	for _, s := range snaps {
+		snapIDs = append(snapIDs, s.ID)
+	}
+	sort.Strings(ids)
+	sort.Strings(snapIDs)
+	assert.Equal(t, ids, snapIDs)
+}
+
+func testVolumeCreateFromSnapshot(t *testing.T, d *Driver) {
+	caps := d.capabilities(t)
+	if !caps[types.DriverCapabilitySnapshots] ||
+		!caps[types.DriverCapabilityCreateFromSnapshot] {
+		return
+	}
+	ctx := d.Context
+	v := d.newVolume(t, "v0")
+	s := d.newSnapshot(t, v, "s0")
+
+	// the volume is the size of the snapshot unless it is overridden, and
+	// the caller's options are not modified
+	opts := &types.VolumeCreateOpts{}
+	nv, err := d.VolumeCreateFromSnapshot(ctx, s.ID, "v1", opts)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, v.ID, nv.ID)
+		assert.Equal(t, "v1", nv.Name)
+		assert.Equal(t, v.Size, nv.Size)
+	}
+	assert.Equal(t, &types.VolumeCreateOpts{}, opts)
+
+	size := v.Size * 2
+	nv, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v2", &types.VolumeCreateOpts{Size: &size})
+	if assert.NoError(t, err) {
+		assert.Equal(t, size, nv.Size)
+	}
+
+	// the volume cannot be smaller than the snapshot
+	size = v.Size / 2
+	_, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v3", &types.VolumeCreateOpts{Size: &size})
+	assert.Error(t, err)
+
+	removed := d.newSnapshot(t, v, "s1")
+	if assert.NoError(t, d.SnapshotRemove(ctx, removed.ID, nil)) {
+		_, err = d.VolumeCreateFromSnapshot(
+			ctx, removed.ID, "v3", &types.VolumeCreateOpts{})
+		assert.IsType(t, &types.ErrNotFound{}, err)
+	}
+}
+
+func testVolumeCopy(t *testing.T, d *Driver) {
+	if !d.capabilities(t)[types.DriverCapabilityCopy] {
+		return
+	}
+	ctx := d.Context
+	v := d.newVolume(t, "v0")
+
+	nv, err := d.VolumeCopy(ctx, v.ID, "v1", nil)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, v.ID, nv.ID)
+		assert.Equal(t, "v1", nv.Name)
+		assert.Equal(t, v.Size, nv.Size)
+	}
+
+	_, err = d.VolumeCopy(ctx, d.MissingVolumeID, "v2", nil)
+	assert.Error(t, err)
+}
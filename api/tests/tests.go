@@ -62,6 +62,9 @@ func (sr *suiteRunner) Describe() {
 			It("list all volumes for all services", func() {
 				t.itClientSpecListVolumes()
 			})
+			It("inspect the service's capabilities", func() {
+				t.itClientSpecInspectServiceCapabilities()
+			})
 
 			Context("w service", func() {
 				BeforeEach(func() {
@@ -77,6 +80,15 @@ func (sr *suiteRunner) Describe() {
 				It("create a new volume", func() {
 					t.itClientSvcSpecCreateVolume()
 				})
+				It("create a volume twice in a transaction", func() {
+					t.itClientSvcSpecCreateVolumeTwice()
+				})
+				It("create a volume asynchronously", func() {
+					t.itClientSvcSpecCreateVolumeAsync()
+				})
+				It("wait for a failed task", func() {
+					t.itClientSvcSpecWaitTaskError()
+				})
 
 				Context("w volume", func() {
 					AfterEach(func() {
@@ -95,6 +107,9 @@ func (sr *suiteRunner) Describe() {
 					It("attach the volume", func() {
 						t.itVolumeSpecAttach()
 					})
+					It("resize the volume", func() {
+						t.itVolumeSpecResize()
+					})
 
 					Context("that is attached", func() {
 						JustBeforeEach(func() {
@@ -113,6 +128,9 @@ func (sr *suiteRunner) Describe() {
 						It("deatch", func() {
 							t.itAttVolumeSpecDetach()
 						})
+						It("unmount", func() {
+							t.itAttVolumeSpecUnmount()
+						})
 					}) // Context w att volume
 
 				}) // Context w volume
@@ -121,6 +139,34 @@ func (sr *suiteRunner) Describe() {
 
 		}) // Context w client
 
+		Context("w client w failover hosts", func() {
+			BeforeEach(func() {
+				t.beforeEachHostsFailover()
+			})
+			JustBeforeEach(func() {
+				t.justBeforeEachHostsClientSpec()
+			})
+			It("fail over to the live host", func() {
+				t.itHostsFailover()
+			})
+		}) // Context w client w failover hosts
+
+		Context("w client w load balanced hosts", func() {
+			BeforeEach(func() {
+				t.beforeEachHostsLoadBalance()
+			})
+			JustBeforeEach(func() {
+				t.initServer2()
+				t.justBeforeEachHostsClientSpec()
+			})
+			It("distribute reads across the hosts", func() {
+				t.itHostsLoadBalance()
+			})
+			It("pin a transaction to one host", func() {
+				t.itHostsLoadBalanceTxPinned()
+			})
+		}) // Context w client w load balanced hosts
+
 		// withClients is used to validate the following tls connections
 		withClients := func() {
 			Context("w client", func() {
@@ -2,6 +2,8 @@ package tests
 
 import (
 	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
 	apiclient "github.com/codedellemc/libstorage/client"
 )
 
@@ -15,16 +17,38 @@ func (t *testRunner) justBeforeEachClientSpec() {
 func (t *testRunner) itClientSpecListRootResources() {
 	roots, err := t.client.API().Root(t.ctx)
 	Ω(err).ToNot(HaveOccurred())
-	Ω(roots).To(HaveLen(5))
+	Ω(roots).To(HaveLen(6))
 }
 
 func (t *testRunner) itClientSpecListVolumes() {
-	vols, err := t.client.API().Volumes(t.ctx, 0)
+	vols, err := t.client.API().Volumes(t.ctx, 0, "")
 	Ω(err).ToNot(HaveOccurred())
 	Ω(vols).Should(HaveLen(1))
 	Ω(vols[t.driverName]).Should(BeEmpty())
 }
 
+func (t *testRunner) itClientSpecInspectServiceCapabilities() {
+	info, err := t.client.API().ServiceInspect(t.ctx, t.driverName)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(info.Driver).ShouldNot(BeNil())
+
+	d, err := registry.NewStorageDriver(t.driverName)
+	Ω(err).ToNot(HaveOccurred())
+	sd, ok := d.(types.StorageDriverWithCapabilities)
+	if !ok {
+		// the capabilities of a driver that does not declare them are
+		// inferred from the optional interfaces the driver implements
+		_, resize := d.(types.StorageDriverWithResize)
+		Ω(info.Driver.Capabilities).Should(HaveKeyWithValue(
+			types.DriverCapabilityResize, resize))
+		return
+	}
+
+	caps, err := sd.Capabilities(t.ctx)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(info.Driver.Capabilities).Should(Equal(caps))
+}
+
 func (t *testRunner) beforeEachClientServiceSpec() {
 	t.ctx = t.ctx.WithValue(context.ServiceKey, t.driverName)
 }
@@ -43,3 +67,64 @@ func (t *testRunner) itClientSvcSpecCreateVolume() {
 	t.Θ(t.client.Storage().VolumeCreate(t.ctx, t.volName, t.volCreateOpts()))
 	t.Ε(t.client.Storage().VolumeRemove(t.ctx, t.volID, t.volRemoveOpts()))
 }
+
+func (t *testRunner) itClientSvcSpecCreateVolumeTwice() {
+	ctx := context.RequireTX(t.ctx)
+	api := t.client.API()
+	req := &types.VolumeCreateRequest{Name: t.volName}
+
+	vol, err := api.VolumeCreate(ctx, t.driverName, req)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(vol).ShouldNot(BeNil())
+
+	// sending the same request again as part of the same transaction is a
+	// new request with its own idempotency key, not a replay of the first
+	vol2, err := api.VolumeCreate(ctx, t.driverName, req)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(vol2).ShouldNot(BeNil())
+	Ω(vol2.ID).ShouldNot(Equal(vol.ID))
+
+	for _, v := range []*types.Volume{vol, vol2} {
+		err := api.VolumeRemove(ctx, t.driverName, v.ID, false)
+		Ω(err).ToNot(HaveOccurred())
+	}
+}
+
+func (t *testRunner) itClientSvcSpecCreateVolumeAsync() {
+	api := t.client.API()
+	req := &types.VolumeCreateRequest{Name: t.volName}
+
+	task, err := api.VolumeCreateAsync(t.ctx, t.driverName, req)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(task).ShouldNot(BeNil())
+
+	vol := &types.Volume{}
+	task, err = api.WaitTask(t.ctx, task.ID, vol)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(task.State).Should(BeEquivalentTo(types.TaskStateSuccess))
+	Ω(vol.Name).Should(Equal(t.volName))
+
+	// inspecting the completed task returns its result
+	task, err = api.TaskInspect(t.ctx, task.ID)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(task.Result).Should(HaveKeyWithValue("id", vol.ID))
+
+	task, err = api.VolumeRemoveAsync(t.ctx, t.driverName, vol.ID, false)
+	Ω(err).ToNot(HaveOccurred())
+	task, err = api.WaitTask(t.ctx, task.ID, nil)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(task.State).Should(BeEquivalentTo(types.TaskStateSuccess))
+}
+
+func (t *testRunner) itClientSvcSpecWaitTaskError() {
+	api := t.client.API()
+
+	task, err := api.VolumeRemoveAsync(t.ctx, t.driverName, t.volName, false)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(task).ShouldNot(BeNil())
+
+	task, err = api.WaitTask(t.ctx, task.ID, nil)
+	Ω(err).To(HaveOccurred())
+	Ω(task.State).Should(BeEquivalentTo(types.TaskStateError))
+	Ω(task.Error).Should(HaveOccurred())
+}
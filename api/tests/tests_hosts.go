@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/akutz/gotil"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	apiserver "github.com/codedellemc/libstorage/api/server"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+	apiclient "github.com/codedellemc/libstorage/client"
+)
+
+const (
+	// hostsConfigFileFormat is the configuration file format used by the
+	// specs that configure the client with multiple hosts
+	hostsConfigFileFormat = `
+libstorage:
+  host:
+  - %[1]s://%[2]s
+  - %[1]s://%[3]s
+  client:
+    loadBalance: %[5]v
+  server:
+    endpoints:
+      localhost:
+        address: %[1]s://%[4]s
+    services:
+      %[6]s:
+        driver: %[6]s
+`
+
+	// serverConfigFileFormat is the configuration file format used to start
+	// additional servers
+	serverConfigFileFormat = `
+libstorage:
+  server:
+    endpoints:
+      localhost:
+        address: %[1]s://%[2]s
+    services:
+      %[3]s:
+        driver: %[3]s
+`
+)
+
+// newLAddr returns an address for the runner's protocol at which nothing is
+// listening.
+func (t *testRunner) newLAddr() string {
+	if t.proto == protoUnix {
+		return utils.GetTempSockFile(t.ctx)
+	}
+	return fmt.Sprintf("127.0.0.1:%d", gotil.RandomTCPPort())
+}
+
+func (t *testRunner) beforeEachHostsFailover() {
+	t.configFileData = []byte(fmt.Sprintf(
+		hostsConfigFileFormat,
+		t.proto, t.newLAddr(), t.laddr, t.laddr, false, t.driverName))
+}
+
+func (t *testRunner) beforeEachHostsLoadBalance() {
+	t.laddr2 = t.newLAddr()
+	t.configFileData = []byte(fmt.Sprintf(
+		hostsConfigFileFormat,
+		t.proto, t.laddr, t.laddr2, t.laddr, true, t.driverName))
+}
+
+func (t *testRunner) initServer2() {
+	config := registry.NewConfig()
+	Ω(config.ReadConfig(bytes.NewReader([]byte(fmt.Sprintf(
+		serverConfigFileFormat,
+		t.proto, t.laddr2, t.driverName))))).ToNot(HaveOccurred())
+
+	var err error
+	t.server2, t.srvErr2, err = apiserver.Serve(t.ctx, config)
+	Ω(err).ToNot(HaveOccurred())
+	go func() {
+		defer GinkgoRecover()
+		if err = <-t.srvErr2; err != nil {
+			Fail(err.Error())
+		}
+	}()
+}
+
+func (t *testRunner) justBeforeEachHostsClientSpec() {
+	t.client, t.err = apiclient.New(t.ctx, t.config)
+	Ω(t.err).ToNot(HaveOccurred())
+	Ω(t.client).ShouldNot(BeNil())
+}
+
+func (t *testRunner) itHostsFailover() {
+	roots, err := t.client.API().Root(t.ctx)
+	Ω(err).ToNot(HaveOccurred())
+	Ω(roots).To(HaveLen(6))
+	Ω(t.client.API().ServerName()).To(Equal(t.server.Name()))
+
+	t.ctx = t.ctx.WithValue(context.ServiceKey, t.driverName)
+	t.Θ(t.client.Storage().VolumeCreate(t.ctx, t.volName, t.volCreateOpts()))
+	t.Ε(t.client.Storage().VolumeRemove(t.ctx, t.volID, t.volRemoveOpts()))
+}
+
+func (t *testRunner) itHostsLoadBalance() {
+	names := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		_, err := t.client.API().Root(t.ctx)
+		Ω(err).ToNot(HaveOccurred())
+		names[t.client.API().ServerName()] = true
+	}
+	Ω(names).To(HaveLen(2))
+	Ω(names).To(HaveKey(t.server.Name()))
+	Ω(names).To(HaveKey(t.server2.Name()))
+}
+
+func (t *testRunner) itHostsLoadBalanceTxPinned() {
+	ctx := context.RequireTX(t.ctx)
+	api := t.client.API()
+
+	vol, err := api.VolumeCreate(
+		ctx, t.driverName, &types.VolumeCreateRequest{Name: t.volName})
+	Ω(err).ToNot(HaveOccurred())
+	Ω(vol).ShouldNot(BeNil())
+	serverName := api.ServerName()
+
+	for i := 0; i < 4; i++ {
+		_, err := api.VolumeInspect(ctx, t.driverName, vol.ID, 0)
+		Ω(err).ToNot(HaveOccurred())
+		Ω(api.ServerName()).To(Equal(serverName))
+	}
+
+	Ω(api.VolumeRemove(
+		ctx, t.driverName, vol.ID, false)).ToNot(HaveOccurred())
+	Ω(api.ServerName()).To(Equal(serverName))
+}
@@ -46,6 +46,8 @@ type testRunner struct {
 	client          types.Client
 	server          types.Server
 	srvErr          <-chan error
+	server2         types.Server
+	srvErr2         <-chan error
 	store           types.Store
 	vol             *types.Volume
 	driverName      string
@@ -53,6 +55,7 @@ type testRunner struct {
 	usrHome         string
 	proto           string
 	laddr           string
+	laddr2          string
 	serverCrt       string
 	serverKey       string
 	clientCrt       string
@@ -101,6 +104,7 @@ func (t *testRunner) afterEach() {
 	t.usrHome = ""
 	t.proto = ""
 	t.laddr = ""
+	t.laddr2 = ""
 	t.client = nil
 	t.pathConfig = nil
 	t.configFileData = nil
@@ -121,6 +125,12 @@ func (t *testRunner) afterEach() {
 		Ω(<-t.srvErr).ToNot(HaveOccurred())
 	}
 
+	if t.server2 != nil {
+		Ω(t.server2.Close()).ToNot(HaveOccurred())
+		t.server2 = nil
+		Ω(<-t.srvErr2).ToNot(HaveOccurred())
+	}
+
 	os.Setenv("LIBSTORAGE_TLS_SOCKITTOME", "")
 	os.Setenv("LIBSTORAGE_TLS_SERVERNAME", "")
 	os.Setenv("LIBSTORAGE_TLS_CERTFILE", "")
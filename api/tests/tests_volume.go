@@ -1,5 +1,14 @@
 package tests
 
+import (
+	"time"
+
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+	apiclient "github.com/codedellemc/libstorage/client"
+)
+
 func (t *testRunner) justBeforeEachVolumeSpec() {
 	t.vol, t.err = t.client.Storage().VolumeCreate(
 		t.ctx, t.volName, t.volCreateOpts())
@@ -27,6 +36,27 @@ func (t *testRunner) itVolumeSpecAttach() {
 	t.Α(t.client.Storage().VolumeAttach(t.ctx, t.vol.ID, t.volAttOpts()))
 }
 
+func (t *testRunner) itVolumeSpecResize() {
+	d, err := registry.NewStorageDriver(t.driverName)
+	Ω(err).ToNot(HaveOccurred())
+	if _, ok := d.(types.StorageDriverWithResize); !ok {
+		Skip("driver does not support resize")
+	}
+
+	sd, ok := t.client.Storage().(types.StorageDriverWithResize)
+	Ω(ok).Should(BeTrue())
+
+	size := t.vol.Size + 1
+	vol, err := sd.VolumeResize(
+		t.ctx, t.vol.ID, &types.VolumeResizeOpts{Size: size, Opts: t.store})
+	t.Θ(vol, err)
+	Ω(vol.Size).Should(Equal(size))
+
+	_, err = sd.VolumeResize(
+		t.ctx, t.vol.ID, &types.VolumeResizeOpts{Size: 0, Opts: t.store})
+	Ω(err).Should(HaveOccurred())
+}
+
 func (t *testRunner) justBeforeEachAttVolumeSpec() {
 	t.vol, t.nextDev, t.err = t.client.Storage().VolumeAttach(
 		t.ctx, t.vol.ID, t.volAttOpts())
@@ -48,3 +78,32 @@ func (t *testRunner) itAttVolumeSpecInspectAttOrAvai() {
 func (t *testRunner) itAttVolumeSpecDetach() {
 	t.Θ(t.client.Storage().VolumeDetach(t.ctx, t.vol.ID, t.volDetOpts()))
 }
+
+func (t *testRunner) itAttVolumeSpecUnmount() {
+	// the executor may still list the device after the volume is detached,
+	// in which case the unmount fails once the timeout expires even though
+	// the volume is detached
+	t.config.Set(types.ConfigDeviceDetachTimeout, "1s")
+	client, err := apiclient.New(t.ctx, t.config)
+	Ω(err).ToNot(HaveOccurred())
+	if client.Integration() == nil {
+		Skip("client does not have an integration driver")
+	}
+
+	start := time.Now()
+	vol, err := client.Integration().Unmount(
+		t.ctx, t.vol.ID, "", utils.NewStore())
+	Ω(time.Since(start)).Should(BeNumerically("<", 30*time.Second))
+	if err == nil {
+		Ω(vol).ShouldNot(BeNil())
+		Ω(vol.Attachments).Should(BeEmpty())
+		return
+	}
+
+	Ω(err.Error()).Should(Equal("device still present on host after detach"))
+	Ω(vol).Should(BeNil())
+	vol, err = t.client.Storage().VolumeInspect(
+		t.ctx, t.vol.ID, t.volInsOpts(1))
+	Ω(err).ToNot(HaveOccurred())
+	Ω(vol.Attachments).Should(BeEmpty())
+}
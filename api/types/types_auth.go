@@ -18,6 +18,13 @@ type AuthToken struct {
 
 	// Encoded is the encoded JWT string.
 	Encoded string `json:"enc"`
+
+	// Scope is the list of permissions granted directly to the token.
+	Scope []string `json:"scope,omitempty"`
+
+	// Roles is the list of roles assigned to the token. A role grants the
+	// permissions mapped to it by the auth configuration.
+	Roles []string `json:"roles,omitempty"`
 }
 
 // String returns the subject of the security token.
@@ -42,4 +49,61 @@ type AuthConfig struct {
 
 	// Alg is the cryptographic algorithm used to sign and verify the token.
 	Alg string
+
+	// Roles is a map of role names and the permissions granted by each role.
+	// Permissions are enforced only when at least one role is defined.
+	Roles map[string][]string
 }
+
+const (
+	// AuthPermAll is the permission that grants all other permissions.
+	AuthPermAll = "*"
+
+	// AuthPermVolumesList is the permission to list volumes.
+	AuthPermVolumesList = "volumes:list"
+
+	// AuthPermVolumesInspect is the permission to inspect a volume.
+	AuthPermVolumesInspect = "volumes:inspect"
+
+	// AuthPermVolumesCreate is the permission to create a volume.
+	AuthPermVolumesCreate = "volumes:create"
+
+	// AuthPermVolumesCopy is the permission to copy a volume.
+	AuthPermVolumesCopy = "volumes:copy"
+
+	// AuthPermVolumesSnapshot is the permission to snapshot a volume.
+	AuthPermVolumesSnapshot = "volumes:snapshot"
+
+	// AuthPermVolumesResize is the permission to resize a volume.
+	AuthPermVolumesResize = "volumes:resize"
+
+	// AuthPermVolumesAttach is the permission to attach a volume.
+	AuthPermVolumesAttach = "volumes:attach"
+
+	// AuthPermVolumesDetach is the permission to detach a volume.
+	AuthPermVolumesDetach = "volumes:detach"
+
+	// AuthPermVolumesRemove is the permission to remove a volume.
+	AuthPermVolumesRemove = "volumes:remove"
+
+	// AuthPermSnapshotsList is the permission to list snapshots.
+	AuthPermSnapshotsList = "snapshots:list"
+
+	// AuthPermSnapshotsInspect is the permission to inspect a snapshot.
+	AuthPermSnapshotsInspect = "snapshots:inspect"
+
+	// AuthPermSnapshotsCopy is the permission to copy a snapshot.
+	AuthPermSnapshotsCopy = "snapshots:copy"
+
+	// AuthPermSnapshotsRemove is the permission to remove a snapshot.
+	AuthPermSnapshotsRemove = "snapshots:remove"
+
+	// AuthPermTasksWatch is the permission to watch tasks.
+	AuthPermTasksWatch = "tasks:watch"
+
+	// AuthPermTasksCancel is the permission to cancel a task.
+	AuthPermTasksCancel = "tasks:cancel"
+
+	// AuthPermMetricsRead is the permission to read the server's metrics.
+	AuthPermMetricsRead = "metrics:read"
+)
@@ -102,16 +102,48 @@ type APIClient interface {
 	// ServiceInspect returns information about a service.
 	ServiceInspect(ctx Context, name string) (*ServiceInfo, error)
 
-	// Volumes returns a list of all Volumes for all Services.
+	// Volumes returns a list of all Volumes for all Services. If the filter
+	// is not empty then only the Volumes that match the filter are returned.
 	Volumes(
 		ctx Context,
-		attachments VolumeAttachmentsTypes) (ServiceVolumeMap, error)
+		attachments VolumeAttachmentsTypes,
+		filter string) (ServiceVolumeMap, error)
 
-	// VolumesByService returns a list of all Volumes for a service.
+	// VolumesByService returns a list of all Volumes for a service. If the
+	// filter is not empty then only the Volumes that match the filter are
+	// returned.
 	VolumesByService(
 		ctx Context,
 		service string,
-		attachments VolumeAttachmentsTypes) (VolumeMap, error)
+		attachments VolumeAttachmentsTypes,
+		filter string) (VolumeMap, error)
+
+	// VolumesPage returns a page of the Volumes for all Services and the
+	// token used to request the next page. The token is empty if there are
+	// no more pages.
+	VolumesPage(
+		ctx Context,
+		attachments VolumeAttachmentsTypes,
+		filter string,
+		page *PageOpts) (ServiceVolumeMap, string, error)
+
+	// VolumesByServicePage returns a page of the Volumes for a service and
+	// the token used to request the next page. The token is empty if there
+	// are no more pages.
+	VolumesByServicePage(
+		ctx Context,
+		service string,
+		attachments VolumeAttachmentsTypes,
+		filter string,
+		page *PageOpts) (VolumeMap, string, error)
+
+	// VolumePages returns an iterator over the pages of the Volumes for all
+	// Services. Each page contains at most limit Volumes.
+	VolumePages(
+		ctx Context,
+		attachments VolumeAttachmentsTypes,
+		filter string,
+		limit int) *VolumePageIterator
 
 	// VolumeInspect gets information about a single volume by ID.
 	VolumeInspect(
@@ -174,6 +206,13 @@ type APIClient interface {
 		service string,
 		request *VolumeDetachRequest) (VolumeMap, error)
 
+	// VolumeResize resizes a single volume.
+	VolumeResize(
+		ctx Context,
+		service string,
+		volumeID string,
+		request *VolumeResizeRequest) (*Volume, error)
+
 	// VolumeSnapshot creates a single snapshot.
 	VolumeSnapshot(
 		ctx Context,
@@ -181,12 +220,37 @@ type APIClient interface {
 		volumeID string,
 		request *VolumeSnapshotRequest) (*Snapshot, error)
 
-	// Snapshots returns a list of all Snapshots for all
-	Snapshots(ctx Context) (ServiceSnapshotMap, error)
+	// Snapshots returns a list of all Snapshots for all services. If the
+	// filter is not empty then only the Snapshots that match the filter are
+	// returned.
+	Snapshots(ctx Context, filter string) (ServiceSnapshotMap, error)
 
 	// SnapshotsByService returns a list of all Snapshots for a single service.
+	// If the filter is not empty then only the Snapshots that match the
+	// filter are returned.
 	SnapshotsByService(
-		ctx Context, service string) (SnapshotMap, error)
+		ctx Context, service, filter string) (SnapshotMap, error)
+
+	// SnapshotsPage returns a page of the Snapshots for all services and the
+	// token used to request the next page. The token is empty if there are
+	// no more pages.
+	SnapshotsPage(
+		ctx Context,
+		filter string,
+		page *PageOpts) (ServiceSnapshotMap, string, error)
+
+	// SnapshotsByServicePage returns a page of the Snapshots for a single
+	// service and the token used to request the next page. The token is empty
+	// if there are no more pages.
+	SnapshotsByServicePage(
+		ctx Context,
+		service, filter string,
+		page *PageOpts) (SnapshotMap, string, error)
+
+	// SnapshotPages returns an iterator over the pages of the Snapshots for
+	// all services. Each page contains at most limit Snapshots.
+	SnapshotPages(
+		ctx Context, filter string, limit int) *SnapshotPageIterator
 
 	// SnapshotInspect gets information about a single snapshot.
 	SnapshotInspect(
@@ -203,4 +267,109 @@ type APIClient interface {
 		ctx Context,
 		service, snapshotID string,
 		request *SnapshotCopyRequest) (*Snapshot, error)
+
+	// Tasks returns the server's tasks.
+	Tasks(ctx Context) (map[int]*Task, error)
+
+	// TaskInspect inspects a single task.
+	TaskInspect(ctx Context, taskID int) (*Task, error)
+
+	// TaskCancel cancels a task and returns the task once it is no longer
+	// running.
+	TaskCancel(ctx Context, taskID int) (*Task, error)
+
+	// WaitTask polls the task until it is completed and decodes the task's
+	// result into reply, if reply is not nil. An error is returned if the
+	// task failed or was canceled.
+	WaitTask(ctx Context, taskID int, reply interface{}) (*Task, error)
+
+	// VolumeCreateAsync creates a single volume without waiting for the
+	// volume to be created. The result of the returned task is a Volume.
+	VolumeCreateAsync(
+		ctx Context,
+		service string,
+		request *VolumeCreateRequest) (*Task, error)
+
+	// VolumeCreateFromSnapshotAsync creates a single volume from a snapshot
+	// without waiting for the volume to be created. The result of the
+	// returned task is a Volume.
+	VolumeCreateFromSnapshotAsync(
+		ctx Context,
+		service, snapshotID string,
+		request *VolumeCreateRequest) (*Task, error)
+
+	// VolumeCopyAsync copies a single volume without waiting for the copy to
+	// be created. The result of the returned task is a Volume.
+	VolumeCopyAsync(
+		ctx Context,
+		service, volumeID string,
+		request *VolumeCopyRequest) (*Task, error)
+
+	// VolumeRemoveAsync removes a single volume without waiting for the
+	// volume to be removed. The returned task has no result.
+	VolumeRemoveAsync(
+		ctx Context,
+		service, volumeID string,
+		force bool) (*Task, error)
+
+	// VolumeAttachAsync attaches a single volume without waiting for the
+	// volume to be attached. The result of the returned task is a
+	// VolumeAttachResponse.
+	VolumeAttachAsync(
+		ctx Context,
+		service string,
+		volumeID string,
+		request *VolumeAttachRequest) (*Task, error)
+
+	// VolumeDetachAsync detaches a single volume without waiting for the
+	// volume to be detached. The result of the returned task is a Volume.
+	VolumeDetachAsync(
+		ctx Context,
+		service string,
+		volumeID string,
+		request *VolumeDetachRequest) (*Task, error)
+
+	// VolumeDetachAllAsync detaches all volumes from all services without
+	// waiting for the volumes to be detached. The result of the returned
+	// task is a ServiceVolumeMap.
+	VolumeDetachAllAsync(
+		ctx Context,
+		request *VolumeDetachRequest) (*Task, error)
+
+	// VolumeDetachAllForServiceAsync detaches all volumes from a service
+	// without waiting for the volumes to be detached. The result of the
+	// returned task is a VolumeMap.
+	VolumeDetachAllForServiceAsync(
+		ctx Context,
+		service string,
+		request *VolumeDetachRequest) (*Task, error)
+
+	// VolumeResizeAsync resizes a single volume without waiting for the
+	// volume to be resized. The result of the returned task is a Volume.
+	VolumeResizeAsync(
+		ctx Context,
+		service string,
+		volumeID string,
+		request *VolumeResizeRequest) (*Task, error)
+
+	// VolumeSnapshotAsync creates a single snapshot without waiting for the
+	// snapshot to be created. The result of the returned task is a Snapshot.
+	VolumeSnapshotAsync(
+		ctx Context,
+		service string,
+		volumeID string,
+		request *VolumeSnapshotRequest) (*Task, error)
+
+	// SnapshotRemoveAsync removes a single snapshot without waiting for the
+	// snapshot to be removed. The returned task has no result.
+	SnapshotRemoveAsync(
+		ctx Context,
+		service, snapshotID string) (*Task, error)
+
+	// SnapshotCopyAsync copies a snapshot without waiting for the copy to be
+	// created. The result of the returned task is a Snapshot.
+	SnapshotCopyAsync(
+		ctx Context,
+		service, snapshotID string,
+		request *SnapshotCopyRequest) (*Task, error)
 }
@@ -93,6 +93,21 @@ const (
 	// ConfigClientCacheInstanceID is a config key.
 	ConfigClientCacheInstanceID = ConfigClient + ".cache.instanceID"
 
+	// ConfigClientLoadBalance is a config key.
+	ConfigClientLoadBalance = ConfigClient + ".loadBalance"
+
+	// ConfigClientHealthCheckInterval is a config key.
+	ConfigClientHealthCheckInterval = ConfigClient + ".healthCheckInterval"
+
+	// ConfigClientRetries is a config key.
+	ConfigClientRetries = ConfigClient + ".retries"
+
+	// ConfigClientRetryDelay is a config key.
+	ConfigClientRetryDelay = ConfigClient + ".retryDelay"
+
+	// ConfigClientProtocol is a config key.
+	ConfigClientProtocol = ConfigClient + ".protocol"
+
 	// ConfigTLS is a config key.
 	ConfigTLS = ConfigRoot + ".tls"
 
@@ -126,6 +141,9 @@ const (
 	// ConfigDeviceAttachTimeout is a config key.
 	ConfigDeviceAttachTimeout = ConfigRoot + ".device.attachTimeout"
 
+	// ConfigDeviceDetachTimeout is a config key.
+	ConfigDeviceDetachTimeout = ConfigRoot + ".device.detachTimeout"
+
 	// ConfigDeviceScanType is a config key.
 	ConfigDeviceScanType = ConfigRoot + ".device.scanType"
 
@@ -140,8 +158,99 @@ const (
 	ConfigServerTasksExeTimeout = ConfigServerTasks + ".exeTimeout"
 
 	// ConfigServerTasksLogTimeout is a config key.
+	//
+	// Deprecated: Completed tasks are removed after ConfigServerTasksTTL.
 	ConfigServerTasksLogTimeout = ConfigServerTasks + ".logTimeout"
 
+	// ConfigServerTasksTTL is a config key.
+	ConfigServerTasksTTL = ConfigServerTasks + ".ttl"
+
+	// ConfigServerTasksStore is a config key.
+	ConfigServerTasksStore = ConfigServerTasks + ".store"
+
+	// ConfigServerTasksStoreType is a config key.
+	ConfigServerTasksStoreType = ConfigServerTasksStore + ".type"
+
+	// ConfigServerTasksStoreFile is a config key.
+	ConfigServerTasksStoreFile = ConfigServerTasksStore + ".file"
+
+	// ConfigServerLocks is a config key.
+	ConfigServerLocks = ConfigServer + ".locks"
+
+	// ConfigServerLocksType is a config key.
+	ConfigServerLocksType = ConfigServerLocks + ".type"
+
+	// ConfigServerLocksTimeout is a config key.
+	ConfigServerLocksTimeout = ConfigServerLocks + ".timeout"
+
+	// ConfigServerLocksDir is a config key.
+	ConfigServerLocksDir = ConfigServerLocks + ".dir"
+
+	// ConfigServerVolumeCache is a config key.
+	ConfigServerVolumeCache = ConfigServer + ".volumeCache"
+
+	// ConfigServerVolumeCacheEnabled is a config key.
+	ConfigServerVolumeCacheEnabled = ConfigServerVolumeCache + ".enabled"
+
+	// ConfigServerVolumeCacheRefreshInterval is a config key.
+	ConfigServerVolumeCacheRefreshInterval = ConfigServerVolumeCache +
+		".refreshInterval"
+
+	// ConfigServerLimits is a config key.
+	ConfigServerLimits = ConfigServer + ".limits"
+
+	// ConfigServerLimitsRate is a config key.
+	ConfigServerLimitsRate = ConfigServerLimits + ".rate"
+
+	// ConfigServerLimitsBurst is a config key.
+	ConfigServerLimitsBurst = ConfigServerLimits + ".burst"
+
+	// ConfigServerLimitsSubjectRate is a config key.
+	ConfigServerLimitsSubjectRate = ConfigServerLimits + ".subjectRate"
+
+	// ConfigServerLimitsSubjectBurst is a config key.
+	ConfigServerLimitsSubjectBurst = ConfigServerLimits + ".subjectBurst"
+
+	// ConfigServerLimitsMaxInFlight is a config key.
+	ConfigServerLimitsMaxInFlight = ConfigServerLimits + ".maxInFlight"
+
+	// ConfigServerLimitsQueue is a config key.
+	ConfigServerLimitsQueue = ConfigServerLimits + ".queue"
+
+	// ConfigServerIdempotency is a config key.
+	ConfigServerIdempotency = ConfigServer + ".idempotency"
+
+	// ConfigServerIdempotencyTTL is a config key.
+	ConfigServerIdempotencyTTL = ConfigServerIdempotency + ".ttl"
+
+	// ConfigServerEvents is a config key.
+	ConfigServerEvents = ConfigServer + ".events"
+
+	// ConfigServerEventsSinks is a config key.
+	ConfigServerEventsSinks = ConfigServerEvents + ".sinks"
+
+	// ConfigServerEventsAudit is a config key.
+	ConfigServerEventsAudit = ConfigServerEvents + ".audit"
+
+	// ConfigServerEventsAuditFile is a config key.
+	ConfigServerEventsAuditFile = ConfigServerEventsAudit + ".file"
+
+	// ConfigServerEventsWebhook is a config key.
+	ConfigServerEventsWebhook = ConfigServerEvents + ".webhook"
+
+	// ConfigServerEventsWebhookURL is a config key.
+	ConfigServerEventsWebhookURL = ConfigServerEventsWebhook + ".url"
+
+	// ConfigServerEventsWebhookTimeout is a config key.
+	ConfigServerEventsWebhookTimeout = ConfigServerEventsWebhook + ".timeout"
+
+	// ConfigServerEventsWebhookRetries is a config key.
+	ConfigServerEventsWebhookRetries = ConfigServerEventsWebhook + ".retries"
+
+	// ConfigServerEventsWebhookRetryDelay is a config key.
+	ConfigServerEventsWebhookRetryDelay = ConfigServerEventsWebhook +
+		".retryDelay"
+
 	// ConfigClientAuth is a config key.
 	ConfigClientAuth = ConfigClient + ".auth"
 
@@ -165,4 +274,7 @@ const (
 
 	// ConfigServerAuthDisabled is a config key.
 	ConfigServerAuthDisabled = ConfigServerAuth + ".disabled"
+
+	// ConfigServerAuthRoles is a config key.
+	ConfigServerAuthRoles = ConfigServerAuth + ".roles"
 )
@@ -22,6 +22,18 @@ const (
 	//ConfigIgVolOpsMountRootPath is a config key.
 	ConfigIgVolOpsMountRootPath = ConfigIgVolOpsMount + ".rootPath"
 
+	//ConfigIgVolOpsMountOptions is a config key.
+	ConfigIgVolOpsMountOptions = ConfigIgVolOpsMount + ".options"
+
+	//ConfigIgVolOpsMountReadOnly is a config key.
+	ConfigIgVolOpsMountReadOnly = ConfigIgVolOpsMount + ".readOnly"
+
+	//ConfigIgVolOpsMountLabel is a config key.
+	ConfigIgVolOpsMountLabel = ConfigIgVolOpsMount + ".label"
+
+	//ConfigIgVolOpsMountFSLabel is a config key.
+	ConfigIgVolOpsMountFSLabel = ConfigIgVolOpsMount + ".fsLabel"
+
 	//ConfigIgVolOpsMountRetryCount is a config key.
 	ConfigIgVolOpsMountRetryCount = ConfigIgVolOpsMount + ".retryCount"
 
@@ -77,6 +77,20 @@ type WaitForDeviceOpts struct {
 	Timeout time.Duration
 }
 
+// WaitForDeviceRemovalOpts are options when waiting on a specific local
+// device to be removed.
+type WaitForDeviceRemovalOpts struct {
+	LocalDevicesOpts
+
+	// DeviceName is the name of the device, ex. /dev/xvdf, that must no
+	// longer appear in the local devices list or exist on the host.
+	DeviceName string
+
+	// Timeout is the maximum duration for which to wait for the device to
+	// be removed.
+	Timeout time.Duration
+}
+
 // NewStorageExecutor is a function that constructs a new StorageExecutors.
 type NewStorageExecutor func() StorageExecutor
 
@@ -178,6 +192,16 @@ type StorageExecutorCLI interface {
 		ctx Context,
 		opts *WaitForDeviceOpts) (bool, *LocalDevices, error)
 
+	// WaitForDeviceRemoval blocks until the provided device no longer appears
+	// in the map returned from LocalDevices and no longer exists on the host
+	// or until the timeout expires, whichever occurs first.
+	//
+	// The return value is a boolean flag indicating whether or not the
+	// device was removed as well as the result of the last LocalDevices call.
+	WaitForDeviceRemoval(
+		ctx Context,
+		opts *WaitForDeviceRemovalOpts) (bool, *LocalDevices, error)
+
 	// Supported returns a flag indicating whether the executor supports
 	// specific functions for a storage platform on the current host.
 	Supported(
@@ -211,6 +235,10 @@ const (
 
 	// LSXSOpMounts indicates an executor supports "Mounts".
 	LSXSOpMounts
+
+	// LSXSOpWaitForDeviceRemoval indicates an executor supports
+	// "WaitForDeviceRemoval".
+	LSXSOpWaitForDeviceRemoval
 )
 
 const (
@@ -224,7 +252,8 @@ const (
 		LSXSOpWaitForDevice |
 		LSXSOpMount |
 		LSXSOpUmount |
-		LSXSOpMounts
+		LSXSOpMounts |
+		LSXSOpWaitForDeviceRemoval
 
 	// LSXOpAllNoMount indicates the executor supports all operations except
 	// mount and unmount.
@@ -273,6 +302,12 @@ func (v LSXSupportedOp) Mounts() bool {
 	return v.bitSet(LSXSOpMounts)
 }
 
+// WaitForDeviceRemoval returns a flag that indicates whether the
+// LSXSOpWaitForDeviceRemoval bit is set.
+func (v LSXSupportedOp) WaitForDeviceRemoval() bool {
+	return v.bitSet(LSXSOpWaitForDeviceRemoval)
+}
+
 func (v LSXSupportedOp) bitSet(b LSXSupportedOp) bool {
 	return v&b == b
 }
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLSXSupportedOp(t *testing.T) {
+	// new bits are added after the existing ones so their values do not
+	// change
+	assert.Equal(t, LSXSupportedOp(64), LSXSOpMounts)
+	assert.Equal(t, LSXSupportedOp(128), LSXSOpWaitForDeviceRemoval)
+
+	assert.True(t, LSXOpAll.WaitForDeviceRemoval())
+	assert.True(t, LSXOpAllNoMount.WaitForDevice())
+	assert.True(t, LSXOpAllNoMount.WaitForDeviceRemoval())
+	assert.False(t, LSXOpAllNoMount.Mount())
+	assert.False(t, LSXSOpNone.WaitForDeviceRemoval())
+}
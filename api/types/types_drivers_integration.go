@@ -8,7 +8,26 @@ type VolumeMountOpts struct {
 	OverwriteFS bool
 	NewFSType   string
 	Preempt     bool
-	Opts        Store
+
+	// MountOptions are the options with which the volume's device is
+	// mounted, such as "noatime,nodev".
+	MountOptions string
+
+	// MountLabel is the SELinux label applied to the mounted file system.
+	MountLabel string
+
+	// ReadOnly attaches and mounts the volume read-only.
+	ReadOnly bool
+
+	// NewFSLabel is the label of the file system created on the volume if
+	// it is formatted.
+	NewFSLabel string
+
+	// NewFSUUID is the UUID of the file system created on the volume if it
+	// is formatted.
+	NewFSUUID string
+
+	Opts Store
 }
 
 // VolumeMapping is a volume's name and the path to which it is mounted.
@@ -94,3 +113,15 @@ type IntegrationDriver interface {
 		volumeName string,
 		opts *VolumeDetachOpts) error
 }
+
+// IntegrationDriverWithResize is an IntegrationDriver with a Resize function.
+type IntegrationDriverWithResize interface {
+	IntegrationDriver
+
+	// Resize grows the volume specified by volumeName or volumeID and, if
+	// the volume is mounted locally, its file system.
+	Resize(
+		ctx Context,
+		volumeID, volumeName string,
+		opts *VolumeResizeOpts) (*Volume, error)
+}
@@ -8,14 +8,37 @@ type DeviceMountOpts struct {
 	MountOptions string
 	MountLabel   string
 	FsType       string
-	Opts         Store
+
+	// ReadOnly mounts the device read-only.
+	ReadOnly bool
+
+	Opts Store
 }
 
 // DeviceFormatOpts are options when formatting a device.
 type DeviceFormatOpts struct {
 	NewFSType   string
 	OverwriteFS bool
-	Opts        Store
+
+	// MkfsOptions are additional options for the command that creates the
+	// file system, such as "-E lazy_itable_init=0" for ext4. If empty, the
+	// options configured for the file system type are used.
+	MkfsOptions string
+
+	// Label is the optional label of the new file system.
+	Label string
+
+	// UUID is the optional UUID of the new file system.
+	UUID string
+
+	Opts Store
+}
+
+// DeviceResizeOpts are options when resizing a device's file system.
+type DeviceResizeOpts struct {
+	FsType     string
+	MountPoint string
+	Opts       Store
 }
 
 // OSDriverManager is the management wrapper for an OSDriver.
@@ -61,3 +84,26 @@ type OSDriver interface {
 		deviceName string,
 		opts *DeviceFormatOpts) error
 }
+
+// OSDriverWithResizeFS is an OSDriver with a ResizeFS function.
+type OSDriverWithResizeFS interface {
+	OSDriver
+
+	// ResizeFS grows a device's file system to fill the device.
+	ResizeFS(
+		ctx Context,
+		deviceName string,
+		opts *DeviceResizeOpts) error
+}
+
+// OSDriverWithFlush is an OSDriver with a Flush function.
+type OSDriverWithFlush interface {
+	OSDriver
+
+	// Flush writes a device's buffered data to the device so that the data
+	// is not lost when the device is detached.
+	Flush(
+		ctx Context,
+		deviceName string,
+		opts Store) error
+}
@@ -229,6 +229,7 @@ type VolumeCreateOpts struct {
 	Type             *string
 	Encrypted        *bool
 	EncryptionKey    *string
+	Labels           map[string]string
 	Opts             Store
 }
 
@@ -236,7 +237,13 @@ type VolumeCreateOpts struct {
 type VolumeAttachOpts struct {
 	NextDevice *string
 	Force      bool
-	Opts       Store
+
+	// ReadOnly requests that the volume be attached read-only. A driver for
+	// a storage platform that cannot attach volumes read-only ignores the
+	// request; the volume may still be mounted read-only.
+	ReadOnly bool
+
+	Opts Store
 }
 
 // VolumeDetachOpts are options for detaching a volume.
@@ -251,6 +258,13 @@ type VolumeRemoveOpts struct {
 	Opts  Store
 }
 
+// VolumeResizeOpts are options for resizing a volume.
+type VolumeResizeOpts struct {
+	Size  int64
+	Force bool
+	Opts  Store
+}
+
 // StorageDriverManager is the management wrapper for a StorageDriver.
 type StorageDriverManager interface {
 	StorageDriver
@@ -387,3 +401,79 @@ type StorageDriverVolInspectByName interface {
 		volumeName string,
 		opts *VolumeInspectOpts) (*Volume, error)
 }
+
+// StorageDriverWithResize is a StorageDriver with a VolumeResize function.
+type StorageDriverWithResize interface {
+	StorageDriver
+
+	// VolumeResize grows a volume to the size specified by the options.
+	VolumeResize(
+		ctx Context,
+		volumeID string,
+		opts *VolumeResizeOpts) (*Volume, error)
+}
+
+// StorageDriverWithDetachAll is a StorageDriver with a VolumeDetachAll
+// function.
+type StorageDriverWithDetachAll interface {
+	StorageDriver
+
+	// VolumeDetachAll detaches every one of a volume's attachments to the
+	// instance. It is not an error if the volume is not attached. All of the
+	// volumes attached to the instance are detached if the volume ID is
+	// empty.
+	VolumeDetachAll(
+		ctx Context,
+		volumeID string,
+		opts *VolumeDetachOpts) error
+}
+
+// StorageDriverWithCapabilities is a StorageDriver that declares which of
+// the optional operations and features it supports. The server rejects the
+// requests for the operations that require a capability the driver does not
+// support before they reach the driver.
+type StorageDriverWithCapabilities interface {
+	StorageDriver
+
+	// Capabilities returns the driver's capabilities. The function is
+	// invoked for every request that requires a capability, so it should
+	// not contact the storage platform.
+	Capabilities(
+		ctx Context) (DriverCapabilities, error)
+}
+
+// PageOpts are the options used to request a page of volumes or snapshots.
+type PageOpts struct {
+
+	// Limit is the maximum number of objects to return. Zero means no limit.
+	Limit int
+
+	// Continue is the token returned with the previous page. An empty token
+	// requests the first page.
+	Continue string
+}
+
+// StorageDriverWithPaging is a StorageDriver that lists volumes and snapshots
+// a page at a time using the storage platform's own page tokens. A driver
+// may return more or fewer objects than the page's limit; the server trims
+// the pages it returns to its clients. However, the driver must return the
+// same objects when the same page is requested again with the same limit.
+// A driver that returns ErrNotImplemented from either function is paged by
+// the server instead.
+type StorageDriverWithPaging interface {
+	StorageDriver
+
+	// VolumesPage returns a page of volumes and the token for the next page.
+	// The token is empty if there are no more pages.
+	VolumesPage(
+		ctx Context,
+		opts *VolumesOpts,
+		page *PageOpts) ([]*Volume, string, error)
+
+	// SnapshotsPage returns a page of snapshots and the token for the next
+	// page. The token is empty if there are no more pages.
+	SnapshotsPage(
+		ctx Context,
+		opts Store,
+		page *PageOpts) ([]*Snapshot, string, error)
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/akutz/goof"
 )
 
@@ -11,6 +13,9 @@ var ErrNotImplemented = goof.New("not implemented")
 // ErrTimedOut is the error that is used to indicate an operation timed out.
 var ErrTimedOut = goof.New("timed out")
 
+// ErrTaskCanceled is the error that is used to indicate a task was canceled.
+var ErrTaskCanceled = goof.New("task canceled")
+
 // ErrUnsupportedForClientType is the error that occurs when an operation is
 // invoked that is unsupported for the current client type.
 type ErrUnsupportedForClientType struct{ goof.Goof }
@@ -48,10 +53,47 @@ type ErrDriverTypeErr struct{ goof.Goof }
 // the objects for which the process did complete.
 type ErrBatchProcess struct{ goof.Goof }
 
+// ErrPermissionDenied occurs when a security token is valid but is not
+// granted the permission required by an operation.
+type ErrPermissionDenied struct{ goof.Goof }
+
 // ErrBadFilter occurs when a bad filter is supplied via the filter query
 // string.
 type ErrBadFilter struct{ goof.Goof }
 
+// ErrBadLabelSelector occurs when a bad label selector is supplied via the
+// labelSelector query string.
+type ErrBadLabelSelector struct{ goof.Goof }
+
+// ErrBadLimit occurs when a bad page size is supplied via the limit query
+// string.
+type ErrBadLimit struct{ goof.Goof }
+
+// ErrBadContinueToken occurs when a bad token is supplied via the continue
+// query string.
+type ErrBadContinueToken struct{ goof.Goof }
+
+// ErrIdempotencyKeyReused occurs when an idempotency key is sent with a
+// request that differs from the one with which the key was first sent.
+type ErrIdempotencyKeyReused struct{ goof.Goof }
+
+// ErrLockTimeout occurs when a task cannot acquire the lock for a resource
+// before the lock timeout elapses, ex. because another task that operates on
+// the same volume is running.
+type ErrLockTimeout struct{ goof.Goof }
+
+// ErrUnsupportedCapability occurs when an operation requires a capability
+// that the service's driver does not support.
+type ErrUnsupportedCapability struct{ goof.Goof }
+
+// ErrRateLimited occurs when a request exceeds a rate limit of a service.
+type ErrRateLimited struct {
+	goof.Goof
+
+	// RetryAfter is the duration after which the request may be sent again.
+	RetryAfter time.Duration `json:"-"`
+}
+
 // ErrMissingStorageService occurs when the storage service is expected in
 // the provided context but is not there.
 var ErrMissingStorageService = goof.New("missing storage service")
@@ -0,0 +1,84 @@
+package types
+
+// EventOperation is the operation recorded by an event.
+type EventOperation string
+
+const (
+	// EventVolumeCreate is emitted when a volume is created.
+	EventVolumeCreate EventOperation = "volume.create"
+
+	// EventVolumeCreateFromSnapshot is emitted when a volume is created from
+	// a snapshot.
+	EventVolumeCreateFromSnapshot EventOperation = "volume.createFromSnapshot"
+
+	// EventVolumeCopy is emitted when a volume is copied.
+	EventVolumeCopy EventOperation = "volume.copy"
+
+	// EventVolumeResize is emitted when a volume is resized.
+	EventVolumeResize EventOperation = "volume.resize"
+
+	// EventVolumeAttach is emitted when a volume is attached.
+	EventVolumeAttach EventOperation = "volume.attach"
+
+	// EventVolumeDetach is emitted when a volume is detached. An event is
+	// emitted for each volume detached by a detach-all request.
+	EventVolumeDetach EventOperation = "volume.detach"
+
+	// EventVolumeRemove is emitted when a volume is removed.
+	EventVolumeRemove EventOperation = "volume.remove"
+
+	// EventSnapshotCreate is emitted when a snapshot is created.
+	EventSnapshotCreate EventOperation = "snapshot.create"
+
+	// EventSnapshotCopy is emitted when a snapshot is copied.
+	EventSnapshotCopy EventOperation = "snapshot.copy"
+
+	// EventSnapshotRemove is emitted when a snapshot is removed.
+	EventSnapshotRemove EventOperation = "snapshot.remove"
+)
+
+// Event is a record of a successful operation that changed a volume or
+// snapshot.
+type Event struct {
+
+	// Time is the epoch at which the operation completed.
+	Time int64 `json:"time" yaml:"time"`
+
+	// Operation is the operation.
+	Operation EventOperation `json:"operation" yaml:"operation"`
+
+	// Service is the name of the service on which the operation occurred.
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+
+	// VolumeID is the ID of the volume on which the operation occurred.
+	VolumeID string `json:"volumeID,omitempty" yaml:"volumeID,omitempty"`
+
+	// SnapshotID is the ID of the snapshot on which the operation occurred.
+	SnapshotID string `json:"snapshotID,omitempty" yaml:"snapshotID,omitempty"`
+
+	// Subject is the subject of the JWT used to authorize the operation.
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+
+	// InstanceID is the ID of the instance that requested the operation.
+	InstanceID string `json:"instanceID,omitempty" yaml:"instanceID,omitempty"`
+
+	// TxID is the ID of the transaction that requested the operation.
+	TxID string `json:"txID,omitempty" yaml:"txID,omitempty"`
+
+	// Result is the object returned by the operation, if any.
+	Result interface{} `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+// NewEventSink is a function that constructs a new EventSink.
+type NewEventSink func() EventSink
+
+// EventSink is a destination to which the server's events are written. A
+// sink that implements io.Closer is closed after its queued events are
+// written when the server is closed.
+type EventSink interface {
+	Driver
+
+	// Emit writes an event to the sink. Emit is never invoked concurrently
+	// for the same sink.
+	Emit(ctx Context, event *Event) error
+}
@@ -39,6 +39,9 @@ const (
 
 	// FilterApproxMatch is the ~= operator.
 	FilterApproxMatch
+
+	// FilterRegexMatch is the =~ operator.
+	FilterRegexMatch
 )
 
 // Filter is an LDAP-style filter string.
@@ -19,6 +19,26 @@ const (
 	// from the server.
 	ServerNameHeader = "Libstorage-Servername"
 
+	// IdempotencyKeyHeader is the HTTP header that contains the key used by
+	// the server to recognize a request that modifies state when the request
+	// is sent again. The server responds to a request with a key it has
+	// already seen with the response to the original request.
+	IdempotencyKeyHeader = "Libstorage-Idempotencykey"
+
+	// ContinueHeader is the HTTP header that contains the token used to
+	// request the next page of a paged listing. The header is omitted from
+	// the last page.
+	ContinueHeader = "Libstorage-Continue"
+
+	// VolumeCacheHeader is the HTTP header that indicates whether the volumes
+	// in a response were read from the server's volume cache. The header's
+	// value is either "hit" or "miss".
+	VolumeCacheHeader = "Libstorage-Volumecache"
+
+	// VolumeCacheAgeHeader is the HTTP header that contains the age, in
+	// seconds, of the oldest cached volume inventory used to build a response.
+	VolumeCacheAgeHeader = "Libstorage-Volumecacheage"
+
 	// AuthorizationHeader is the HTTP header that contains the Authorization
 	// information.
 	AuthorizationHeader = "Authorization"
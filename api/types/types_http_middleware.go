@@ -34,3 +34,17 @@ type Middleware interface {
 		r *http.Request,
 		store Store) error
 }
+
+// SchemaMiddleware is middleware that validates the payloads of a route's
+// requests and responses against JSON schemas.
+type SchemaMiddleware interface {
+	Middleware
+
+	// RequestSchema returns the JSON schema for the route's request payload.
+	// Nil is returned if the route does not accept a payload.
+	RequestSchema() []byte
+
+	// ResponseSchema returns the JSON schema for the route's response
+	// payload. Nil is returned if the route does not return a payload.
+	ResponseSchema() []byte
+}
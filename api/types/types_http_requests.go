@@ -13,6 +13,7 @@ type VolumeCreateRequest struct {
 	IOPS             *int64                 `json:"iops,omitempty"`
 	Size             *int64                 `json:"size,omitempty"`
 	Type             *string                `json:"type,omitempty"`
+	Labels           map[string]string      `json:"labels,omitempty"`
 	Opts             map[string]interface{} `json:"opts,omitempty"`
 }
 
@@ -25,6 +26,7 @@ type VolumeCopyRequest struct {
 // VolumeSnapshotRequest is the JSON body for snapshotting a volume.
 type VolumeSnapshotRequest struct {
 	SnapshotName string                 `json:"snapshotName"`
+	Labels       map[string]string      `json:"labels,omitempty"`
 	Opts         map[string]interface{} `json:"opts,omitempty"`
 }
 
@@ -32,6 +34,7 @@ type VolumeSnapshotRequest struct {
 type VolumeAttachRequest struct {
 	Force          bool                   `json:"force,omitempty"`
 	NextDeviceName *string                `json:"nextDeviceName,omitempty"`
+	ReadOnly       bool                   `json:"readOnly,omitempty"`
 	Opts           map[string]interface{} `json:"opts,omitempty"`
 }
 
@@ -41,6 +44,13 @@ type VolumeDetachRequest struct {
 	Opts  map[string]interface{} `json:"opts,omitempty"`
 }
 
+// VolumeResizeRequest is the JSON body for resizing a volume.
+type VolumeResizeRequest struct {
+	Size  int64                  `json:"size"`
+	Force bool                   `json:"force,omitempty"`
+	Opts  map[string]interface{} `json:"opts,omitempty"`
+}
+
 // SnapshotCopyRequest is the JSON body for copying a snapshot.
 type SnapshotCopyRequest struct {
 	SnapshotName  string                 `json:"snapshotName"`
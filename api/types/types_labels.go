@@ -0,0 +1,82 @@
+package types
+
+// LabelSelectorOperator is a label selector operator.
+type LabelSelectorOperator int
+
+const (
+	// LabelSelectorEquals is the = and == operator.
+	LabelSelectorEquals LabelSelectorOperator = iota
+
+	// LabelSelectorNotEquals is the != operator.
+	LabelSelectorNotEquals
+
+	// LabelSelectorIn is the in operator.
+	LabelSelectorIn
+
+	// LabelSelectorNotIn is the notin operator.
+	LabelSelectorNotIn
+
+	// LabelSelectorExists is the operator used when a label key is specified
+	// without an operator or value.
+	LabelSelectorExists
+
+	// LabelSelectorDoesNotExist is the ! operator.
+	LabelSelectorDoesNotExist
+)
+
+// LabelRequirement is a single requirement of a label selector.
+type LabelRequirement struct {
+
+	// Key is the label key.
+	Key string
+
+	// Op is the operation.
+	Op LabelSelectorOperator
+
+	// Values are the label values against which the key's value is compared.
+	// The equality operators have exactly one value while the existence
+	// operators have none.
+	Values []string
+}
+
+// Matches returns a flag indicating whether or not the provided labels
+// satisfy the requirement.
+func (r *LabelRequirement) Matches(labels map[string]string) bool {
+	v, ok := labels[r.Key]
+	switch r.Op {
+	case LabelSelectorExists:
+		return ok
+	case LabelSelectorDoesNotExist:
+		return !ok
+	case LabelSelectorEquals, LabelSelectorIn:
+		return ok && r.hasValue(v)
+	case LabelSelectorNotEquals, LabelSelectorNotIn:
+		return !ok || !r.hasValue(v)
+	}
+	return false
+}
+
+func (r *LabelRequirement) hasValue(v string) bool {
+	for _, rv := range r.Values {
+		if rv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelSelector is a list of label requirements, all of which must be
+// satisfied for a set of labels to match the selector.
+type LabelSelector []*LabelRequirement
+
+// Matches returns a flag indicating whether or not the provided labels
+// satisfy all of the selector's requirements. An empty selector matches
+// everything.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
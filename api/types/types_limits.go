@@ -0,0 +1,31 @@
+package types
+
+// LimitsConfig is the configuration of the limits placed on the requests
+// sent to a storage service.
+type LimitsConfig struct {
+	// Rate is the number of requests per second a service accepts. A rate
+	// of zero does not limit the service's requests.
+	Rate float64
+
+	// Burst is the number of requests a service accepts at once before the
+	// rate is enforced.
+	Burst int
+
+	// SubjectRate is the number of requests per second a service accepts
+	// from a single subject, as identified by the subject of the request's
+	// security token. A rate of zero does not limit the subjects' requests.
+	SubjectRate float64
+
+	// SubjectBurst is the number of requests a service accepts at once from a
+	// single subject before the subject's rate is enforced.
+	SubjectBurst int
+
+	// MaxInFlight is the number of a service's tasks that may run at once.
+	// The tasks of a service that does not specify a maximum run one at a
+	// time.
+	MaxInFlight int
+
+	// Queue is a flag that indicates whether requests that exceed the rate
+	// limits wait in the task queue instead of failing.
+	Queue bool
+}
@@ -1,6 +1,9 @@
 package types
 
-import "strconv"
+import (
+	"encoding/json"
+	"strconv"
+)
 
 // StorageType is the type of storage a driver provides.
 type StorageType string
@@ -29,6 +32,24 @@ type ServiceVolumeMap map[string]VolumeMap
 // services.
 type ServiceSnapshotMap map[string]SnapshotMap
 
+// PagedResult is the result of a task that lists a page of objects. Only the
+// Result is marshaled to JSON; the token for the next page is returned to the
+// client via the Libstorage-Continue header.
+type PagedResult struct {
+
+	// Result is the page of objects.
+	Result interface{}
+
+	// Continue is the token used to request the next page. The token is empty
+	// if this is the last page.
+	Continue string
+}
+
+// MarshalJSON marshals the page of objects.
+func (p *PagedResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Result)
+}
+
 // ServicesMap is the response when getting one to many ServiceInfos.
 type ServicesMap map[string]*ServiceInfo
 
@@ -119,6 +140,10 @@ type Snapshot struct {
 	// The size of the volume to which the snapshot belongs.
 	VolumeSize int64 `json:"volumeSize,omitempty" yaml:"volumeSize,omitempty"`
 
+	// Labels are the user-defined key/value pairs used to organize and
+	// select the snapshot.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
 	// Fields are additional properties that can be defined for this type.
 	Fields map[string]string `json:"fields,omitempty" yaml:",omitempty"`
 }
@@ -205,6 +230,10 @@ type Volume struct {
 	// The volume type.
 	Type string `json:"type" yaml:"type"`
 
+	// Labels are the user-defined key/value pairs used to organize and
+	// select the volume.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
 	// Fields are additional properties that can be defined for this type.
 	Fields map[string]string `json:"fields,omitempty" yaml:"fields,omitempty"`
 }
@@ -297,6 +326,91 @@ type DriverInfo struct {
 
 	// NextDevice is the next available device information for the service.
 	NextDevice *NextDeviceInfo `json:"nextDevice,omitempty" yaml:"nextDevice,omitempty"`
+
+	// Capabilities indicates which of the optional operations and features
+	// the driver supports.
+	Capabilities DriverCapabilities `json:"capabilities,omitempty" yaml:",omitempty"`
+}
+
+// DriverCapability is an optional operation or feature of a storage driver.
+type DriverCapability string
+
+const (
+	// DriverCapabilitySnapshots is the capability to snapshot volumes and
+	// to list, inspect, and remove snapshots.
+	DriverCapabilitySnapshots DriverCapability = "snapshots"
+
+	// DriverCapabilityCopy is the capability to copy volumes.
+	DriverCapabilityCopy DriverCapability = "copy"
+
+	// DriverCapabilitySnapshotCopy is the capability to copy snapshots.
+	DriverCapabilitySnapshotCopy DriverCapability = "snapshotCopy"
+
+	// DriverCapabilityCreateFromSnapshot is the capability to create volumes
+	// from snapshots.
+	DriverCapabilityCreateFromSnapshot DriverCapability = "createFromSnapshot"
+
+	// DriverCapabilityResize is the capability to resize volumes.
+	DriverCapabilityResize DriverCapability = "resize"
+
+	// DriverCapabilityEncryption is the capability to create encrypted
+	// volumes.
+	DriverCapabilityEncryption DriverCapability = "encryption"
+
+	// DriverCapabilityMultiAttach is the capability to attach a volume to
+	// more than one instance at a time.
+	DriverCapabilityMultiAttach DriverCapability = "multiAttach"
+
+	// DriverCapabilityIOPS is the capability to provision volumes with a
+	// number of IOPS.
+	DriverCapabilityIOPS DriverCapability = "iops"
+
+	// DriverCapabilityAvailabilityZones is the capability to create volumes
+	// in an availability zone.
+	DriverCapabilityAvailabilityZones DriverCapability = "availabilityZones"
+)
+
+// AllDriverCapabilities are all of the driver capabilities.
+var AllDriverCapabilities = []DriverCapability{
+	DriverCapabilitySnapshots,
+	DriverCapabilityCopy,
+	DriverCapabilitySnapshotCopy,
+	DriverCapabilityCreateFromSnapshot,
+	DriverCapabilityResize,
+	DriverCapabilityEncryption,
+	DriverCapabilityMultiAttach,
+	DriverCapabilityIOPS,
+	DriverCapabilityAvailabilityZones,
+}
+
+// DriverCapabilities indicates whether a driver supports its capabilities.
+// Whether a driver supports a capability that is not in the map is unknown.
+type DriverCapabilities map[DriverCapability]bool
+
+// NewDriverCapabilities returns the capabilities of a driver that supports
+// the provided capabilities and none of the others.
+func NewDriverCapabilities(supported ...DriverCapability) DriverCapabilities {
+	dc := DriverCapabilities{}
+	for _, c := range AllDriverCapabilities {
+		dc[c] = false
+	}
+	for _, c := range supported {
+		dc[c] = true
+	}
+	return dc
+}
+
+// Unsupported returns the first of the provided capabilities that is known
+// to be unsupported. An empty string is returned if none of them are.
+func (dc DriverCapabilities) Unsupported(
+	caps ...DriverCapability) DriverCapability {
+
+	for _, c := range caps {
+		if supported, ok := dc[c]; ok && !supported {
+			return c
+		}
+	}
+	return ""
 }
 
 // NextDeviceInfo assists the libStorage client in determining the
@@ -341,6 +455,10 @@ const (
 
 	// TaskStateError is the state for a task that has completed with an error.
 	TaskStateError = "error"
+
+	// TaskStateCanceled is the state for a task that was canceled before it
+	// completed.
+	TaskStateCanceled = "canceled"
 )
 
 // Task is a representation of an asynchronous, long-running task.
@@ -369,4 +487,35 @@ type Task struct {
 
 	// Error contains the error if the task was unsuccessful.
 	Error error `json:"error,omitempty" yaml:",omitempty"`
+
+	// Locks are the locks the task holds or is waiting to acquire.
+	Locks []*TaskLock `json:"locks,omitempty" yaml:",omitempty"`
+}
+
+// TaskLockState is the possible state of a task's lock.
+type TaskLockState string
+
+const (
+	// TaskLockStateWaiting is the state for a lock the task is waiting to
+	// acquire.
+	TaskLockStateWaiting TaskLockState = "waiting"
+
+	// TaskLockStateHeld is the state for a lock the task holds.
+	TaskLockStateHeld = "held"
+
+	// TaskLockStateReleased is the state for a lock the task has released.
+	TaskLockStateReleased = "released"
+
+	// TaskLockStateTimedOut is the state for a lock the task could not
+	// acquire before the lock timeout elapsed.
+	TaskLockStateTimedOut = "timedOut"
+)
+
+// TaskLock is the state of a lock acquired by a task.
+type TaskLock struct {
+	// Key is the lock's key, ex. vfs/volumes/vfs-000.
+	Key string `json:"key"`
+
+	// State is the lock's state.
+	State TaskLockState `json:"state"`
 }
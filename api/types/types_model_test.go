@@ -85,3 +85,41 @@ func TestInstanceWithOnlyInstanceIDMarshalToYAML(t *testing.T) {
 
 	fmt.Println(string(out))
 }
+
+func TestDriverCapabilities(t *testing.T) {
+
+	dc := NewDriverCapabilities(
+		DriverCapabilitySnapshots, DriverCapabilityResize)
+	if len(dc) != len(AllDriverCapabilities) {
+		t.Fatalf("len(dc) != %d", len(AllDriverCapabilities))
+	}
+	if !dc[DriverCapabilitySnapshots] || !dc[DriverCapabilityResize] {
+		t.Fatal("supported capability is false")
+	}
+	if dc[DriverCapabilityCopy] {
+		t.Fatal("unsupported capability is true")
+	}
+
+	if c := dc.Unsupported(DriverCapabilitySnapshots); c != "" {
+		t.Fatalf("unexpected unsupported capability %s", c)
+	}
+	if c := dc.Unsupported(
+		DriverCapabilityResize,
+		DriverCapabilityCopy,
+		DriverCapabilityEncryption); c != DriverCapabilityCopy {
+		t.Fatalf("unsupported capability %s != copy", c)
+	}
+
+	// unknown capabilities are not unsupported
+	dc = DriverCapabilities{DriverCapabilityCopy: false}
+	if c := dc.Unsupported(DriverCapabilityResize); c != "" {
+		t.Fatalf("unexpected unsupported capability %s", c)
+	}
+	if c := dc.Unsupported(DriverCapabilityCopy); c != DriverCapabilityCopy {
+		t.Fatalf("unsupported capability %s != copy", c)
+	}
+	if c := DriverCapabilities(nil).Unsupported(
+		DriverCapabilityCopy); c != "" {
+		t.Fatalf("unexpected unsupported capability %s", c)
+	}
+}
@@ -0,0 +1,105 @@
+package types
+
+// VolumePageFunc fetches the page of volumes that begins at the provided
+// continuation token and returns the token for the next page.
+type VolumePageFunc func(token string) (ServiceVolumeMap, string, error)
+
+// VolumePageIterator iterates over the pages of a volume listing.
+type VolumePageIterator struct {
+	fetch VolumePageFunc
+	page  ServiceVolumeMap
+	token string
+	done  bool
+	err   error
+}
+
+// NewVolumePageIterator returns a new VolumePageIterator that fetches its
+// pages with the provided function, beginning with the page for the provided
+// continuation token.
+func NewVolumePageIterator(
+	fetch VolumePageFunc, token string) *VolumePageIterator {
+
+	return &VolumePageIterator{fetch: fetch, token: token}
+}
+
+// Next fetches the next page. The function returns false when there are no
+// more pages or an error occurred.
+func (i *VolumePageIterator) Next() bool {
+	if i.done || i.err != nil {
+		return false
+	}
+	if i.page, i.token, i.err = i.fetch(i.token); i.err != nil {
+		i.page = nil
+		return false
+	}
+	i.done = i.token == ""
+	return true
+}
+
+// Page returns the page fetched by the last call to Next.
+func (i *VolumePageIterator) Page() ServiceVolumeMap {
+	return i.page
+}
+
+// Continue returns the token for the page that the next call to Next
+// fetches. The token is empty if there are no more pages.
+func (i *VolumePageIterator) Continue() string {
+	return i.token
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (i *VolumePageIterator) Err() error {
+	return i.err
+}
+
+// SnapshotPageFunc fetches the page of snapshots that begins at the provided
+// continuation token and returns the token for the next page.
+type SnapshotPageFunc func(token string) (ServiceSnapshotMap, string, error)
+
+// SnapshotPageIterator iterates over the pages of a snapshot listing.
+type SnapshotPageIterator struct {
+	fetch SnapshotPageFunc
+	page  ServiceSnapshotMap
+	token string
+	done  bool
+	err   error
+}
+
+// NewSnapshotPageIterator returns a new SnapshotPageIterator that fetches its
+// pages with the provided function, beginning with the page for the provided
+// continuation token.
+func NewSnapshotPageIterator(
+	fetch SnapshotPageFunc, token string) *SnapshotPageIterator {
+
+	return &SnapshotPageIterator{fetch: fetch, token: token}
+}
+
+// Next fetches the next page. The function returns false when there are no
+// more pages or an error occurred.
+func (i *SnapshotPageIterator) Next() bool {
+	if i.done || i.err != nil {
+		return false
+	}
+	if i.page, i.token, i.err = i.fetch(i.token); i.err != nil {
+		i.page = nil
+		return false
+	}
+	i.done = i.token == ""
+	return true
+}
+
+// Page returns the page fetched by the last call to Next.
+func (i *SnapshotPageIterator) Page() ServiceSnapshotMap {
+	return i.page
+}
+
+// Continue returns the token for the page that the next call to Next
+// fetches. The token is empty if there are no more pages.
+func (i *SnapshotPageIterator) Continue() string {
+	return i.token
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (i *SnapshotPageIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagedResultMarshalJSON(t *testing.T) {
+	pr := &PagedResult{
+		Result:   VolumeMap{"vol-000": &Volume{ID: "vol-000"}},
+		Continue: "token",
+	}
+	buf, err := json.Marshal(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := json.Marshal(pr.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), string(buf))
+}
+
+func TestVolumePageIterator(t *testing.T) {
+	pages := map[string]ServiceVolumeMap{
+		"":  {"vfs": VolumeMap{"vol-000": &Volume{ID: "vol-000"}}},
+		"1": {"vfs": VolumeMap{"vol-001": &Volume{ID: "vol-001"}}},
+	}
+	next := map[string]string{"": "1", "1": ""}
+
+	i := NewVolumePageIterator(
+		func(token string) (ServiceVolumeMap, string, error) {
+			return pages[token], next[token], nil
+		}, "")
+
+	var ids []string
+	for i.Next() {
+		for _, v := range i.Page()["vfs"] {
+			ids = append(ids, v.ID)
+		}
+	}
+	assert.NoError(t, i.Err())
+	assert.Equal(t, []string{"vol-000", "vol-001"}, ids)
+	assert.Equal(t, "", i.Continue())
+	assert.False(t, i.Next())
+}
+
+func TestSnapshotPageIteratorError(t *testing.T) {
+	i := NewSnapshotPageIterator(
+		func(token string) (ServiceSnapshotMap, string, error) {
+			return nil, "", errors.New("error")
+		}, "")
+	assert.False(t, i.Next())
+	assert.Error(t, i.Err())
+	assert.Nil(t, i.Page())
+}
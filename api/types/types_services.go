@@ -74,6 +74,56 @@ type TaskTrackingService interface {
 	// TaskWaitAll returns a channel that is closed when the specified task
 	// completes.
 	TaskWaitAllC(taskIDs ...int) <-chan int
+
+	// TaskCancel cancels the specified task.
+	TaskCancel(taskID int) *Task
+
+	// TaskWatch returns a channel on which a task is received every time its
+	// state changes. The channel is closed when the context is done.
+	TaskWatch(ctx Context) <-chan *Task
+}
+
+// NewTaskStore is a function that constructs a new TaskStore.
+type NewTaskStore func() TaskStore
+
+// TaskStore is a store used by the task service to persist tasks.
+type TaskStore interface {
+	Driver
+
+	// NextID returns the next available task ID.
+	NextID(ctx Context) int
+
+	// Save inserts or updates a task.
+	Save(ctx Context, task *Task) error
+
+	// Get returns the task with the specified ID or nil if no such task
+	// exists.
+	Get(ctx Context, taskID int) (*Task, error)
+
+	// List returns all of the tasks in the store.
+	List(ctx Context) ([]*Task, error)
+
+	// Remove removes the task with the specified ID.
+	Remove(ctx Context, taskID int) error
+}
+
+// NewLockManager is a function that constructs a new LockManager.
+type NewLockManager func() LockManager
+
+// LockManager is used by the server to serialize the tasks that operate on
+// the same resource, such as a volume. A lock manager that is shared by
+// multiple servers serializes the tasks across the servers.
+type LockManager interface {
+	Driver
+
+	// Lock blocks until the lock with the specified key is acquired on
+	// behalf of the holder or until the context is done, in which case the
+	// context's error is returned.
+	Lock(ctx Context, key, holder string) error
+
+	// Unlock releases the lock with the specified key if it is held by the
+	// holder.
+	Unlock(ctx Context, key, holder string) error
 }
 
 // TaskExecutionService is a service for executing tasks.
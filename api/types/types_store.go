@@ -73,6 +73,10 @@ type Store interface {
 	// exist.
 	GetMap(k string) map[string]interface{}
 
+	// GetStringMap returns a string map value for a key; a nil value if the
+	// key does not exist.
+	GetStringMap(k string) map[string]string
+
 	// GetStore returns a Store value for a key; a nil value if the key does
 	// not exist.
 	GetStore(k string) Store
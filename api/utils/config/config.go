@@ -2,6 +2,7 @@ package config
 
 import (
 	"path"
+	"strings"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -63,12 +64,32 @@ func readConfigFile(config gofig.Config, path string) error {
 	return config.ReadConfigFile(path)
 }
 
+// Hosts gets the configured hosts. The hosts may be configured as a list or
+// as a comma-separated string.
+func Hosts(config gofig.Config) []string {
+	var hosts []string
+	for _, v := range config.GetStringSlice(types.ConfigHost) {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	return hosts
+}
+
 // DeviceAttachTimeout gets the configured device attach timeout.
 func DeviceAttachTimeout(config gofig.Config) time.Duration {
 	return utils.DeviceAttachTimeout(
 		config.GetString(types.ConfigDeviceAttachTimeout))
 }
 
+// DeviceDetachTimeout gets the configured device detach timeout.
+func DeviceDetachTimeout(config gofig.Config) time.Duration {
+	return utils.DeviceDetachTimeout(
+		config.GetString(types.ConfigDeviceDetachTimeout))
+}
+
 // DeviceScanType gets the configured device scan type.
 func DeviceScanType(config gofig.Config) types.DeviceScanType {
 	return types.ParseDeviceScanType(config.GetInt(types.ConfigDeviceScanType))
@@ -0,0 +1,105 @@
+/*
+Package devwatch reports the block devices that are added to and removed from
+a host.
+
+On Linux the events are read from the kernel's uevent netlink socket and from
+inotify watches on /dev and /dev/disk/by-id, the latter of which receives the
+links that udev creates after the kernel reports a device. Hosts that do not
+support watching devices return types.ErrNotImplemented from New, and callers
+are expected to fall back to polling.
+*/
+package devwatch
+
+import (
+	"time"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+const (
+	// ActionAdd is the action of an event for a device that was added.
+	ActionAdd = "add"
+
+	// ActionRemove is the action of an event for a device that was removed.
+	ActionRemove = "remove"
+
+	// ActionChange is the action of an event for a device that changed.
+	ActionChange = "change"
+)
+
+// Event is a device event.
+type Event struct {
+
+	// Action is the event's action, ex. add, remove, or change.
+	Action string
+
+	// Path is the path of the device or device link, ex. /dev/xvdf or
+	// /dev/disk/by-id/google-data.
+	Path string
+}
+
+// Source is a source of device events.
+type Source interface {
+
+	// Events returns the channel on which events are received. The channel
+	// is not closed when the source is closed.
+	Events() <-chan *Event
+
+	// Close stops the source.
+	Close() error
+}
+
+// Wait calls the check function until it returns true or an error, or until
+// the timeout elapses or the context is done. The check function is called
+// once immediately, again whenever the source emits an event, and every poll
+// interval in case an event was missed. The source may be nil, in which case
+// the check function is only called every poll interval.
+//
+// A types.ErrTimedOut error is returned if the timeout elapses.
+func Wait(
+	ctx types.Context,
+	src Source,
+	timeout, poll time.Duration,
+	check func() (bool, error)) error {
+
+	var events <-chan *Event
+	if src != nil {
+		events = src.Events()
+	}
+
+	timeoutC := time.After(timeout)
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		if ok, err := check(); err != nil {
+			return err
+		} else if ok {
+			return nil
+		}
+
+		select {
+		case <-timeoutC:
+			return types.ErrTimedOut
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case ev := <-events:
+			ctx.WithField("path", ev.Path).Debug(ev.Action)
+			// a device is usually reported along with its partitions and
+			// links, so the pending events are drained to avoid checking once
+			// per event
+			drain(events)
+		}
+	}
+}
+
+func drain(events <-chan *Event) {
+	for {
+		select {
+		case <-events:
+		default:
+			return
+		}
+	}
+}
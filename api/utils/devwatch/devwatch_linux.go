@@ -0,0 +1,262 @@
+// +build linux
+
+package devwatch
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/akutz/goof"
+)
+
+const (
+	// eventBufferSize is the number of events that may be buffered before
+	// the watcher waits for them to be received.
+	eventBufferSize = 64
+
+	// ueventGroup is the netlink multicast group of the kernel's uevents.
+	ueventGroup = 1
+
+	// ueventReadTimeout is how long a read from the uevent socket blocks
+	// before the watcher checks whether it has been closed.
+	ueventReadTimeout = 250 * time.Millisecond
+
+	inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE |
+		syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM
+)
+
+// Dirs are the directories watched for devices and device links.
+var Dirs = []string{"/dev", "/dev/disk/by-id"}
+
+type watcher struct {
+	events    chan *Event
+	done      chan struct{}
+	closeOnce sync.Once
+	closers   []func()
+}
+
+// New returns a Source that reports the block devices added to and removed
+// from this host. An error is returned only if neither the uevent socket nor
+// any of the watched directories could be watched.
+func New() (Source, error) {
+	w := &watcher{
+		events: make(chan *Event, eventBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	uerr := w.watchUevents()
+	ierr := w.watchDirs(Dirs...)
+	if uerr != nil && ierr != nil {
+		return nil, goof.WithFieldsE(goof.Fields{
+			"inotifyErr": ierr.Error(),
+		}, "error watching devices", uerr)
+	}
+
+	return w, nil
+}
+
+func (w *watcher) Events() <-chan *Event {
+	return w.events
+}
+
+func (w *watcher) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		for _, f := range w.closers {
+			f()
+		}
+	})
+	return nil
+}
+
+func (w *watcher) send(ev *Event) bool {
+	select {
+	case w.events <- ev:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+func (w *watcher) closed() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *watcher) watchUevents() error {
+	fd, err := syscall.Socket(
+		syscall.AF_NETLINK,
+		syscall.SOCK_RAW|syscall.SOCK_CLOEXEC,
+		syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return err
+	}
+
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{
+		Family: syscall.AF_NETLINK,
+		Groups: ueventGroup,
+	}); err != nil {
+		syscall.Close(fd)
+		return err
+	}
+
+	tv := syscall.NsecToTimeval(int64(ueventReadTimeout))
+	if err := syscall.SetsockoptTimeval(
+		fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		syscall.Close(fd)
+		return err
+	}
+
+	go func() {
+		defer syscall.Close(fd)
+		buf := make([]byte, syscall.Getpagesize())
+		for !w.closed() {
+			n, _, err := syscall.Recvfrom(fd, buf, 0)
+			if err != nil {
+				if err == syscall.EAGAIN || err == syscall.EINTR {
+					continue
+				}
+				return
+			}
+			if ev := parseUevent(buf[:n]); ev != nil && !w.send(ev) {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// parseUevent parses a kernel uevent message, which is a header of the form
+// ACTION@DEVPATH followed by NUL-terminated KEY=VALUE pairs. Nil is returned
+// for messages that are not about block devices.
+func parseUevent(buf []byte) *Event {
+	fields := bytes.Split(buf, []byte{0})
+	if len(fields) < 2 || !bytes.Contains(fields[0], []byte("@")) {
+		return nil
+	}
+
+	env := map[string]string{}
+	for _, f := range fields[1:] {
+		if kv := strings.SplitN(string(f), "=", 2); len(kv) == 2 {
+			env[kv[0]] = kv[1]
+		}
+	}
+
+	if env["SUBSYSTEM"] != "block" || env["DEVNAME"] == "" {
+		return nil
+	}
+
+	action := env["ACTION"]
+	if action == "" {
+		action = string(bytes.SplitN(fields[0], []byte("@"), 2)[0])
+	}
+
+	devName := env["DEVNAME"]
+	if !path.IsAbs(devName) {
+		devName = path.Join("/dev", devName)
+	}
+
+	return &Event{Action: action, Path: devName}
+}
+
+func (w *watcher) watchDirs(dirs ...string) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		return err
+	}
+
+	var (
+		lastErr error
+		wds     = map[int32]string{}
+	)
+	for _, dir := range dirs {
+		wd, err := syscall.InotifyAddWatch(fd, dir, inotifyMask)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		wds[int32(wd)] = dir
+	}
+	if len(wds) == 0 {
+		syscall.Close(fd)
+		return lastErr
+	}
+
+	// removing the watches causes the kernel to emit IN_IGNORED events,
+	// which unblocks the read so the watcher can observe it was closed
+	w.closers = append(w.closers, func() {
+		for wd := range wds {
+			syscall.InotifyRmWatch(fd, uint32(wd))
+		}
+	})
+
+	go func() {
+		defer syscall.Close(fd)
+		buf := make([]byte, syscall.Getpagesize())
+		for !w.closed() {
+			n, err := syscall.Read(fd, buf)
+			if err != nil {
+				if err == syscall.EINTR {
+					continue
+				}
+				return
+			}
+			for _, ev := range parseInotify(buf[:n], wds) {
+				if !w.send(ev) {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// parseInotify parses the inotify events in the buffer. The watch
+// descriptors are mapped to the watched directories in order to construct
+// the paths of the events.
+func parseInotify(buf []byte, wds map[int32]string) []*Event {
+	var events []*Event
+	for off := 0; off+syscall.SizeofInotifyEvent <= len(buf); {
+		raw := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+		off += syscall.SizeofInotifyEvent
+
+		end := off + int(raw.Len)
+		if end > len(buf) {
+			break
+		}
+		name := string(bytes.TrimRight(buf[off:end], "\x00"))
+		off = end
+
+		dir, ok := wds[raw.Wd]
+		if !ok || name == "" {
+			continue
+		}
+
+		var action string
+		switch {
+		case raw.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0:
+			action = ActionAdd
+		case raw.Mask&(syscall.IN_DELETE|syscall.IN_MOVED_FROM) != 0:
+			action = ActionRemove
+		default:
+			continue
+		}
+
+		events = append(events, &Event{
+			Action: action,
+			Path:   path.Join(dir, name),
+		})
+	}
+	return events
+}
@@ -0,0 +1,79 @@
+// +build linux
+
+package devwatch
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUevent(t *testing.T) {
+	ev := parseUevent([]byte(
+		"add@/devices/vbd-51792/block/xvdf\x00" +
+			"ACTION=add\x00" +
+			"DEVPATH=/devices/vbd-51792/block/xvdf\x00" +
+			"SUBSYSTEM=block\x00" +
+			"DEVNAME=xvdf\x00" +
+			"DEVTYPE=disk\x00"))
+	if assert.NotNil(t, ev) {
+		assert.Equal(t, &Event{Action: ActionAdd, Path: "/dev/xvdf"}, ev)
+	}
+
+	ev = parseUevent([]byte(
+		"remove@/devices/virtual/block/loop0\x00" +
+			"SUBSYSTEM=block\x00" +
+			"DEVNAME=/dev/loop0\x00"))
+	if assert.NotNil(t, ev) {
+		assert.Equal(t, &Event{Action: ActionRemove, Path: "/dev/loop0"}, ev)
+	}
+
+	assert.Nil(t, parseUevent([]byte(
+		"add@/devices/virtual/net/veth0\x00"+
+			"ACTION=add\x00"+
+			"SUBSYSTEM=net\x00")))
+	assert.Nil(t, parseUevent([]byte("libudev\x00")))
+}
+
+func TestWatchDirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devwatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	w := &watcher{
+		events: make(chan *Event, eventBufferSize),
+		done:   make(chan struct{}),
+	}
+	if err := w.watchDirs(dir, path.Join(dir, "missing")); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	dev := path.Join(dir, "google-data")
+	if err := ioutil.WriteFile(dev, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertEvent(t, w, &Event{Action: ActionAdd, Path: dev})
+
+	if err := os.Remove(dev); err != nil {
+		t.Fatal(err)
+	}
+	assertEvent(t, w, &Event{Action: ActionRemove, Path: dev})
+
+	assert.Error(t, (&watcher{}).watchDirs(path.Join(dir, "missing")))
+}
+
+func assertEvent(t *testing.T, w *watcher, expected *Event) {
+	select {
+	case ev := <-w.Events():
+		assert.Equal(t, expected, ev)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %v", expected)
+	}
+}
@@ -0,0 +1,11 @@
+// +build !linux
+
+package devwatch
+
+import "github.com/codedellemc/libstorage/api/types"
+
+// New returns types.ErrNotImplemented as watching devices is only supported
+// on Linux.
+func New() (Source, error) {
+	return nil, types.ErrNotImplemented
+}
@@ -0,0 +1,89 @@
+package devwatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// fakeSource is a Source whose events are sent by the test.
+type fakeSource struct {
+	events chan *Event
+	closed bool
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{events: make(chan *Event, eventBufferSize)}
+}
+
+func (s *fakeSource) Events() <-chan *Event {
+	return s.events
+}
+
+func (s *fakeSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestWaitEvent(t *testing.T) {
+	src := newFakeSource()
+	defer src.Close()
+
+	var (
+		checks int
+		added  = make(chan bool, 1)
+	)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		added <- true
+		src.events <- &Event{Action: ActionAdd, Path: "/dev/xvdf"}
+		src.events <- &Event{Action: ActionAdd, Path: "/dev/xvdf1"}
+	}()
+
+	start := time.Now()
+	err := Wait(context.Background(), src, time.Minute, time.Minute,
+		func() (bool, error) {
+			checks++
+			select {
+			case <-added:
+				return true, nil
+			default:
+				return false, nil
+			}
+		})
+
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) < 10*time.Second)
+	assert.Equal(t, 2, checks)
+}
+
+func TestWaitPoll(t *testing.T) {
+	checks := 0
+	err := Wait(context.Background(), nil, time.Minute, 10*time.Millisecond,
+		func() (bool, error) {
+			checks++
+			return checks == 3, nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, checks)
+}
+
+func TestWaitTimeout(t *testing.T) {
+	src := newFakeSource()
+	defer src.Close()
+
+	err := Wait(context.Background(), src, 50*time.Millisecond, time.Minute,
+		func() (bool, error) { return false, nil })
+	assert.Equal(t, types.ErrTimedOut, err)
+}
+
+func TestWaitError(t *testing.T) {
+	err := Wait(context.Background(), nil, time.Minute, time.Minute,
+		func() (bool, error) { return false, types.ErrNotImplemented })
+	assert.Equal(t, types.ErrNotImplemented, err)
+}
@@ -10,6 +10,8 @@ package filters
 import (
 	"bytes"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/akutz/goof"
 
@@ -28,6 +30,7 @@ const (
 	filterGreaterOrEqual    = types.FilterGreaterOrEqual
 	filterLessOrEqual       = types.FilterLessOrEqual
 	filterApproxMatch       = types.FilterApproxMatch
+	filterRegexMatch        = types.FilterRegexMatch
 )
 
 var filterMap = map[types.FilterOperator]string{
@@ -40,6 +43,7 @@ var filterMap = map[types.FilterOperator]string{
 	filterLessOrEqual:    "Less Or Equal",
 	filterPresent:        "Present",
 	filterApproxMatch:    "Approx Match",
+	filterRegexMatch:     "Regex Match",
 }
 
 var (
@@ -52,7 +56,9 @@ var (
 // CompileFilter compiles a filter string.
 func CompileFilter(s string) (*types.Filter, error) {
 
-	es, err := url.QueryUnescape(s)
+	// escape plus signs so they are not unescaped as spaces, since a plus
+	// sign is a valid character in a regular expression
+	es, err := url.QueryUnescape(strings.Replace(s, "+", "%2B", -1))
 	if err != nil {
 		return nil, err
 	}
@@ -125,27 +131,61 @@ func compileFilter(s string, pos int) (*types.Filter, int, error) {
 			f          *types.Filter
 			abuf, cbuf bytes.Buffer
 			newPos     = pos
+			depth      int
 		)
 
-		for newPos < len(s) && s[newPos] != ')' {
+		for newPos < len(s) {
+
+			// a regular expression may contain balanced or escaped
+			// parentheses, so only an unbalanced, closing parenthesis
+			// terminates its value
+			if f != nil && f.Op == filterRegexMatch {
+				if s[newPos] == '\\' && newPos+1 < len(s) {
+					if _, err := cbuf.WriteString(
+						s[newPos : newPos+2]); err != nil {
+						return nil, 0, err
+					}
+					newPos = newPos + 2
+					continue
+				}
+				if s[newPos] == '(' {
+					depth++
+				} else if s[newPos] == ')' {
+					if depth == 0 {
+						break
+					}
+					depth--
+				}
+			} else if s[newPos] == ')' {
+				break
+			}
+
 			switch {
 			case f != nil:
 				if err := cbuf.WriteByte(s[newPos]); err != nil {
 					return nil, 0, err
 				}
 
+			case s[newPos] == '=' && newPos+1 < len(s) &&
+				s[newPos+1] == '~':
+				f = &types.Filter{Op: filterRegexMatch}
+				newPos++
+
 			case s[newPos] == '=':
 				f = &types.Filter{Op: filterEqualityMatch}
 
-			case s[newPos] == '>' && s[newPos+1] == '=':
+			case s[newPos] == '>' && newPos+1 < len(s) &&
+				s[newPos+1] == '=':
 				f = &types.Filter{Op: filterGreaterOrEqual}
 				newPos++
 
-			case s[newPos] == '<' && s[newPos+1] == '=':
+			case s[newPos] == '<' && newPos+1 < len(s) &&
+				s[newPos+1] == '=':
 				f = &types.Filter{Op: filterLessOrEqual}
 				newPos++
 
-			case s[newPos] == '~' && s[newPos+1] == '=':
+			case s[newPos] == '~' && newPos+1 < len(s) &&
+				s[newPos+1] == '=':
 				f = &types.Filter{Op: filterApproxMatch}
 				newPos++
 
@@ -171,10 +211,22 @@ func compileFilter(s string, pos int) (*types.Filter, int, error) {
 			cbyt = cbuf.Bytes()
 			cstr = cbuf.String()
 			clen = len(cbyt)
-			cfch = cbyt[clen-1]
 		)
 
+		if clen == 0 {
+			return nil, 0, errParse
+		}
+
+		cfch := cbyt[clen-1]
+
 		switch {
+		case f.Op == filterRegexMatch:
+			if _, err := regexp.Compile(cstr); err != nil {
+				return nil, 0, goof.WithFieldE(
+					"regex", cstr, "error compiling filter regex", err)
+			}
+			f.Right = cstr
+
 		case f.Op == filterEqualityMatch && cstr == "*":
 			f.Op = filterPresent
 
@@ -0,0 +1,247 @@
+package filters
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// valuesFunc returns the values of an object's property. A property may have
+// multiple values, such as the property of a volume's attachments. The
+// returned flag indicates whether or not the property is numeric.
+type valuesFunc func(key string) (values []string, numeric bool)
+
+// MatchVolume returns a flag indicating whether or not a volume matches a
+// filter. The filter's keys may be any of the volume's properties, such as
+// name or size, as well as the keys "fields.*", "labels.*" and
+// "attachments.*". A volume matches a filter for its attachments if any of
+// its attachments matches.
+func MatchVolume(f *types.Filter, v *types.Volume) bool {
+	return match(f, func(key string) ([]string, bool) {
+		return volumeValues(v, key)
+	})
+}
+
+// MatchSnapshot returns a flag indicating whether or not a snapshot matches a
+// filter. The filter's keys may be any of the snapshot's properties, such as
+// name or volumeSize, as well as the keys "fields.*" and "labels.*".
+func MatchSnapshot(f *types.Filter, s *types.Snapshot) bool {
+	return match(f, func(key string) ([]string, bool) {
+		return snapshotValues(s, key)
+	})
+}
+
+func match(f *types.Filter, values valuesFunc) bool {
+	if f == nil {
+		return true
+	}
+
+	switch f.Op {
+	case filterAnd:
+		for _, c := range f.Children {
+			if !match(c, values) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, c := range f.Children {
+			if match(c, values) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		if len(f.Children) == 0 {
+			return false
+		}
+		return !match(f.Children[0], values)
+	}
+
+	vals, numeric := values(strings.ToLower(f.Left))
+	for _, v := range vals {
+		if matchValue(f, v, numeric) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchValue(f *types.Filter, v string, numeric bool) bool {
+	lv := strings.ToLower(v)
+	lr := strings.ToLower(f.Right)
+
+	switch f.Op {
+	case filterPresent:
+		return v != ""
+	case filterEqualityMatch:
+		if c, ok := compareNumbers(v, f.Right); ok {
+			return c == 0
+		}
+		return lv == lr
+	case filterApproxMatch:
+		return strings.TrimSpace(lv) == strings.TrimSpace(lr)
+	case filterSubstrings:
+		return strings.Contains(lv, lr)
+	case filterSubstringsPrefix:
+		return strings.HasSuffix(lv, lr)
+	case filterSubstringsPostfix:
+		return strings.HasPrefix(lv, lr)
+	case filterGreaterOrEqual:
+		if c, ok := compareNumbers(v, f.Right); ok {
+			return c >= 0
+		}
+		return !numeric && lv >= lr
+	case filterLessOrEqual:
+		if c, ok := compareNumbers(v, f.Right); ok {
+			return c <= 0
+		}
+		return !numeric && lv <= lr
+	case filterRegexMatch:
+		rx, err := regexp.Compile(f.Right)
+		if err != nil {
+			return false
+		}
+		return rx.MatchString(v)
+	}
+
+	return false
+}
+
+// compareNumbers compares two values numerically. The returned flag is false
+// if either value is not a number. Values of numeric properties are never
+// compared as strings, while values of other properties are compared as
+// strings if they cannot be compared as numbers.
+func compareNumbers(l, r string) (int, bool) {
+	lf, lerr := strconv.ParseFloat(strings.TrimSpace(l), 64)
+	rf, rerr := strconv.ParseFloat(strings.TrimSpace(r), 64)
+	if lerr != nil || rerr != nil {
+		return 0, false
+	}
+	switch {
+	case lf < rf:
+		return -1, true
+	case lf > rf:
+		return 1, true
+	}
+	return 0, true
+}
+
+func volumeValues(v *types.Volume, key string) ([]string, bool) {
+	switch key {
+	case "id":
+		return []string{v.ID}, false
+	case "name":
+		return []string{v.Name}, false
+	case "type":
+		return []string{v.Type}, false
+	case "status":
+		return []string{v.Status}, false
+	case "availabilityzone":
+		return []string{v.AvailabilityZone}, false
+	case "networkname":
+		return []string{v.NetworkName}, false
+	case "encrypted":
+		return []string{strconv.FormatBool(v.Encrypted)}, false
+	case "attachmentstate":
+		return []string{v.AttachmentState.String()}, false
+	case "size":
+		return []string{strconv.FormatInt(v.Size, 10)}, true
+	case "iops":
+		return []string{strconv.FormatInt(v.IOPS, 10)}, true
+	}
+
+	if k, ok := trimKeyPrefix(key, "fields."); ok {
+		return mapValue(v.Fields, k), false
+	}
+	if k, ok := trimKeyPrefix(key, "labels."); ok {
+		return mapValue(v.Labels, k), false
+	}
+	if k, ok := trimKeyPrefix(key, "attachments."); ok {
+		var vals []string
+		for _, a := range v.Attachments {
+			vals = append(vals, attachmentValues(a, k)...)
+		}
+		return vals, false
+	}
+
+	return nil, false
+}
+
+func attachmentValues(a *types.VolumeAttachment, key string) []string {
+	switch key {
+	case "devicename":
+		return []string{a.DeviceName}
+	case "mountpoint":
+		return []string{a.MountPoint}
+	case "status":
+		return []string{a.Status}
+	case "volumeid":
+		return []string{a.VolumeID}
+	case "instanceid":
+		if a.InstanceID == nil {
+			return nil
+		}
+		return []string{a.InstanceID.ID}
+	}
+
+	if k, ok := trimKeyPrefix(key, "fields."); ok {
+		return mapValue(a.Fields, k)
+	}
+
+	return nil
+}
+
+func snapshotValues(s *types.Snapshot, key string) ([]string, bool) {
+	switch key {
+	case "id":
+		return []string{s.ID}, false
+	case "name":
+		return []string{s.Name}, false
+	case "description":
+		return []string{s.Description}, false
+	case "status":
+		return []string{s.Status}, false
+	case "volumeid":
+		return []string{s.VolumeID}, false
+	case "encrypted":
+		return []string{strconv.FormatBool(s.Encrypted)}, false
+	case "volumesize", "size":
+		return []string{strconv.FormatInt(s.VolumeSize, 10)}, true
+	case "starttime":
+		return []string{strconv.FormatInt(s.StartTime, 10)}, true
+	}
+
+	if k, ok := trimKeyPrefix(key, "fields."); ok {
+		return mapValue(s.Fields, k), false
+	}
+	if k, ok := trimKeyPrefix(key, "labels."); ok {
+		return mapValue(s.Labels, k), false
+	}
+
+	return nil, false
+}
+
+func trimKeyPrefix(key, prefix string) (string, bool) {
+	if !strings.HasPrefix(key, prefix) || len(key) == len(prefix) {
+		return "", false
+	}
+	return key[len(prefix):], true
+}
+
+// mapValue returns the value of a map's key. Since a filter's keys are
+// case-insensitive, the key is compared to the map's keys without regard to
+// case.
+func mapValue(m map[string]string, key string) []string {
+	if v, ok := m[key]; ok {
+		return []string{v}
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return []string{v}
+		}
+	}
+	return nil
+}
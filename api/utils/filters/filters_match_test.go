@@ -0,0 +1,105 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func assertMatchVolume(
+	t *testing.T, v *types.Volume, filter string, expected bool) {
+
+	f, err := CompileFilter(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, MatchVolume(f, v), filter)
+}
+
+func assertMatchSnapshot(
+	t *testing.T, s *types.Snapshot, filter string, expected bool) {
+
+	f, err := CompileFilter(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, MatchSnapshot(f, s), filter)
+}
+
+func TestMatchVolume(t *testing.T) {
+	v := &types.Volume{
+		ID:   "vol-000",
+		Name: "db-001",
+		Size: 100,
+		IOPS: 3000,
+		Type: "gp2",
+		Attachments: []*types.VolumeAttachment{
+			&types.VolumeAttachment{
+				InstanceID: &types.InstanceID{ID: "iid-000"},
+				VolumeID:   "vol-000",
+				DeviceName: "/dev/xvdb",
+				Fields:     map[string]string{"mode": "rw"},
+			},
+		},
+		Labels: map[string]string{"team": "storage"},
+		Fields: map[string]string{"Owner": "root", "priority": "2"},
+	}
+
+	assertMatchVolume(t, v, `(name=db-001)`, true)
+	assertMatchVolume(t, v, `(name=DB-001)`, true)
+	assertMatchVolume(t, v, `(name=db-002)`, false)
+	assertMatchVolume(t, v, `(name=db*)`, true)
+	assertMatchVolume(t, v, `(name=*001)`, true)
+	assertMatchVolume(t, v, `(name=*b-0*)`, true)
+	assertMatchVolume(t, v, `(name=*)`, true)
+	assertMatchVolume(t, v, `(networkName=*)`, false)
+	assertMatchVolume(t, v, `(name~= db-001 )`, true)
+	assertMatchVolume(t, v, `(name=~^db-\d+$)`, true)
+	assertMatchVolume(t, v, `(name=~^web-\d+$)`, false)
+	assertMatchVolume(t, v, `(name=~^(db|web)-001$)`, true)
+
+	// size is compared numerically and not lexically
+	assertMatchVolume(t, v, `(size>=100)`, true)
+	assertMatchVolume(t, v, `(size>=20)`, true)
+	assertMatchVolume(t, v, `(size>=200)`, false)
+	assertMatchVolume(t, v, `(size<=20)`, false)
+	assertMatchVolume(t, v, `(size=100)`, true)
+	assertMatchVolume(t, v, `(size>=abc)`, false)
+	assertMatchVolume(t, v, `(iops<=3000)`, true)
+
+	assertMatchVolume(t, v, `(fields.owner=root)`, true)
+	assertMatchVolume(t, v, `(fields.priority>=10)`, false)
+	assertMatchVolume(t, v, `(fields.missing=*)`, false)
+	assertMatchVolume(t, v, `(labels.team=storage)`, true)
+	assertMatchVolume(t, v, `(attachments.instanceID=iid-000)`, true)
+	assertMatchVolume(t, v, `(attachments.deviceName=/dev/xvdc)`, false)
+	assertMatchVolume(t, v, `(attachments.fields.mode=rw)`, true)
+
+	assertMatchVolume(t, v, `(&(name=db*)(size>=50))`, true)
+	assertMatchVolume(t, v, `(&(name=db*)(size>=500))`, false)
+	assertMatchVolume(t, v, `(|(name=web*)(size>=50))`, true)
+	assertMatchVolume(t, v, `(!(type=gp2))`, false)
+	assertMatchVolume(t, v, `(unknown=value)`, false)
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	s := &types.Snapshot{
+		ID:         "snap-000",
+		Name:       "nightly",
+		VolumeID:   "vol-000",
+		VolumeSize: 100,
+		StartTime:  1455826676,
+		Labels:     map[string]string{"team": "storage"},
+		Fields:     map[string]string{"region": "US"},
+	}
+
+	assertMatchSnapshot(t, s, `(name=nightly)`, true)
+	assertMatchSnapshot(t, s, `(volumeID=vol-001)`, false)
+	assertMatchSnapshot(t, s, `(startTime>=1455826600)`, true)
+	assertMatchSnapshot(t, s, `(startTime<=999999999)`, false)
+	assertMatchSnapshot(t, s, `(volumeSize>=20)`, true)
+	assertMatchSnapshot(t, s, `(fields.region=us)`, true)
+	assertMatchSnapshot(t, s, `(labels.team=~^stor)`, true)
+}
@@ -145,3 +145,43 @@ func TestCompileInequalityMatch(t *testing.T) {
 	assert.EqualValues(t, "department", f.Children[1].Left)
 	assert.EqualValues(t, "finance", f.Children[1].Right)
 }
+
+func TestCompileRegexMatch(t *testing.T) {
+	f, err := CompileFilter(`(name=~^(db|web)-\d+$)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, filterRegexMatch, f.Op)
+	assert.EqualValues(t, "name", f.Left)
+	assert.EqualValues(t, `^(db|web)-\d+$`, f.Right)
+
+	f, err = CompileFilter(`(&(name=~^db\)$)(size>=10))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, filterAnd, f.Op)
+	assert.EqualValues(t, filterRegexMatch, f.Children[0].Op)
+	assert.EqualValues(t, `^db\)$`, f.Children[0].Right)
+	assert.EqualValues(t, filterGreaterOrEqual, f.Children[1].Op)
+	assert.EqualValues(t, "size", f.Children[1].Left)
+	assert.EqualValues(t, "10", f.Children[1].Right)
+}
+
+func TestCompileInvalidRegexMatch(t *testing.T) {
+	_, err := CompileFilter(`(name=~^db[$)`)
+	assert.Error(t, err)
+}
+
+func TestCompileMissingValue(t *testing.T) {
+	_, err := CompileFilter(`(name=)`)
+	assert.Error(t, err)
+}
+
+func TestCompileTruncatedOperator(t *testing.T) {
+	for _, s := range []string{`(a=`, `(a>`, `(a<`, `(a~`} {
+		_, err := CompileFilter(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Package labels parses label selectors. The selector syntax is a
+comma-separated list of requirements, all of which must be satisfied:
+
+	key=value, key==value    the label is set to the value
+	key!=value               the label is not set to the value
+	key in (v1,v2)           the label is set to one of the values
+	key notin (v1,v2)        the label is not set to any of the values
+	key                      the label is set
+	!key                     the label is not set
+*/
+package labels
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+var (
+	errEmptyRequirement = goof.New("empty label requirement")
+	errUnbalancedParens = goof.New("unbalanced parentheses")
+
+	setRX = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// ParseSelector parses a label selector string. An empty string yields an
+// empty selector that matches everything.
+func ParseSelector(s string) (types.LabelSelector, error) {
+	terms, err := splitTerms(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var sel types.LabelSelector
+	for _, t := range terms {
+		r, err := parseRequirement(t)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// splitTerms splits a selector on the commas that are not part of a set.
+func splitTerms(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var (
+		terms []string
+		depth int
+		start int
+	)
+
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, errUnbalancedParens
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, errUnbalancedParens
+	}
+
+	return append(terms, s[start:]), nil
+}
+
+func parseRequirement(s string) (*types.LabelRequirement, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errEmptyRequirement
+	}
+
+	var r *types.LabelRequirement
+
+	if m := setRX.FindStringSubmatch(s); m != nil {
+		r = &types.LabelRequirement{Key: m[1], Op: types.LabelSelectorIn}
+		if m[2] == "notin" {
+			r.Op = types.LabelSelectorNotIn
+		}
+		for _, v := range strings.Split(m[3], ",") {
+			r.Values = append(r.Values, strings.TrimSpace(v))
+		}
+	} else if s[0] == '!' && !strings.Contains(s, "=") {
+		r = &types.LabelRequirement{
+			Key: strings.TrimSpace(s[1:]),
+			Op:  types.LabelSelectorDoesNotExist,
+		}
+	} else if i := strings.Index(s, "!="); i > -1 {
+		r = newEqualityRequirement(
+			s[:i], s[i+2:], types.LabelSelectorNotEquals)
+	} else if i := strings.Index(s, "=="); i > -1 {
+		r = newEqualityRequirement(s[:i], s[i+2:], types.LabelSelectorEquals)
+	} else if i := strings.Index(s, "="); i > -1 {
+		r = newEqualityRequirement(s[:i], s[i+1:], types.LabelSelectorEquals)
+	} else {
+		r = &types.LabelRequirement{Key: s, Op: types.LabelSelectorExists}
+	}
+
+	if err := validateKey(r.Key); err != nil {
+		return nil, err
+	}
+	for _, v := range r.Values {
+		if strings.ContainsAny(v, "=!(),") {
+			return nil, goof.WithField("value", v, "invalid label value")
+		}
+	}
+
+	return r, nil
+}
+
+func newEqualityRequirement(
+	key, val string,
+	op types.LabelSelectorOperator) *types.LabelRequirement {
+
+	return &types.LabelRequirement{
+		Key:    strings.TrimSpace(key),
+		Op:     op,
+		Values: []string{strings.TrimSpace(val)},
+	}
+}
+
+func validateKey(k string) error {
+	if k == "" || strings.ContainsAny(k, " \t=!(),") {
+		return goof.WithField("key", k, "invalid label key")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func TestParseEmpty(t *testing.T) {
+	s, err := ParseSelector("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, s, 0)
+	assert.True(t, s.Matches(nil))
+	assert.True(t, s.Matches(map[string]string{"team": "storage"}))
+}
+
+func TestParseEquals(t *testing.T) {
+	for _, sz := range []string{"team=storage", "team==storage", " team = storage "} {
+		s, err := ParseSelector(sz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, s, 1)
+		assert.EqualValues(t, types.LabelSelectorEquals, s[0].Op)
+		assert.EqualValues(t, "team", s[0].Key)
+		assert.EqualValues(t, []string{"storage"}, s[0].Values)
+	}
+}
+
+func TestParseNotEquals(t *testing.T) {
+	s, err := ParseSelector("team!=storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, s, 1)
+	assert.EqualValues(t, types.LabelSelectorNotEquals, s[0].Op)
+	assert.EqualValues(t, "team", s[0].Key)
+	assert.EqualValues(t, []string{"storage"}, s[0].Values)
+}
+
+func TestParseSets(t *testing.T) {
+	s, err := ParseSelector("env in (prod, qa),tier notin (web)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, s, 2)
+	assert.EqualValues(t, types.LabelSelectorIn, s[0].Op)
+	assert.EqualValues(t, "env", s[0].Key)
+	assert.EqualValues(t, []string{"prod", "qa"}, s[0].Values)
+	assert.EqualValues(t, types.LabelSelectorNotIn, s[1].Op)
+	assert.EqualValues(t, "tier", s[1].Key)
+	assert.EqualValues(t, []string{"web"}, s[1].Values)
+}
+
+func TestParseExists(t *testing.T) {
+	s, err := ParseSelector("team,!deprecated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, s, 2)
+	assert.EqualValues(t, types.LabelSelectorExists, s[0].Op)
+	assert.EqualValues(t, "team", s[0].Key)
+	assert.EqualValues(t, types.LabelSelectorDoesNotExist, s[1].Op)
+	assert.EqualValues(t, "deprecated", s[1].Key)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, sz := range []string{
+		"team=storage,",
+		"=storage",
+		"env in (prod",
+		"env in prod)",
+		"my team=storage",
+		"team=a=b",
+		"!",
+	} {
+		_, err := ParseSelector(sz)
+		assert.Error(t, err, sz)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	labels := map[string]string{
+		"team": "storage",
+		"env":  "prod",
+	}
+
+	tests := map[string]bool{
+		"team=storage":             true,
+		"team=compute":             false,
+		"team!=compute":            true,
+		"owner!=root":              true,
+		"env in (prod,qa)":         true,
+		"env in (dev,qa)":          false,
+		"env notin (dev,qa)":       true,
+		"owner notin (root)":       true,
+		"team":                     true,
+		"owner":                    false,
+		"!owner":                   true,
+		"!team":                    false,
+		"team=storage,env=prod":    true,
+		"team=storage,env in (qa)": false,
+	}
+
+	for sz, expected := range tests {
+		s, err := ParseSelector(sz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, s.Matches(labels), sz)
+	}
+}
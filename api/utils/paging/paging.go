@@ -0,0 +1,206 @@
+/*
+Package paging pages volume and snapshot listings. A listing is paged either
+by the storage driver, if it implements types.StorageDriverWithPaging, or by
+the server. Either way the client receives an opaque continuation token that
+records the service at which the next page begins and the position in that
+service's objects.
+*/
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+// Token is a continuation token.
+type Token struct {
+
+	// Service is the name of the service at which the next page begins.
+	Service string `json:"s,omitempty"`
+
+	// After is the ID of the last object returned by a page that was paged by
+	// the server.
+	After string `json:"a,omitempty"`
+
+	// Page is the driver's token for the driver page from which the next page
+	// begins.
+	Page string `json:"p,omitempty"`
+
+	// Offset is the number of objects in the driver page that have already
+	// been returned.
+	Offset int `json:"o,omitempty"`
+
+	// Size is the limit with which the driver page was fetched. The same
+	// limit is used to fetch the page again so that the offset refers to the
+	// same objects.
+	Size int `json:"n,omitempty"`
+}
+
+// String returns the encoded token.
+func (t *Token) String() string {
+	if t == nil {
+		return ""
+	}
+	buf, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// ParseToken decodes a token.
+func ParseToken(s string) (*Token, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, utils.NewBadContinueTokenErr(s, err)
+	}
+	t := &Token{}
+	if err := json.Unmarshal(buf, t); err != nil {
+		return nil, utils.NewBadContinueTokenErr(s, err)
+	}
+	if t.Offset < 0 {
+		return nil, utils.NewBadContinueTokenErr(
+			s, goof.New("negative offset"))
+	}
+	return t, nil
+}
+
+// ParseOpts returns the page options from the limit and continue query
+// parameters along with the decoded continuation token. A nil value is
+// returned for the options if the listing should not be paged, and for the
+// token if the first page is requested.
+func ParseOpts(store types.Store) (*types.PageOpts, *Token, error) {
+	if !store.IsSet("limit") && !store.IsSet("continue") {
+		return nil, nil, nil
+	}
+
+	var (
+		page = &types.PageOpts{}
+		tok  *Token
+	)
+
+	if store.IsSet("limit") {
+		limit, ok := store.Get("limit").(int64)
+		if !ok || limit < 1 {
+			return nil, nil, utils.NewBadLimitErr(store.Get("limit"))
+		}
+		page.Limit = int(limit)
+	}
+
+	if store.IsSet("continue") {
+		sz, ok := store.Get("continue").(string)
+		if !ok {
+			return nil, nil, utils.NewBadContinueTokenErr(
+				store.GetString("continue"), goof.New("invalid token"))
+		}
+		var err error
+		if tok, err = ParseToken(sz); err != nil {
+			return nil, nil, err
+		}
+		page.Continue = sz
+	}
+
+	return page, tok, nil
+}
+
+// FetchFunc fetches a page of objects from a driver using the driver's page
+// token and page size. The function returns the number of objects in the page
+// and the driver's token for the next page, or an empty string if there are
+// no more pages.
+type FetchFunc func(pageToken string, size int) (
+	count int, next string, err error)
+
+// IncludeFunc is invoked for the object at the provided index of the most
+// recently fetched page. The function returns a flag indicating whether or
+// not the object was included in the page returned to the client.
+type IncludeFunc func(i int) (bool, error)
+
+// DriverPage pages a service's objects using the driver's page tokens,
+// fetching driver pages until the limit is reached or there are no more
+// objects. A limit less than one means there is no limit. The returned token
+// is nil if there are no more objects.
+//
+// Because the objects in a driver page may be omitted by the server's
+// filters, and because a driver page may be larger than the limit, the
+// returned token records the offset into the driver page at which the next
+// page begins.
+func DriverPage(
+	limit int,
+	tok *Token,
+	fetch FetchFunc,
+	include IncludeFunc) (*Token, error) {
+
+	var (
+		pageToken string
+		offset    int
+		count     int
+		size      = limit
+	)
+
+	if tok != nil {
+		pageToken, offset = tok.Page, tok.Offset
+		if offset > 0 {
+			size = tok.Size
+		}
+	}
+
+	for {
+		n, next, err := fetch(pageToken, size)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := offset; i < n; i++ {
+			ok, err := include(i)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if count++; limit < 1 || count < limit {
+				continue
+			}
+			if i+1 < n {
+				return &Token{
+					Page:   pageToken,
+					Offset: i + 1,
+					Size:   size,
+				}, nil
+			}
+			if next == "" {
+				return nil, nil
+			}
+			return &Token{Page: next}, nil
+		}
+
+		if next == "" {
+			return nil, nil
+		}
+		pageToken, offset, size = next, 0, limit
+	}
+}
+
+// ServerPage pages the IDs of a service's objects for a driver that does not
+// page its own objects. The IDs are sorted and the page begins after the ID
+// recorded by the token. The returned token is nil if there are no more IDs.
+func ServerPage(limit int, tok *Token, ids []string) ([]string, *Token) {
+	sort.Strings(ids)
+
+	if tok != nil && tok.After != "" {
+		ids = ids[sort.SearchStrings(ids, tok.After):]
+		if len(ids) > 0 && ids[0] == tok.After {
+			ids = ids[1:]
+		}
+	}
+
+	if limit < 1 || len(ids) <= limit {
+		return ids, nil
+	}
+
+	ids = ids[:limit]
+	return ids, &Token{After: ids[limit-1]}
+}
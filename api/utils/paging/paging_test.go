@@ -0,0 +1,164 @@
+package paging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	tok := &Token{Service: "vfs", Page: "abc", Offset: 3, Size: 5}
+	tok2, err := ParseToken(tok.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, tok, tok2)
+	assert.Equal(t, "", (*Token)(nil).String())
+}
+
+func TestParseTokenErrors(t *testing.T) {
+	for _, sz := range []string{"!!!", "bm90IGpzb24", "eyJvIjotMX0"} {
+		_, err := ParseToken(sz)
+		assert.Error(t, err, sz)
+		assert.IsType(t, &types.ErrBadContinueToken{}, err, sz)
+	}
+}
+
+func TestParseOpts(t *testing.T) {
+	page, tok, err := ParseOpts(utils.NewStore())
+	assert.NoError(t, err)
+	assert.Nil(t, page)
+	assert.Nil(t, tok)
+
+	store := utils.NewStore()
+	store.Set("limit", int64(10))
+	store.Set("continue", (&Token{Service: "vfs", After: "vfs-001"}).String())
+	page, tok, err = ParseOpts(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 10, page.Limit)
+	assert.NotEmpty(t, page.Continue)
+	assert.Equal(t, "vfs", tok.Service)
+	assert.Equal(t, "vfs-001", tok.After)
+}
+
+func TestParseOptsErrors(t *testing.T) {
+	for _, v := range []interface{}{int64(0), int64(-1), "ten", true} {
+		store := utils.NewStore()
+		store.Set("limit", v)
+		_, _, err := ParseOpts(store)
+		assert.IsType(t, &types.ErrBadLimit{}, err)
+	}
+
+	store := utils.NewStore()
+	store.Set("continue", "!!!")
+	_, _, err := ParseOpts(store)
+	assert.IsType(t, &types.ErrBadContinueToken{}, err)
+}
+
+func TestServerPage(t *testing.T) {
+	ids := []string{"e", "b", "d", "a", "c"}
+
+	page, tok := ServerPage(2, nil, ids)
+	assert.Equal(t, []string{"a", "b"}, page)
+	assert.Equal(t, "b", tok.After)
+
+	page, tok = ServerPage(2, tok, ids)
+	assert.Equal(t, []string{"c", "d"}, page)
+	assert.Equal(t, "d", tok.After)
+
+	page, tok = ServerPage(2, tok, ids)
+	assert.Equal(t, []string{"e"}, page)
+	assert.Nil(t, tok)
+
+	// the object after which the page begins was removed
+	page, tok = ServerPage(2, &Token{After: "bb"}, ids)
+	assert.Equal(t, []string{"c", "d"}, page)
+	assert.NotNil(t, tok)
+
+	page, tok = ServerPage(0, nil, ids)
+	assert.Len(t, page, 5)
+	assert.Nil(t, tok)
+}
+
+// driverPages are the pages of a fake driver whose page tokens are the
+// page indices.
+var driverPages = map[string][]string{
+	"":  {"a", "b", "c"},
+	"1": {"d", "e", "f"},
+	"2": {"g"},
+}
+
+func pageAll(
+	t *testing.T, limit int, include func(id string) bool) [][]string {
+
+	var (
+		pages [][]string
+		tok   *Token
+	)
+
+	for {
+		var (
+			objs []string
+			page []string
+		)
+		next, err := DriverPage(
+			limit,
+			tok,
+			func(pageToken string, size int) (int, string, error) {
+				objs = driverPages[pageToken]
+				switch pageToken {
+				case "":
+					return len(objs), "1", nil
+				case "1":
+					return len(objs), "2", nil
+				}
+				return len(objs), "", nil
+			},
+			func(i int) (bool, error) {
+				if !include(objs[i]) {
+					return false, nil
+				}
+				page = append(page, objs[i])
+				return true, nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, page)
+		if next == nil {
+			return pages
+		}
+		if next.Offset > 0 {
+			assert.Equal(t, limit, next.Size)
+		}
+		tok = next
+	}
+}
+
+func TestDriverPage(t *testing.T) {
+	all := func(string) bool { return true }
+
+	assert.Equal(t,
+		[][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}, {"g"}},
+		pageAll(t, 2, all))
+
+	assert.Equal(t,
+		[][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g"}},
+		pageAll(t, 3, all))
+
+	assert.Equal(t,
+		[][]string{{"a", "b", "c", "d", "e", "f", "g"}},
+		pageAll(t, 0, all))
+
+	// objects omitted by the server's filters do not count toward the limit
+	assert.Equal(t,
+		[][]string{{"a", "c"}, {"e", "g"}},
+		pageAll(t, 2, func(id string) bool {
+			return id == "a" || id == "c" || id == "e" || id == "g"
+		}))
+}
@@ -0,0 +1,106 @@
+/*
+Package ratelimit provides token bucket rate limiters. A bucket holds up to a
+burst of tokens and is refilled at a constant rate. Each request takes a
+token, and a request that finds the bucket empty is either rejected or waits
+for the bucket to be refilled.
+*/
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Limiter is a token bucket.
+type Limiter struct {
+	sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// New returns a new limiter that is refilled at the rate of the specified
+// number of tokens per second and that holds up to the specified number of
+// tokens. A burst less than one is treated as one. The limiter is full.
+func New(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// refill adds the tokens accrued since the limiter was last refilled.
+func (l *Limiter) refill() {
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// Take takes a token and returns zero if one is available. Otherwise no
+// token is taken and the duration after which a token is available is
+// returned.
+func (l *Limiter) Take() time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	l.refill()
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Put returns a token that was taken but not used. The limiter holds no more
+// than its burst of tokens.
+func (l *Limiter) Put() {
+	l.Lock()
+	defer l.Unlock()
+	l.refill()
+	if l.tokens++; l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// Full returns a flag that indicates whether the limiter holds its burst of
+// tokens, meaning it has not been used for some time.
+func (l *Limiter) Full() bool {
+	l.Lock()
+	defer l.Unlock()
+	l.refill()
+	return l.tokens >= l.burst
+}
+
+// Wait waits until a token is available and takes it. An error is returned
+// if the context is done first.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		d := l.Take()
+		if d == 0 {
+			return nil
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func newTestLimiter(rate float64, burst int) (*Limiter, *time.Time) {
+	now := time.Unix(0, 0)
+	l := New(rate, burst)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestTake(t *testing.T) {
+	l, now := newTestLimiter(2, 3)
+
+	// the limiter starts with its burst of tokens
+	assert.Zero(t, l.Take())
+	assert.Zero(t, l.Take())
+	assert.Zero(t, l.Take())
+	assert.Equal(t, 500*time.Millisecond, l.Take())
+	assert.False(t, l.Full())
+
+	*now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, l.Take())
+
+	*now = now.Add(250 * time.Millisecond)
+	assert.Zero(t, l.Take())
+	assert.Equal(t, 500*time.Millisecond, l.Take())
+
+	// the limiter holds no more than its burst of tokens
+	*now = now.Add(time.Hour)
+	assert.True(t, l.Full())
+	assert.Zero(t, l.Take())
+	assert.Zero(t, l.Take())
+	assert.Zero(t, l.Take())
+	assert.NotZero(t, l.Take())
+}
+
+func TestTakeZeroBurst(t *testing.T) {
+	l, _ := newTestLimiter(1, 0)
+	assert.Zero(t, l.Take())
+	assert.Equal(t, time.Second, l.Take())
+}
+
+func TestWait(t *testing.T) {
+	l := New(100, 1)
+	assert.NoError(t, l.Wait(context.Background()))
+
+	start := time.Now()
+	assert.NoError(t, l.Wait(context.Background()))
+	assert.True(t, time.Since(start) >= 5*time.Millisecond)
+
+	l = New(0.001, 1)
+	assert.Zero(t, l.Take())
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx))
+}
+
+func TestPut(t *testing.T) {
+	l, _ := newTestLimiter(1, 2)
+
+	assert.Zero(t, l.Take())
+	assert.Zero(t, l.Take())
+	assert.NotZero(t, l.Take())
+
+	l.Put()
+	assert.Zero(t, l.Take())
+
+	// the limiter holds no more than its burst of tokens
+	l.Put()
+	l.Put()
+	l.Put()
+	assert.True(t, l.Full())
+	assert.Zero(t, l.Take())
+	assert.Zero(t, l.Take())
+	assert.NotZero(t, l.Take())
+}
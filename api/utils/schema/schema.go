@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/cesanta/ucl"
@@ -43,6 +44,12 @@ var (
 	// SnapshotSchema is the JSON schema for the Snapshot resource.
 	SnapshotSchema = buildSchemaVar("snapshot")
 
+	// TaskSchema is the JSON schema for the Task resource.
+	TaskSchema = buildSchemaVar("task")
+
+	// TaskMapSchema is the JSON schema for a map[string]*Task.
+	TaskMapSchema = buildSchemaVar("taskMap")
+
 	// ServiceInfoSchema is the JSON schema for the ServiceInfo resource.
 	ServiceInfoSchema = buildSchemaVar("serviceInfo")
 
@@ -55,6 +62,12 @@ var (
 	// ExecutorInfoSchema is the JSON schema for the ExecutorInfo resource.
 	ExecutorInfoSchema = buildSchemaVar("executorInfo")
 
+	// VersionInfoSchema is the JSON schema for the VersionInfo resource.
+	VersionInfoSchema = buildSchemaVar("versionInfo")
+
+	// URLsSchema is the JSON schema for a list of resource URLs.
+	URLsSchema = buildSchemaVar("urls")
+
 	// VolumeCreateRequestSchema is the JSON schema for a Volume creation
 	// request.
 	VolumeCreateRequestSchema = buildSchemaVar("volumeCreateRequest")
@@ -79,6 +92,10 @@ var (
 	// request.
 	VolumeDetachRequestSchema = buildSchemaVar("volumeDetachRequest")
 
+	// VolumeResizeRequestSchema is the JSON schema for a Volume resize
+	// request.
+	VolumeResizeRequestSchema = buildSchemaVar("volumeResizeRequest")
+
 	// SnapshotCopyRequestSchema is the JSON schema for a Snapshot copy
 	// request.
 	SnapshotCopyRequestSchema = buildSchemaVar("snapshotCopyRequest")
@@ -96,6 +113,23 @@ func buildSchemaVar(name string) []byte {
 }`, jsonSchemaID, name))
 }
 
+// DefinitionName returns the name of the definition in the libStorage JSON
+// schema to which a schema built by this package refers. An empty string is
+// returned if the schema does not refer to a definition.
+func DefinitionName(s []byte) string {
+	var v struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(s, &v); err != nil {
+		return ""
+	}
+	prefix := jsonSchemaID + "#/definitions/"
+	if !strings.HasPrefix(v.Ref, prefix) {
+		return ""
+	}
+	return v.Ref[len(prefix):]
+}
+
 // ValidateVolume validates a Volume object using the JSON schema. If the
 // object is valid no error is returned. The first return value, the object
 // marshaled to JSON, is returned whether or not the validation is successful.
@@ -128,6 +162,15 @@ func ValidateVolumeSnapshotRequest(
 	return validateObject(VolumeSnapshotRequestSchema, v)
 }
 
+// ValidateVolumeResizeRequest validates a VolumeResizeRequest object using the
+// JSON schema. If the object is valid no error is returned. The first return
+// value, the object marshaled to JSON, is returned whether or not the
+// validation is successful.
+func ValidateVolumeResizeRequest(
+	v *types.VolumeResizeRequest) ([]byte, error) {
+	return validateObject(VolumeResizeRequestSchema, v)
+}
+
 func validateObject(s []byte, o interface{}) (d []byte, e error) {
 	if d, e = json.Marshal(o); e != nil {
 		return
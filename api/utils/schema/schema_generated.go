@@ -61,6 +61,7 @@ const (
                     "type": "string",
                     "description": "The volume status."
                 },
+                "labels": { "$ref": "#/definitions/labels" },
                 "fields": { "$ref": "#/definitions/fields" }
             },
             "required": [ "id", "name" ],
@@ -190,6 +191,7 @@ const (
                     "type": "number",
                     "description": "The size of the volume to which the snapshot belongs."
                 },
+                "labels": { "$ref": "#/definitions/labels" },
                 "fields": { "$ref": "#/definitions/fields" }
             },
             "required": [ "id" ],
@@ -224,6 +226,11 @@ const (
                     "type": "number",
                     "description": "The time stamp (epoch) when the task started running."
                 },
+                "state": {
+                    "type": "string",
+                    "enum": [ "queued", "running", "success", "error", "canceled" ],
+                    "description": "The current state of the task."
+                },
                 "result": {
                     "type": "object",
                     "description": "The result of the operation."
@@ -232,9 +239,29 @@ const (
                     "type": "object",
                     "description": "If the operation returned an error, this is it."
                 },
+                "locks": {
+                    "type": "array",
+                    "description": "The locks the task acquires before it runs.",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "key": {
+                                "type": "string",
+                                "description": "The key of the locked resource."
+                            },
+                            "state": {
+                                "type": "string",
+                                "enum": [ "waiting", "held", "released", "timedOut" ],
+                                "description": "The state of the lock."
+                            }
+                        },
+                        "required": [ "key", "state" ],
+                        "additionalProperties": false
+                    }
+                },
                 "fields": { "$ref": "#/definitions/fields" }
             },
-            "required": [ "id", "name",  "user", "queueTime" ],
+            "required": [ "id", "queueTime", "state" ],
             "additionalProperties": false
         },
 
@@ -265,13 +292,32 @@ const (
                     "type": "string",
                     "description": "Type is the type of storage the driver provides: block, nas, object."
                 },
-                "nextDevice": { "$ref": "#/definitions/nextDeviceInfo" }
+                "nextDevice": { "$ref": "#/definitions/nextDeviceInfo" },
+                "capabilities": { "$ref": "#/definitions/driverCapabilities" }
             },
             "required": [ "name", "type" ],
             "additionalProperties": false
         },
 
 
+        "driverCapabilities": {
+            "type": "object",
+            "description": "Capabilities indicates which of the optional operations and features the driver supports. Whether the driver supports a capability that is absent is unknown.",
+            "properties": {
+                "snapshots": { "type": "boolean" },
+                "copy": { "type": "boolean" },
+                "snapshotCopy": { "type": "boolean" },
+                "createFromSnapshot": { "type": "boolean" },
+                "resize": { "type": "boolean" },
+                "encryption": { "type": "boolean" },
+                "multiAttach": { "type": "boolean" },
+                "iops": { "type": "boolean" },
+                "availabilityZones": { "type": "boolean" }
+            },
+            "additionalProperties": false
+        },
+
+
         "nextDeviceInfo": {
             "type": "object",
             "properties": {
@@ -302,6 +348,16 @@ const (
         },
 
 
+        "labels": {
+            "type": "object",
+            "description": "Labels are user-defined key/value pairs used to organize and select objects.",
+            "patternProperties": {
+                ".+": { "type": "string" }
+            },
+            "additionalProperties": false
+        },
+
+
         "volumeMap": {
             "type": "object",
             "patternProperties": {
@@ -418,6 +474,7 @@ const (
                 "type": {
                     "type": "string"
                 },
+                "labels": { "$ref" : "#/definitions/labels" },
                 "opts": { "$ref" : "#/definitions/opts" }
             },
             "required": [ "name" ],
@@ -444,6 +501,7 @@ const (
                 "snapshotName": {
                     "type": "string"
                 },
+                "labels": { "$ref" : "#/definitions/labels" },
                 "opts": { "$ref" : "#/definitions/opts" }
             },
             "required": [ "snapshotName" ],
@@ -460,6 +518,9 @@ const (
                 "force": {
                     "type": "boolean"
                 },
+                "readOnly": {
+                    "type": "boolean"
+                },
                 "opts": { "$ref" : "#/definitions/opts" }
             },
             "additionalProperties": false
@@ -489,6 +550,22 @@ const (
         },
 
 
+        "volumeResizeRequest": {
+            "type": "object",
+            "properties": {
+                "size": {
+                    "type": "number"
+                },
+                "force": {
+                    "type": "boolean"
+                },
+                "opts": { "$ref" : "#/definitions/opts" }
+            },
+            "required": [ "size" ],
+            "additionalProperties": false
+        },
+
+
         "snapshotCopyRequest": {
             "type": "object",
             "properties": {
@@ -514,6 +591,42 @@ const (
         },
 
 
+        "versionInfo": {
+            "type": "object",
+            "properties": {
+                "semver": {
+                    "type": "string",
+                    "description": "The semantic version string."
+                },
+                "shaLong": {
+                    "type": "string",
+                    "description": "The commit hash from which the server was built."
+                },
+                "buildTimestamp": {
+                    "type": "number",
+                    "description": "The time stamp (epoch) when the server was built."
+                },
+                "branch": {
+                    "type": "string",
+                    "description": "The branch name from which the server was built."
+                },
+                "arch": {
+                    "type": "string",
+                    "description": "The OS-Arch string of the system the server supports."
+                }
+            },
+            "required": [ "semver" ],
+            "additionalProperties": false
+        },
+
+
+        "urls": {
+            "type": "array",
+            "description": "A list of the URLs of the resources beneath a resource.",
+            "items": { "type": "string" }
+        },
+
+
         "error": {
             "type": "object",
             "properties": {
@@ -24,6 +24,10 @@ func TestVolumeObject(t *testing.T) {
 				DeviceName: "/dev/xvd000",
 			},
 		},
+		Labels: map[string]string{
+			"team": "storage",
+			"app":  "db",
+		},
 		Fields: map[string]string{
 			"priority": "2",
 			"owner":    "root@example.com",
@@ -138,6 +142,20 @@ func TestVolumeSchema(t *testing.T) {
 	err = Validate(nil, s, d)
 	assert.Error(t, err)
 	assert.EqualError(t, err, `"#/fields/priority": must be of type "string"`)
+
+	d = []byte(`{
+    "id": "vol-000",
+    "name": "Volume 000",
+    "size": 378,
+    "labels": {
+        "team": "storage",
+        "replicas": 2
+    }
+}`)
+
+	err = Validate(nil, s, d)
+	assert.Error(t, err)
+	assert.EqualError(t, err, `"#/labels/replicas": must be of type "string"`)
 }
 
 func TestSnapshotObject(t *testing.T) {
@@ -148,6 +166,9 @@ func TestSnapshotObject(t *testing.T) {
 		VolumeID:   "vol-000",
 		VolumeSize: 10240,
 		StartTime:  1455826676,
+		Labels: map[string]string{
+			"team": "storage",
+		},
 		Fields: map[string]string{
 			"sparse": "true",
 			"region": "US",
@@ -246,6 +267,9 @@ func TestVolumeCreateRequestObject(t *testing.T) {
 		IOPS:             &iops,
 		Size:             &size,
 		Type:             &volType,
+		Labels: map[string]string{
+			"team": "storage",
+		},
 		Opts: map[string]interface{}{
 			"priority": 2,
 			"owner":    "root@example.com",
@@ -272,6 +296,7 @@ func TestVolumeSnapshotRequestObject(t *testing.T) {
 
 	s := &types.VolumeSnapshotRequest{
 		SnapshotName: snapshotName,
+		Labels:       map[string]string{"team": "storage"},
 		Opts:         opts,
 	}
 
@@ -282,3 +307,46 @@ func TestVolumeSnapshotRequestObject(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestVolumeResizeRequestObject(t *testing.T) {
+	s := &types.VolumeResizeRequest{
+		Size: 20,
+		Opts: map[string]interface{}{
+			"priority": 2,
+		},
+	}
+
+	d, err := ValidateVolumeResizeRequest(s)
+	if d == nil {
+		assert.NoError(t, err, string(d))
+	} else {
+		assert.NoError(t, err)
+	}
+}
+
+func TestVolumeResizeRequestSchema(t *testing.T) {
+	s := VolumeResizeRequestSchema
+
+	d := []byte(`{
+    "size": 20,
+    "force": true
+}`)
+
+	err := Validate(nil, s, d)
+	assert.NoError(t, err)
+
+	d = []byte(`{
+    "force": true
+}`)
+
+	err = Validate(nil, s, d)
+	assert.Error(t, err)
+	assert.EqualError(t, err, `"#" must have property "size"`)
+}
+
+func TestDefinitionName(t *testing.T) {
+	assert.Equal(t, "volume", DefinitionName(VolumeSchema))
+	assert.Equal(t, "taskMap", DefinitionName(TaskMapSchema))
+	assert.Equal(t, "", DefinitionName([]byte(`{"type":"string"}`)))
+	assert.Equal(t, "", DefinitionName(nil))
+}
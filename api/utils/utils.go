@@ -49,3 +49,12 @@ func DeviceAttachTimeout(val string) time.Duration {
 	}
 	return dur
 }
+
+// DeviceDetachTimeout gets the configured device detach timeout.
+func DeviceDetachTimeout(val string) time.Duration {
+	dur, err := time.ParseDuration(val)
+	if err != nil {
+		return time.Duration(30) * time.Second
+	}
+	return dur
+}
@@ -19,7 +19,8 @@ func ParseAuthConfig(
 
 	const prefix = types.ConfigServer + "."
 
-	if !isSetPrefix(config, prefix, types.ConfigServerAuthAllow, roots...) {
+	if !isSetPrefix(config, prefix, types.ConfigServerAuthAllow, roots...) &&
+		!isSetPrefix(config, prefix, types.ConfigServerAuthRoles, roots...) {
 		ctx.Debug("server auth config not defined")
 		return nil, nil
 	}
@@ -73,5 +74,11 @@ func ParseAuthConfig(
 		f(types.ConfigServerAuthDeny, authConfig.Deny)
 	}
 
+	if isSetPrefix(config, prefix, types.ConfigServerAuthRoles, roots...) {
+		authConfig.Roles = getStringSliceMapPrefix(
+			config, prefix, types.ConfigServerAuthRoles, roots...)
+		f(types.ConfigServerAuthRoles, authConfig.Roles)
+	}
+
 	return authConfig, nil
 }
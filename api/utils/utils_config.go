@@ -127,3 +127,55 @@ func getStringSlicePrefix(
 
 	return config.GetStringSlice(key)
 }
+
+func getStringSliceMapPrefix(
+	config gofig.Config,
+	prefix, key string,
+	roots ...string) map[string][]string {
+
+	for _, r := range roots {
+		rk := strings.Replace(key, prefix, fmt.Sprintf("%s.", r), 1)
+		if val := toStringSliceMap(config.Get(rk)); len(val) > 0 {
+			return val
+		}
+	}
+
+	return toStringSliceMap(config.Get(key))
+}
+
+// toStringSliceMap converts a config value into a map of string slices. Each
+// of the map's values may be a list or a single string of elements separated
+// by whitespace or commas.
+func toStringSliceMap(v interface{}) map[string][]string {
+	m := map[string][]string{}
+
+	add := func(k string, v interface{}) {
+		switch tv := v.(type) {
+		case string:
+			m[k] = strings.FieldsFunc(tv, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			})
+		case []string:
+			m[k] = tv
+		case []interface{}:
+			for _, e := range tv {
+				m[k] = append(m[k], fmt.Sprintf("%v", e))
+			}
+		}
+	}
+
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, v := range tv {
+			add(k, v)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range tv {
+			add(fmt.Sprintf("%v", k), v)
+		}
+	case map[string][]string:
+		return tv
+	}
+
+	return m
+}
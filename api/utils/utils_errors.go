@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"time"
+
 	"github.com/akutz/goof"
 
 	"github.com/codedellemc/libstorage/api/types"
@@ -71,3 +73,67 @@ func NewBadFilterErr(filter string, err error) error {
 	return &types.ErrBadFilter{Goof: goof.WithFieldE(
 		"filter", filter, "bad filter", err)}
 }
+
+// NewBadLabelSelectorErr returns a new ErrBadLabelSelector error.
+func NewBadLabelSelectorErr(selector string, err error) error {
+	return &types.ErrBadLabelSelector{Goof: goof.WithFieldE(
+		"labelSelector", selector, "bad label selector", err)}
+}
+
+// NewBadLimitErr returns a new ErrBadLimit error.
+func NewBadLimitErr(limit interface{}) error {
+	return &types.ErrBadLimit{Goof: goof.WithField(
+		"limit", limit, "bad limit")}
+}
+
+// NewBadContinueTokenErr returns a new ErrBadContinueToken error.
+func NewBadContinueTokenErr(token string, err error) error {
+	return &types.ErrBadContinueToken{Goof: goof.WithFieldE(
+		"continue", token, "bad continue token", err)}
+}
+
+// NewIdempotencyKeyReusedErr returns a new ErrIdempotencyKeyReused error.
+func NewIdempotencyKeyReusedErr(key string) error {
+	return &types.ErrIdempotencyKeyReused{Goof: goof.WithField(
+		"idempotencyKey", key, "idempotency key reused for different request")}
+}
+
+// NewLockTimeoutErr returns a new ErrLockTimeout error.
+func NewLockTimeoutErr(key string, timeout interface{}) error {
+	return &types.ErrLockTimeout{Goof: goof.WithFields(goof.Fields{
+		"lockKey": key,
+		"timeout": timeout,
+	}, "timed out waiting for lock")}
+}
+
+// NewRateLimitedErr returns a new ErrRateLimited error.
+func NewRateLimitedErr(
+	service, subject string, retryAfter time.Duration) error {
+
+	return &types.ErrRateLimited{
+		Goof: goof.WithFields(goof.Fields{
+			"service":    service,
+			"subject":    subject,
+			"retryAfter": retryAfter.String(),
+		}, "rate limit exceeded"),
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewUnsupportedCapabilityErr returns a new ErrUnsupportedCapability error.
+func NewUnsupportedCapabilityErr(
+	service string, capability types.DriverCapability) error {
+
+	return &types.ErrUnsupportedCapability{Goof: goof.WithFields(goof.Fields{
+		"service":    service,
+		"capability": capability,
+	}, "unsupported capability")}
+}
+
+// NewPermissionDeniedError returns a new ErrPermissionDenied error.
+func NewPermissionDeniedError(subject, permission string) error {
+	return &types.ErrPermissionDenied{Goof: goof.WithFields(goof.Fields{
+		"subject":    subject,
+		"permission": permission,
+	}, "permission denied")}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// ParseLimitsConfig returns a new LimitsConfig instance. A nil value is
+// returned if no limits are configured.
+func ParseLimitsConfig(
+	ctx types.Context,
+	config gofig.Config,
+	fields log.Fields,
+	roots ...string) (*types.LimitsConfig, error) {
+
+	const prefix = types.ConfigServer + "."
+
+	var (
+		isSet  bool
+		limits = &types.LimitsConfig{}
+	)
+
+	f := func(k string, v interface{}) {
+		isSet = true
+		if fields == nil {
+			return
+		}
+		fields[k] = v
+		ctx.WithField(k, v).Debug("parsed server limits property")
+	}
+
+	getFloat := func(k string, v *float64) error {
+		if !isSetPrefix(config, prefix, k, roots...) {
+			return nil
+		}
+		sz := getStringPrefix(config, prefix, k, roots...)
+		fv, err := strconv.ParseFloat(sz, 64)
+		if err != nil || fv < 0 {
+			return goof.WithFieldE("configKey", k, "invalid limit", err)
+		}
+		*v = fv
+		f(k, fv)
+		return nil
+	}
+
+	getInt := func(k string, v *int) error {
+		if !isSetPrefix(config, prefix, k, roots...) {
+			return nil
+		}
+		sz := getStringPrefix(config, prefix, k, roots...)
+		iv, err := strconv.Atoi(sz)
+		if err != nil || iv < 0 {
+			return goof.WithFieldE("configKey", k, "invalid limit", err)
+		}
+		*v = iv
+		f(k, iv)
+		return nil
+	}
+
+	if err := getFloat(
+		types.ConfigServerLimitsRate, &limits.Rate); err != nil {
+		return nil, err
+	}
+	if err := getInt(
+		types.ConfigServerLimitsBurst, &limits.Burst); err != nil {
+		return nil, err
+	}
+	if err := getFloat(
+		types.ConfigServerLimitsSubjectRate, &limits.SubjectRate); err != nil {
+		return nil, err
+	}
+	if err := getInt(
+		types.ConfigServerLimitsSubjectBurst, &limits.SubjectBurst); err != nil {
+		return nil, err
+	}
+	if err := getInt(
+		types.ConfigServerLimitsMaxInFlight, &limits.MaxInFlight); err != nil {
+		return nil, err
+	}
+
+	if isSetPrefix(config, prefix, types.ConfigServerLimitsQueue, roots...) {
+		limits.Queue = getBoolPrefix(
+			config, prefix, types.ConfigServerLimitsQueue, roots...)
+		f(types.ConfigServerLimitsQueue, limits.Queue)
+	}
+
+	if !isSet {
+		ctx.Debug("server limits config not defined")
+		return nil, nil
+	}
+
+	return limits, nil
+}
@@ -366,6 +366,30 @@ func (s *keyValueStore) GetMap(k string) map[string]interface{} {
 	}
 }
 
+func (s *keyValueStore) GetStringMap(k string) map[string]string {
+	v := s.Get(k)
+	switch tv := v.(type) {
+	case map[string]string:
+		return tv
+	case map[string]interface{}:
+		return toStringMap(tv)
+	case types.Store:
+		return toStringMap(tv.Map())
+	default:
+		return nil
+	}
+}
+
+func toStringMap(m map[string]interface{}) map[string]string {
+	sm := map[string]string{}
+	for k, v := range m {
+		if sv, ok := v.(string); ok {
+			sm[k] = sv
+		}
+	}
+	return sm
+}
+
 func (s *keyValueStore) GetInstanceID(k string) *types.InstanceID {
 	v := s.Get(k)
 	switch tv := v.(type) {
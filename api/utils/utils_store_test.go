@@ -112,3 +112,21 @@ func TestGetInt64Ptr(t *testing.T) {
 	assert.NotNil(t, pv)
 	assert.EqualValues(t, v, *pv)
 }
+
+func TestGetStringMap(t *testing.T) {
+	s := NewStore()
+	assert.Nil(t, s.GetStringMap("myVal"))
+
+	v := map[string]string{"team": "storage"}
+	s.Set("myVal", v)
+	assert.EqualValues(t, v, s.GetStringMap("myVal"))
+
+	s.Set("myVal", map[string]interface{}{"team": "storage", "size": 5})
+	assert.EqualValues(t, v, s.GetStringMap("myVal"))
+
+	s.Set("myVal", NewStoreWithData(map[string]interface{}{"team": "storage"}))
+	assert.EqualValues(t, v, s.GetStringMap("myVal"))
+
+	s.Set("myVal", "storage")
+	assert.Nil(t, s.GetStringMap("myVal"))
+}
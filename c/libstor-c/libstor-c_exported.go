@@ -58,7 +58,7 @@ func volumes(clientID C.h, attachments C.short) C.result {
 	}
 
 	svcToVolMap, err := c.API().Volumes(
-		nil, types.VolumeAttachmentsTypes(attachments))
+		nil, types.VolumeAttachmentsTypes(attachments), "")
 	if err != nil {
 		result.err = C.CString(err.Error())
 		return result
@@ -217,8 +217,9 @@ func (d *driver) Mount(
 		var token string
 		vol, token, err = client.Storage().VolumeAttach(
 			ctx, vol.ID, &types.VolumeAttachOpts{
-				Force: opts.Preempt,
-				Opts:  utils.NewStore(),
+				Force:    opts.Preempt,
+				ReadOnly: opts.ReadOnly,
+				Opts:     utils.NewStore(),
 			})
 		if err != nil {
 			return "", nil, err
@@ -283,17 +284,24 @@ func (d *driver) Mount(
 		return d.volumeMountPath(mounts[0].MountPoint), vol, nil
 	}
 
-	if opts.NewFSType == "" {
-		opts.NewFSType = d.fsType()
-	}
-	if err := client.OS().Format(
-		ctx,
-		ma.DeviceName,
-		&types.DeviceFormatOpts{
-			NewFSType:   opts.NewFSType,
-			OverwriteFS: opts.OverwriteFS,
-		}); err != nil {
-		return "", nil, err
+	// a device attached read-only cannot be formatted
+	if opts.ReadOnly {
+		ctx.Debug("skipping format of read-only volume")
+	} else {
+		if opts.NewFSType == "" {
+			opts.NewFSType = d.fsType()
+		}
+		if err := client.OS().Format(
+			ctx,
+			ma.DeviceName,
+			&types.DeviceFormatOpts{
+				NewFSType:   opts.NewFSType,
+				OverwriteFS: opts.OverwriteFS,
+				Label:       opts.NewFSLabel,
+				UUID:        opts.NewFSUUID,
+			}); err != nil {
+			return "", nil, err
+		}
 	}
 
 	mountPath, err := d.getVolumeMountPath(vol.Name)
@@ -309,7 +317,12 @@ func (d *driver) Mount(
 		ctx,
 		ma.DeviceName,
 		mountPath,
-		&types.DeviceMountOpts{}); err != nil {
+		&types.DeviceMountOpts{
+			MountOptions: opts.MountOptions,
+			MountLabel:   opts.MountLabel,
+			ReadOnly:     opts.ReadOnly,
+			Opts:         opts.Opts,
+		}); err != nil {
 		return "", nil, err
 	}
 
@@ -392,7 +405,21 @@ func (d *driver) Unmount(
 		}
 	}
 
-	vol, err = client.Storage().VolumeDetach(ctx, vol.ID,
+	// flush the device's buffers so that no data is lost if the volume is
+	// attached to another host as soon as it is detached from this one
+	if od, ok := client.OS().(types.OSDriverWithFlush); ok {
+		if err := od.Flush(ctx, ma.DeviceName, opts); err != nil {
+			if err != types.ErrNotImplemented {
+				return nil, goof.WithFieldE(
+					"deviceName", ma.DeviceName,
+					"problem flushing device", err)
+			}
+			ctx.Debug("skipping device flush; not supported by os driver")
+		}
+	}
+
+	volID := vol.ID
+	vol, err = client.Storage().VolumeDetach(ctx, volID,
 		&types.VolumeDetachOpts{
 			Force: opts.GetBool("force"),
 			Opts:  utils.NewStore(),
@@ -401,12 +428,139 @@ func (d *driver) Unmount(
 		return nil, err
 	}
 
+	// the device is expected to be removed from the host once the volume is
+	// detached, unless the executor is unable to observe the removal
+	fields := log.Fields{"volumeID": volID, "deviceName": ma.DeviceName}
+	lsxSO, _ := client.Executor().Supported(ctx, opts)
+	if !lsxSO.WaitForDeviceRemoval() {
+		ctx.WithFields(fields).Warn(
+			"skipping wait for device removal; not supported by executor")
+	} else if _, _, err := client.Executor().WaitForDeviceRemoval(
+		ctx, &types.WaitForDeviceRemovalOpts{
+			LocalDevicesOpts: types.LocalDevicesOpts{
+				ScanType: apiconfig.DeviceScanType(d.config),
+				Opts:     opts,
+			},
+			DeviceName: ma.DeviceName,
+			Timeout:    apiconfig.DeviceDetachTimeout(d.config),
+		}); err != nil {
+		if err.Error() != types.ErrNotImplemented.Error() {
+			return nil, goof.WithFieldsE(
+				fields, "device still present on host after detach", err)
+		}
+		ctx.WithFields(fields).Warn(
+			"skipping wait for device removal; not implemented by executor")
+	}
+
 	ctx.WithFields(log.Fields{
 		"vol": vol}).Info("unmounted and detached volume")
 
 	return vol, nil
 }
 
+// Resize will grow the volume specified by volumeName or volumeID. If the
+// volume is attached to and mounted on this instance then its file system
+// is grown as well.
+func (d *driver) Resize(
+	ctx types.Context,
+	volumeID, volumeName string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	ctx.WithFields(log.Fields{
+		"volumeName": volumeName,
+		"volumeID":   volumeID,
+		"opts":       opts}).Info("resizing volume")
+
+	if volumeName == "" && volumeID == "" {
+		return nil, goof.New("missing volume name or ID")
+	}
+
+	if opts.Opts == nil {
+		opts.Opts = utils.NewStore()
+	}
+
+	client := context.MustClient(ctx)
+
+	sd, ok := client.Storage().(types.StorageDriverWithResize)
+	if !ok {
+		return nil, types.ErrNotImplemented
+	}
+
+	vol, err := d.volumeInspectByIDOrName(
+		ctx, volumeID, volumeName, 0, opts.Opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if vol, err = sd.VolumeResize(ctx, vol.ID, opts); err != nil {
+		return nil, err
+	}
+
+	attVol, err := d.volumeInspectByIDOrName(
+		ctx, vol.ID, "",
+		types.VolAttReqWithDevMapOnlyVolsAttachedToInstance, opts.Opts)
+	if err != nil {
+		if isErrNotFound(err) {
+			return vol, nil
+		}
+		return nil, err
+	}
+
+	if len(attVol.Attachments) == 0 {
+		ctx.Debug("skipping file system resize; volume not attached")
+		return vol, nil
+	}
+
+	inst, err := client.Storage().InstanceInspect(ctx, utils.NewStore())
+	if err != nil {
+		return nil, goof.New("problem getting instance ID")
+	}
+	var ma *types.VolumeAttachment
+	for _, att := range attVol.Attachments {
+		if att.InstanceID.ID == inst.InstanceID.ID {
+			ma = att
+			break
+		}
+	}
+
+	if ma == nil || ma.DeviceName == "" {
+		ctx.Debug("skipping file system resize; no local attachment")
+		return vol, nil
+	}
+
+	mounts, err := client.OS().Mounts(
+		ctx, ma.DeviceName, "", opts.Opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mounts) == 0 {
+		ctx.Debug("skipping file system resize; volume not mounted")
+		return vol, nil
+	}
+
+	od, ok := client.OS().(types.OSDriverWithResizeFS)
+	if !ok {
+		return nil, goof.New("os driver cannot resize file systems")
+	}
+
+	if err := od.ResizeFS(
+		ctx,
+		ma.DeviceName,
+		&types.DeviceResizeOpts{
+			FsType:     mounts[0].FSType,
+			MountPoint: mounts[0].MountPoint,
+			Opts:       opts.Opts,
+		}); err != nil {
+		return nil, goof.WithError("problem resizing file system", err)
+	}
+
+	ctx.WithFields(log.Fields{
+		"vol": vol}).Info("resized volume and file system")
+
+	return vol, nil
+}
+
 // Path will return the mounted path of the volumeName or volumeID.
 func (d *driver) Path(
 	ctx types.Context,
@@ -16,6 +16,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"syscall"
 
 	log "github.com/Sirupsen/logrus"
 
@@ -42,15 +43,20 @@ func init() {
 	r := gofigCore.NewRegistration("Linux")
 	r.Key(gofig.Int, "", 0700, "", "linux.volume.filemode")
 	r.Key(gofig.String, "", "/data", "", "linux.volume.rootpath")
+	for _, fsType := range []string{
+		"btrfs", "ext3", "ext4", "swap", "vfat", "xfs"} {
+		r.Key(gofig.String, "", "", "", mkfsOptionsKey(fsType))
+	}
 	gofigCore.Register(r)
 }
 
 type driver struct {
 	config gofig.Config
+	run    CommandRunner
 }
 
 func newDriver() types.OSDriver {
-	return &driver{}
+	return &driver{run: runCommand}
 }
 
 func (d *driver) Init(ctx types.Context, config gofig.Config) error {
@@ -141,7 +147,8 @@ func (d *driver) Mount(
 	}
 
 	if d.isNfsDevice(deviceName) {
-		if err := d.nfsMount(deviceName, mountPoint); err != nil {
+		if err := d.nfsMount(
+			deviceName, mountPoint, mountOptions("nfs", opts)); err != nil {
 			return err
 		}
 		os.MkdirAll(d.volumeMountPath(mountPoint), d.fileModeMountPath())
@@ -158,11 +165,7 @@ func (d *driver) Mount(
 		}
 	}
 
-	options := fmt.Sprintf("%s,%s", opts.MountOptions, opts.MountLabel)
-	if fsType == "xfs" {
-		options = fmt.Sprintf("%s,nouuid", opts.MountLabel)
-	}
-
+	options := mountOptions(fsType, opts)
 	if err := mount(deviceName, mountPoint, fsType, options); err != nil {
 		return goof.WithFieldsE(goof.Fields{
 			"deviceName": deviceName,
@@ -237,26 +240,88 @@ func (d *driver) Format(
 		"driverName":  driverName}).Info("probe information")
 
 	if opts.OverwriteFS || !fsDetected {
-		switch opts.NewFSType {
-		case "ext4":
-			if err := exec.Command(
-				"mkfs.ext4", "-F", deviceName).Run(); err != nil {
-				return goof.WithFieldE(
-					"deviceName", deviceName,
-					"error creating filesystem",
-					err)
-			}
-		case "xfs":
-			if err := exec.Command(
-				"mkfs.xfs", "-f", deviceName).Run(); err != nil {
-				return goof.WithFieldE(
-					"deviceName", deviceName,
-					"error creating filesystem",
-					err)
-			}
-		default:
+		f, ok := getFormatter(opts.NewFSType)
+		if !ok {
 			return errUnsupportedFileSystem
 		}
+
+		fOpts := *opts
+		if fOpts.MkfsOptions == "" {
+			fOpts.MkfsOptions = d.mkfsOptions(opts.NewFSType)
+		}
+
+		return f.Format(ctx, d.run, deviceName, &fOpts)
+	}
+
+	return nil
+}
+
+func (d *driver) ResizeFS(
+	ctx types.Context,
+	deviceName string,
+	opts *types.DeviceResizeOpts) error {
+
+	fsType := opts.FsType
+	if fsType == "" {
+		var err error
+		if fsType, err = probeFsType(deviceName); err != nil {
+			return err
+		}
+	}
+
+	ctx.WithFields(log.Fields{
+		"fsType":     fsType,
+		"deviceName": deviceName,
+		"mountPoint": opts.MountPoint,
+		"driverName": driverName}).Info("resizing file system")
+
+	var cmd *exec.Cmd
+	switch fsType {
+	case "ext2", "ext3", "ext4":
+		cmd = exec.Command("resize2fs", deviceName)
+	case "xfs":
+		// xfs can only be grown while mounted
+		if opts.MountPoint == "" {
+			return goof.WithField(
+				"deviceName", deviceName, "xfs must be mounted to resize")
+		}
+		cmd = exec.Command("xfs_growfs", opts.MountPoint)
+	default:
+		return errUnsupportedFileSystem
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return goof.WithFieldsE(goof.Fields{
+			"deviceName": deviceName,
+			"output":     string(out),
+		}, "error resizing filesystem", err)
+	}
+
+	return nil
+}
+
+// Flush writes the host's dirty pages to their devices and then flushes the
+// device's buffer cache.
+func (d *driver) Flush(
+	ctx types.Context,
+	deviceName string,
+	opts types.Store) error {
+
+	if d.isNfsDevice(deviceName) {
+		return nil
+	}
+
+	ctx.WithFields(log.Fields{
+		"deviceName": deviceName,
+		"driverName": driverName}).Info("flushing device")
+
+	syscall.Sync()
+
+	if out, err := d.run("blockdev", "--flushbufs", deviceName); err != nil {
+		return goof.WithFieldsE(goof.Fields{
+			"deviceName": deviceName,
+			"output":     string(out),
+		}, "error flushing device", err)
 	}
 
 	return nil
@@ -266,9 +331,12 @@ func (d *driver) isNfsDevice(device string) bool {
 	return strings.Contains(device, ":")
 }
 
-func (d *driver) nfsMount(device, target string) error {
-	command := exec.Command("mount", device, target)
-	output, err := command.CombinedOutput()
+func (d *driver) nfsMount(device, target, options string) error {
+	args := []string{device, target}
+	if options != "" {
+		args = append([]string{"-o", options}, args...)
+	}
+	output, err := d.run("mount", args...)
 	if err != nil {
 		return goof.WithError(fmt.Sprintf("failed mounting: %s", output), err)
 	}
@@ -276,6 +344,34 @@ func (d *driver) nfsMount(device, target string) error {
 	return nil
 }
 
+// mountOptions returns the options with which a device is mounted.
+func mountOptions(fsType string, opts *types.DeviceMountOpts) string {
+	var options []string
+	if opts.MountOptions != "" {
+		options = append(options, opts.MountOptions)
+	}
+	if opts.ReadOnly {
+		options = append(options, "ro")
+	}
+	if fsType == "xfs" {
+		options = append(options, "nouuid")
+	}
+	if opts.MountLabel != "" {
+		options = append(options, formatMountLabel(opts.MountLabel))
+	}
+	return strings.Join(options, ",")
+}
+
+// formatMountLabel returns the mount option that applies an SELinux label to
+// a file system. A label that is already a mount option, such as
+// "fscontext=...", is returned as is.
+func formatMountLabel(label string) string {
+	if strings.Contains(label, "context=") {
+		return label
+	}
+	return fmt.Sprintf(`context="%s"`, label)
+}
+
 func (d *driver) fileModeMountPath() (fileMode os.FileMode) {
 	return os.FileMode(d.volumeFileMode())
 }
@@ -294,6 +390,11 @@ func probeFsType(device string) (string, error) {
 		{"btrfs", "_BHRfS_M", 0x10040},
 		{"ext4", "\123\357", 0x438},
 		{"xfs", "XFSB", 0},
+		{"vfat", "FAT32   ", 0x52},
+		{"vfat", "FAT16   ", 0x36},
+		{"vfat", "FAT12   ", 0x36},
+		{"swap", "SWAPSPACE2", 0xff6},
+		{"swap", "SWAP-SPACE", 0xff6},
 	}
 
 	maxLen := uint64(0)
@@ -342,3 +443,11 @@ func (d *driver) volumeFileMode() int {
 func (d *driver) volumeRootPath() string {
 	return d.config.GetString("linux.volume.rootpath")
 }
+
+func (d *driver) mkfsOptions(fsType string) string {
+	return d.config.GetString(mkfsOptionsKey(fsType))
+}
+
+func mkfsOptionsKey(fsType string) string {
+	return fmt.Sprintf("linux.format.%s.options", strings.ToLower(fsType))
+}
@@ -0,0 +1,211 @@
+// +build linux
+
+package linux
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/akutz/goof"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// CommandRunner runs a command and returns its combined output.
+type CommandRunner func(name string, args ...string) ([]byte, error)
+
+func runCommand(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// Formatter creates a file system on a device.
+type Formatter interface {
+
+	// Format creates a file system on the device using the provided command
+	// runner.
+	Format(
+		ctx types.Context,
+		run CommandRunner,
+		deviceName string,
+		opts *types.DeviceFormatOpts) error
+}
+
+var (
+	formatters    = map[string]Formatter{}
+	formattersRWL = &sync.RWMutex{}
+)
+
+// RegisterFormatter registers the Formatter used to create file systems of
+// the provided type. A Formatter registered for a type that already has one
+// replaces it.
+func RegisterFormatter(fsType string, f Formatter) {
+	formattersRWL.Lock()
+	defer formattersRWL.Unlock()
+	formatters[strings.ToLower(fsType)] = f
+}
+
+func getFormatter(fsType string) (Formatter, bool) {
+	formattersRWL.RLock()
+	defer formattersRWL.RUnlock()
+	f, ok := formatters[strings.ToLower(fsType)]
+	return f, ok
+}
+
+func init() {
+	RegisterFormatter("ext3", &Mkfs{
+		Command: "mkfs.ext3",
+		Force:   []string{"-F"},
+		Label:   flagArgs("-L"),
+		UUID:    uuidFlagArgs("-U"),
+	})
+	RegisterFormatter("ext4", &Mkfs{
+		Command: "mkfs.ext4",
+		Force:   []string{"-F"},
+		Label:   flagArgs("-L"),
+		UUID:    uuidFlagArgs("-U"),
+	})
+	RegisterFormatter("xfs", &Mkfs{
+		Command: "mkfs.xfs",
+		Force:   []string{"-f"},
+		Label:   flagArgs("-L"),
+		UUID: func(uuid string) ([]string, error) {
+			return []string{"-m", fmt.Sprintf("uuid=%s", uuid)}, nil
+		},
+	})
+	RegisterFormatter("btrfs", &Mkfs{
+		Command: "mkfs.btrfs",
+		Force:   []string{"-f"},
+		Label:   flagArgs("-L"),
+		UUID:    uuidFlagArgs("-U"),
+	})
+	RegisterFormatter("vfat", &Mkfs{
+		Command: "mkfs.vfat",
+		Force:   []string{"-I"},
+		Label:   flagArgs("-n"),
+		UUID: func(uuid string) ([]string, error) {
+			id, err := vfatVolumeID(uuid)
+			if err != nil {
+				return nil, err
+			}
+			return []string{"-i", id}, nil
+		},
+	})
+	RegisterFormatter("swap", &Mkfs{
+		Command: "mkswap",
+		Force:   []string{"-f"},
+		Label:   flagArgs("-L"),
+		UUID:    uuidFlagArgs("-U"),
+	})
+}
+
+// Mkfs is a Formatter that creates a file system with a mkfs command.
+type Mkfs struct {
+
+	// Command is the name of the command.
+	Command string
+
+	// Force are the arguments that force the command to overwrite an
+	// existing file system.
+	Force []string
+
+	// Label returns the arguments that set the file system's label. A nil
+	// function means the file system does not support labels.
+	Label func(label string) []string
+
+	// UUID returns the arguments that set the file system's UUID. A nil
+	// function means the file system does not support UUIDs. An error is
+	// returned if the UUID is not valid for the file system.
+	UUID func(uuid string) ([]string, error)
+}
+
+// Args returns the command's arguments for the device and options.
+func (m *Mkfs) Args(
+	deviceName string, opts *types.DeviceFormatOpts) ([]string, error) {
+
+	args := append([]string{}, m.Force...)
+
+	if opts.Label != "" {
+		if m.Label == nil {
+			return nil, goof.WithField(
+				"command", m.Command, "file system labels not supported")
+		}
+		args = append(args, m.Label(opts.Label)...)
+	}
+
+	if opts.UUID != "" {
+		if m.UUID == nil {
+			return nil, goof.WithField(
+				"command", m.Command, "file system uuids not supported")
+		}
+		uuidArgs, err := m.UUID(opts.UUID)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, uuidArgs...)
+	}
+
+	args = append(args, strings.Fields(opts.MkfsOptions)...)
+	return append(args, deviceName), nil
+}
+
+// Format creates a file system on the device.
+func (m *Mkfs) Format(
+	ctx types.Context,
+	run CommandRunner,
+	deviceName string,
+	opts *types.DeviceFormatOpts) error {
+
+	args, err := m.Args(deviceName, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx.WithField("args", args).Debug(m.Command)
+
+	if out, err := run(m.Command, args...); err != nil {
+		return goof.WithFieldsE(goof.Fields{
+			"deviceName": deviceName,
+			"output":     string(out),
+		}, "error creating filesystem", err)
+	}
+
+	return nil
+}
+
+// flagArgs returns a function that returns the flag followed by its value.
+func flagArgs(flag string) func(string) []string {
+	return func(v string) []string {
+		return []string{flag, v}
+	}
+}
+
+// uuidFlagArgs returns a UUID function that returns the flag followed by the
+// UUID.
+func uuidFlagArgs(flag string) func(string) ([]string, error) {
+	return func(v string) ([]string, error) {
+		return []string{flag, v}, nil
+	}
+}
+
+// vfatVolumeID returns the 8 hex digits that mkfs.vfat accepts as a volume
+// ID. A FAT file system has a 32-bit volume ID rather than a UUID, so the ID
+// may be provided as XXXX-XXXX or XXXXXXXX, or the first 8 hex digits of a
+// full UUID are used.
+func vfatVolumeID(uuid string) (string, error) {
+	var id string
+	switch {
+	case len(uuid) == 36 && uuid[8] == '-':
+		id = uuid[:8]
+	case len(uuid) == 9 && uuid[4] == '-':
+		id = uuid[:4] + uuid[5:]
+	default:
+		id = uuid
+	}
+	if _, err := hex.DecodeString(id); err != nil || len(id) != 8 {
+		return "", goof.WithField("uuid", uuid, "invalid vfat volume id")
+	}
+	return id, nil
+}
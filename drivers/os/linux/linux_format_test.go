@@ -0,0 +1,200 @@
+// +build linux
+
+package linux
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	gofigCore "github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+// fakeRunner records the commands it runs instead of running them.
+type fakeRunner struct {
+	cmds [][]string
+}
+
+func (r *fakeRunner) run(name string, args ...string) ([]byte, error) {
+	r.cmds = append(r.cmds, append([]string{name}, args...))
+	return nil, nil
+}
+
+func TestMkfsArgs(t *testing.T) {
+	tests := []struct {
+		fsType string
+		opts   *types.DeviceFormatOpts
+		args   []string
+	}{
+		{
+			"ext4",
+			&types.DeviceFormatOpts{},
+			[]string{"-F", "/dev/xvdb"},
+		},
+		{
+			"ext4",
+			&types.DeviceFormatOpts{
+				Label:       "data",
+				UUID:        "0c7f2f5c-4a8b-4d3b-9c5e-0d6a0d1f6c1a",
+				MkfsOptions: "-m 0  -E lazy_itable_init=0",
+			},
+			[]string{
+				"-F", "-L", "data",
+				"-U", "0c7f2f5c-4a8b-4d3b-9c5e-0d6a0d1f6c1a",
+				"-m", "0", "-E", "lazy_itable_init=0",
+				"/dev/xvdb",
+			},
+		},
+		{
+			"xfs",
+			&types.DeviceFormatOpts{UUID: "0c7f2f5c"},
+			[]string{"-f", "-m", "uuid=0c7f2f5c", "/dev/xvdb"},
+		},
+		{
+			"vfat",
+			&types.DeviceFormatOpts{Label: "BOOT", UUID: "1234-ABCD"},
+			[]string{"-I", "-n", "BOOT", "-i", "1234ABCD", "/dev/xvdb"},
+		},
+		{
+			"vfat",
+			&types.DeviceFormatOpts{
+				UUID: "0c7f2f5c-4a8b-4d3b-9c5e-0d6a0d1f6c1a"},
+			[]string{"-I", "-i", "0c7f2f5c", "/dev/xvdb"},
+		},
+		{
+			"swap",
+			&types.DeviceFormatOpts{Label: "swap0"},
+			[]string{"-f", "-L", "swap0", "/dev/xvdb"},
+		},
+	}
+
+	for _, tt := range tests {
+		f, ok := getFormatter(tt.fsType)
+		if !assert.True(t, ok, tt.fsType) {
+			continue
+		}
+		args, err := f.(*Mkfs).Args("/dev/xvdb", tt.opts)
+		assert.NoError(t, err, tt.fsType)
+		assert.Equal(t, tt.args, args, tt.fsType)
+	}
+
+	m := &Mkfs{Command: "mkfs.minix"}
+	_, err := m.Args("/dev/xvdb", &types.DeviceFormatOpts{Label: "data"})
+	assert.Error(t, err)
+	_, err = m.Args("/dev/xvdb", &types.DeviceFormatOpts{UUID: "0c7f2f5c"})
+	assert.Error(t, err)
+
+	vfat, _ := getFormatter("vfat")
+	for _, uuid := range []string{"1234-ABC", "1234ABCDE", "XYZW-1234"} {
+		_, err = vfat.(*Mkfs).Args(
+			"/dev/xvdb", &types.DeviceFormatOpts{UUID: uuid})
+		assert.Error(t, err, uuid)
+	}
+}
+
+// newDevice returns the path to a file that is large enough to be probed
+// for a file system. The file begins with the provided data.
+func newDevice(t *testing.T, data []byte, offset int64) string {
+	f, err := ioutil.TempFile("", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(0x20000); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt(data, offset); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestFormat(t *testing.T) {
+	config := gofigCore.New()
+	config.Set(mkfsOptionsKey("btrfs"), "-O quota")
+
+	r := &fakeRunner{}
+	d := &driver{config: config, run: r.run}
+	ctx := context.Background()
+
+	dev := newDevice(t, nil, 0)
+	defer os.RemoveAll(dev)
+
+	// the configured options are used unless options are provided
+	assert.NoError(t, d.Format(ctx, dev, &types.DeviceFormatOpts{
+		NewFSType: "btrfs",
+		Label:     "data",
+	}))
+	assert.NoError(t, d.Format(ctx, dev, &types.DeviceFormatOpts{
+		NewFSType:   "btrfs",
+		MkfsOptions: "-M",
+	}))
+	assert.Equal(t, [][]string{
+		{"mkfs.btrfs", "-f", "-L", "data", "-O", "quota", dev},
+		{"mkfs.btrfs", "-f", "-M", dev},
+	}, r.cmds)
+
+	assert.Equal(t, errUnsupportedFileSystem, d.Format(
+		ctx, dev, &types.DeviceFormatOpts{NewFSType: "ntfs"}))
+}
+
+func TestFormatDetected(t *testing.T) {
+	r := &fakeRunner{}
+	d := &driver{config: gofigCore.New(), run: r.run}
+	ctx := context.Background()
+
+	dev := newDevice(t, []byte("SWAPSPACE2"), 0xff6)
+	defer os.RemoveAll(dev)
+
+	fsType, err := probeFsType(dev)
+	assert.NoError(t, err)
+	assert.Equal(t, "swap", fsType)
+
+	// a device with a file system is only formatted if it is overwritten
+	assert.NoError(t, d.Format(ctx, dev, &types.DeviceFormatOpts{
+		NewFSType: "ext4",
+	}))
+	assert.Empty(t, r.cmds)
+
+	assert.NoError(t, d.Format(ctx, dev, &types.DeviceFormatOpts{
+		NewFSType:   "ext4",
+		OverwriteFS: true,
+	}))
+	assert.Equal(t, [][]string{{"mkfs.ext4", "-F", dev}}, r.cmds)
+}
+
+type testFormatter struct {
+	devices []string
+}
+
+func (f *testFormatter) Format(
+	ctx types.Context,
+	run CommandRunner,
+	deviceName string,
+	opts *types.DeviceFormatOpts) error {
+
+	f.devices = append(f.devices, deviceName)
+	return nil
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	f := &testFormatter{}
+	RegisterFormatter("ZFS", f)
+	defer func() {
+		formattersRWL.Lock()
+		delete(formatters, "zfs")
+		formattersRWL.Unlock()
+	}()
+
+	d := &driver{config: gofigCore.New(), run: (&fakeRunner{}).run}
+	dev := newDevice(t, nil, 0)
+	defer os.RemoveAll(dev)
+
+	assert.NoError(t, d.Format(
+		context.Background(), dev, &types.DeviceFormatOpts{NewFSType: "zfs"}))
+	assert.Equal(t, []string{dev}, f.devices)
+}
@@ -0,0 +1,54 @@
+// +build linux
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+func TestMountOptions(t *testing.T) {
+	assert.Equal(t, "", mountOptions("ext4", &types.DeviceMountOpts{}))
+	assert.Equal(t, "nouuid", mountOptions("xfs", &types.DeviceMountOpts{}))
+	assert.Equal(t, "noatime,nodev,ro,nouuid", mountOptions(
+		"xfs", &types.DeviceMountOpts{
+			MountOptions: "noatime,nodev",
+			ReadOnly:     true,
+		}))
+	assert.Equal(t, `ro,context="system_u:object_r:svirt_sandbox_file_t:s0:c1,c2"`,
+		mountOptions("ext4", &types.DeviceMountOpts{
+			MountLabel: "system_u:object_r:svirt_sandbox_file_t:s0:c1,c2",
+			ReadOnly:   true,
+		}))
+	assert.Equal(t, `fscontext="system_u:object_r:nfs_t:s0"`,
+		mountOptions("nfs", &types.DeviceMountOpts{
+			MountLabel: `fscontext="system_u:object_r:nfs_t:s0"`,
+		}))
+}
+
+func TestParseMountOptions(t *testing.T) {
+	flag, data := parseOptions(mountOptions("ext4", &types.DeviceMountOpts{
+		MountOptions: "noatime,discard",
+		MountLabel:   "system_u:object_r:svirt_sandbox_file_t:s0:c1,c2",
+		ReadOnly:     true,
+	}))
+	assert.Equal(t, NOATIME|RDONLY, flag)
+	assert.Equal(t,
+		`discard,context="system_u:object_r:svirt_sandbox_file_t:s0:c1,c2"`,
+		data)
+}
+
+func TestFlush(t *testing.T) {
+	r := &fakeRunner{}
+	d := &driver{run: r.run}
+	ctx := context.Background()
+
+	assert.NoError(t, d.Flush(ctx, "/dev/xvdf", nil))
+	assert.NoError(t, d.Flush(ctx, "nfs.example.com:/data", nil))
+	assert.Equal(t,
+		[][]string{{"blockdev", "--flushbufs", "/dev/xvdf"}}, r.cmds)
+}
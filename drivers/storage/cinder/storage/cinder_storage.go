@@ -276,6 +276,7 @@ func translateVolumeV1(
 		IOPS:             0,
 		Size:             int64(volume.Size),
 		Attachments:      attachments,
+		Labels:           volume.Metadata,
 	}
 }
 
@@ -305,6 +306,7 @@ func translateVolume(
 		IOPS:             0,
 		Size:             int64(volume.Size),
 		Attachments:      attachments,
+		Labels:           volume.Metadata,
 	}
 }
 
@@ -357,6 +359,7 @@ func translateSnapshot(snapshot *snapshots.Snapshot) *types.Snapshot {
 		StartTime:   time.Time(snapshot.CreatedAt).Unix(),
 		Description: snapshot.Description,
 		Status:      snapshot.Status,
+		Labels:      snapshot.Metadata,
 	}
 }
 
@@ -376,6 +379,13 @@ func (d *driver) VolumeSnapshot(
 		Force:    true,
 	}
 
+	if labels := opts.GetStringMap("labels"); len(labels) > 0 {
+		createOpts.Metadata = map[string]interface{}{}
+		for k, v := range labels {
+			createOpts.Metadata[k] = v
+		}
+	}
+
 	snapshot, err := snapshots.Create(d.clientBlockStorage, createOpts).Extract()
 	if err != nil {
 		return nil,
@@ -453,6 +463,7 @@ func (d *driver) VolumeCopy(
 	volumeCreateOpts := &types.VolumeCreateOpts{
 		Type:             &volume.Type,
 		AvailabilityZone: &volume.AvailabilityZone,
+		Labels:           volume.Labels,
 	}
 
 	return d.createVolume(ctx, volumeName, volumeID, "", volumeCreateOpts)
@@ -469,6 +480,7 @@ func (d *driver) createVolume(
 		Name:          volumeName,
 		SnapshotID:    snapshotID,
 		SourceReplica: volumeSourceID,
+		Metadata:      opts.Labels,
 	}
 
 	fields := eff(map[string]interface{}{
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -21,6 +22,9 @@ import (
 
 const (
 	minSizeGiB = 1
+
+	// resourceTypeVolume is the resource type of volume snapshots
+	resourceTypeVolume = "volume"
 )
 
 type driver struct {
@@ -102,6 +106,17 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+// Capabilities returns the capabilities the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(
+		types.DriverCapabilitySnapshots,
+		types.DriverCapabilityCopy,
+		types.DriverCapabilityCreateFromSnapshot,
+		types.DriverCapabilityAvailabilityZones,
+	), nil
+}
+
 // DigitalOcean volumes are are found using device-by-id, ex:
 // /dev/disk/by-id/scsi-0DO_Volume_volume-nyc1-01 See
 // https://www.digitalocean.com/community/tutorials/how-to-use-block-storage-on-digitalocean#preparing-volumes-for-use-in-linux
@@ -235,6 +250,74 @@ func (d *driver) VolumeCreate(
 		SizeGigaBytes: *opts.Size,
 	}
 
+	return d.createVolume(ctx, volumeReq, fields)
+}
+
+func (d *driver) VolumeCreateFromSnapshot(
+	ctx types.Context, snapshotID string, volumeName string,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
+	snapshot, err := d.getSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a volume can only be restored in the region of its snapshot
+	if opts.AvailabilityZone != nil && *opts.AvailabilityZone != "" &&
+		!snapshotInRegion(snapshot, *opts.AvailabilityZone) {
+		return nil, goof.WithFields(goof.Fields{
+			"snapshotID": snapshotID,
+			"region":     *opts.AvailabilityZone,
+		}, "snapshot not available in region")
+	}
+
+	return d.volumeCreateFromSnapshot(ctx, snapshot, volumeName, opts.Size)
+}
+
+func (d *driver) volumeCreateFromSnapshot(
+	ctx types.Context,
+	snapshot *godo.Snapshot,
+	name string,
+	size *int64) (*types.Volume, error) {
+
+	name = d.convUnderscores(name)
+	fields := map[string]interface{}{
+		"volumeName": name,
+		"snapshotID": snapshot.ID,
+	}
+
+	if len(snapshot.Regions) == 0 {
+		return nil, goof.WithFields(fields, "snapshot has no region")
+	}
+	fields["region"] = snapshot.Regions[0]
+
+	minSize := int64(snapshot.MinDiskSize)
+	if size == nil {
+		size = &minSize
+	}
+	fields["size"] = *size
+
+	if *size < minSize {
+		fields["minSize"] = minSize
+		return nil, goof.WithFields(fields,
+			"volume size smaller than snapshot")
+	}
+
+	volumeReq := &godo.VolumeCreateRequest{
+		Region:        snapshot.Regions[0],
+		Name:          name,
+		SizeGigaBytes: *size,
+		SnapshotID:    snapshot.ID,
+	}
+
+	return d.createVolume(ctx, volumeReq, fields)
+}
+
+func (d *driver) createVolume(
+	ctx types.Context,
+	volumeReq *godo.VolumeCreateRequest,
+	fields map[string]interface{}) (*types.Volume, error) {
+
 	volume, _, err := d.client.Storage.CreateVolume(ctx, volumeReq)
 	if err != nil {
 		ctx.WithFields(fields).WithError(err).Error(
@@ -249,22 +332,71 @@ func (d *driver) VolumeCreate(
 	)
 }
 
-func (d *driver) VolumeCreateFromSnapshot(
-	ctx types.Context, snapshotID string, volumeName string,
-	opts *types.VolumeCreateOpts) (*types.Volume, error) {
-	return nil, types.ErrNotImplemented
-}
-
+// VolumeCopy copies a volume by taking a temporary snapshot of the volume
+// and restoring the snapshot to a new volume. The temporary snapshot is
+// removed once the new volume is created.
 func (d *driver) VolumeCopy(
 	ctx types.Context, volumeID string, volumeName string,
 	opts types.Store) (*types.Volume, error) {
-	return nil, types.ErrNotImplemented
+
+	volume, _, err := d.client.Storage.GetVolume(ctx, volumeID)
+	if err != nil {
+		return nil, goof.WithError("error retrieving volume", err)
+	}
+
+	snapshotName := fmt.Sprintf(
+		"%s-copy-%d", volume.Name, time.Now().UnixNano())
+	snapshot, err := d.createSnapshot(ctx, volumeID, snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, err := d.client.Storage.DeleteSnapshot(
+			ctx, snapshot.ID); err != nil {
+			ctx.WithField("snapshotID", snapshot.ID).WithError(err).Warn(
+				"error removing temporary snapshot")
+		}
+	}()
+
+	return d.volumeCreateFromSnapshot(
+		ctx, snapshot, volumeName, &volume.SizeGigaBytes)
 }
 
 func (d *driver) VolumeSnapshot(
 	ctx types.Context, volumeID string, snapshotName string,
 	opts types.Store) (*types.Snapshot, error) {
-	return nil, types.ErrNotImplemented
+
+	snapshot, err := d.createSnapshot(
+		ctx, volumeID, d.convUnderscores(snapshotName))
+	if err != nil {
+		return nil, err
+	}
+	return toTypesSnapshot(snapshot), nil
+}
+
+func (d *driver) createSnapshot(
+	ctx types.Context,
+	volumeID string,
+	snapshotName string) (*godo.Snapshot, error) {
+
+	fields := map[string]interface{}{
+		"volumeID":     volumeID,
+		"snapshotName": snapshotName,
+	}
+
+	snapshot, _, err := d.client.Storage.CreateSnapshot(ctx,
+		&godo.SnapshotCreateRequest{
+			VolumeID: volumeID,
+			Name:     snapshotName,
+		},
+	)
+	if err != nil {
+		ctx.WithFields(fields).WithError(err).Error(
+			"error returned from create snapshot")
+		return nil, err
+	}
+
+	return d.waitForSnapshot(ctx, snapshot.ID)
 }
 
 func (d *driver) VolumeRemove(
@@ -397,13 +529,46 @@ func (d *driver) VolumeDetach(
 
 func (d *driver) Snapshots(
 	ctx types.Context, opts types.Store) ([]*types.Snapshot, error) {
-	return nil, types.ErrNotImplemented
+
+	var (
+		snapshots []*types.Snapshot
+		listOpts  = &godo.ListOptions{}
+	)
+
+	for {
+		doSnapshots, resp, err := d.client.Snapshots.ListVolume(
+			ctx, listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range doSnapshots {
+			snapshots = append(snapshots, toTypesSnapshot(&doSnapshots[i]))
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		listOpts.Page = page + 1
+	}
+
+	return snapshots, nil
 }
 
 func (d *driver) SnapshotInspect(
 	ctx types.Context, snapshotID string,
 	opts types.Store) (*types.Snapshot, error) {
-	return nil, types.ErrNotImplemented
+
+	snapshot, err := d.getSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return toTypesSnapshot(snapshot), nil
 }
 
 func (d *driver) SnapshotCopy(
@@ -414,7 +579,32 @@ func (d *driver) SnapshotCopy(
 
 func (d *driver) SnapshotRemove(
 	ctx types.Context, snapshotID string, opts types.Store) error {
-	return types.ErrNotImplemented
+
+	// make sure the snapshot exists and belongs to a volume, since
+	// droplet snapshots share the same API
+	if _, err := d.getSnapshot(ctx, snapshotID); err != nil {
+		return err
+	}
+
+	_, err := d.client.Storage.DeleteSnapshot(ctx, snapshotID)
+	return err
+}
+
+func (d *driver) getSnapshot(
+	ctx types.Context,
+	snapshotID string) (*godo.Snapshot, error) {
+
+	snapshot, _, err := d.client.Storage.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, apiUtils.NewNotFoundError(snapshotID)
+		}
+		return nil, err
+	}
+	if snapshot.ResourceType != resourceTypeVolume {
+		return nil, apiUtils.NewNotFoundError(snapshotID)
+	}
+	return snapshot, nil
 }
 
 func mustInstanceIDID(ctx types.Context) *string {
@@ -481,24 +671,57 @@ func (d *driver) toTypesVolume(
 	return vol, nil
 }
 
-func (d *driver) waitForAction(
+func toTypesSnapshot(snapshot *godo.Snapshot) *types.Snapshot {
+	s := &types.Snapshot{
+		Name:       snapshot.Name,
+		ID:         snapshot.ID,
+		VolumeID:   snapshot.ResourceID,
+		VolumeSize: int64(snapshot.MinDiskSize),
+	}
+	t, err := time.Parse(time.RFC3339, snapshot.Created)
+	if err == nil {
+		s.StartTime = t.Unix()
+	}
+	return s
+}
+
+func snapshotInRegion(snapshot *godo.Snapshot, region string) bool {
+	for _, r := range snapshot.Regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotFound(err error) bool {
+	if errResp, ok := err.(*godo.ErrorResponse); ok {
+		return errResp.Response != nil &&
+			errResp.Response.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// waitFor polls the provided function with an exponential backoff until
+// the function reports it is done, the maximum number of status attempts
+// is exhausted, or the status timeout occurs. The second return value is
+// false if the status timeout occurred.
+func (d *driver) waitFor(
 	ctx types.Context,
-	volumeID string,
-	action *godo.Action) error {
+	done func() (string, bool, error)) (bool, error) {
 
 	f := func() (interface{}, error) {
 		duration := d.statusDelay
 		for i := 1; i <= d.maxAttempts; i++ {
-			action, _, err := d.client.StorageActions.Get(
-				ctx, volumeID, action.ID)
+			status, ok, err := done()
 			if err != nil {
 				return nil, err
 			}
-			if action.Status == godo.ActionCompleted {
+			if ok {
 				return nil, nil
 			}
-			ctx.WithField("status", action.Status).Debug(
-				"still waiting for action",
+			ctx.WithField("status", status).Debug(
+				"still waiting for status",
 			)
 			time.Sleep(time.Duration(duration) * time.Nanosecond)
 			duration = int64(2) * duration
@@ -508,6 +731,22 @@ func (d *driver) waitForAction(
 	}
 
 	_, ok, err := apiUtils.WaitFor(f, d.statusTimeout)
+	return ok, err
+}
+
+func (d *driver) waitForAction(
+	ctx types.Context,
+	volumeID string,
+	action *godo.Action) error {
+
+	ok, err := d.waitFor(ctx, func() (string, bool, error) {
+		action, _, err := d.client.StorageActions.Get(
+			ctx, volumeID, action.ID)
+		if err != nil {
+			return "", false, err
+		}
+		return action.Status, action.Status == godo.ActionCompleted, nil
+	})
 	if !ok {
 		return goof.WithFields(goof.Fields{
 			"volumeID":      volumeID,
@@ -521,6 +760,37 @@ func (d *driver) waitForAction(
 	return nil
 }
 
+// waitForSnapshot waits until a newly created snapshot can be retrieved
+// and returns it.
+func (d *driver) waitForSnapshot(
+	ctx types.Context,
+	snapshotID string) (*godo.Snapshot, error) {
+
+	var snapshot *godo.Snapshot
+	ok, err := d.waitFor(ctx, func() (string, bool, error) {
+		s, _, err := d.client.Storage.GetSnapshot(ctx, snapshotID)
+		if err != nil {
+			if isNotFound(err) {
+				return "pending", false, nil
+			}
+			return "", false, err
+		}
+		snapshot = s
+		return "available", true, nil
+	})
+	if !ok {
+		return nil, goof.WithFields(goof.Fields{
+			"snapshotID":    snapshotID,
+			"statusTimeout": d.statusTimeout},
+			"Timeout occured waiting for snapshot")
+	}
+	if err != nil {
+		return nil, goof.WithFieldE("snapshotID", snapshotID,
+			"Error while waiting for snapshot", err)
+	}
+	return snapshot, nil
+}
+
 func (d *driver) mustRegion(ctx types.Context) *string {
 	if iid, ok := context.InstanceID(ctx); ok {
 		if v, ok := iid.Fields[do.InstanceIDFieldRegion]; ok && v != "" {
@@ -0,0 +1,456 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/tests/drivertest"
+	"github.com/codedellemc/libstorage/api/types"
+	do "github.com/codedellemc/libstorage/drivers/storage/dobs"
+)
+
+const (
+	testRegion    = "nyc1"
+	testDropletID = "1000"
+
+	// fakeSnapshotPageSize is the number of snapshots the fake DO API
+	// returns per page so the tests exercise paging.
+	fakeSnapshotPageSize = 2
+
+	// fakeSnapshotPendingGets is the number of times a new snapshot is not
+	// found by the fake DO API so the tests exercise the status polling.
+	fakeSnapshotPendingGets = 1
+)
+
+// fakeDO is an in-memory stand-in for the DigitalOcean API that handles
+// the requests the driver makes for volumes and snapshots.
+type fakeDO struct {
+	sync.Mutex
+	url       string
+	nextID    int
+	volumes   map[string]*godo.Volume
+	snapshots map[string]*godo.Snapshot
+	pending   map[string]int
+	sources   map[string]string
+}
+
+func newFakeDO() *fakeDO {
+	return &fakeDO{
+		volumes:   map[string]*godo.Volume{},
+		snapshots: map[string]*godo.Snapshot{},
+		pending:   map[string]int{},
+		sources:   map[string]string{},
+	}
+}
+
+func (f *fakeDO) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+func (f *fakeDO) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v2" {
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+		return
+	}
+
+	switch parts[1] {
+	case "volumes":
+		f.serveVolumes(w, req, parts[2:])
+	case "snapshots":
+		f.serveSnapshots(w, req, parts[2:])
+	default:
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+	}
+}
+
+func (f *fakeDO) serveVolumes(
+	w http.ResponseWriter, req *http.Request, parts []string) {
+
+	if len(parts) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			var vols []godo.Volume
+			for _, k := range f.sortedVolumeIDs() {
+				vols = append(vols, *f.volumes[k])
+			}
+			f.writeJSON(w, http.StatusOK, map[string]interface{}{
+				"volumes": vols,
+			})
+		case http.MethodPost:
+			f.createVolume(w, req)
+		}
+		return
+	}
+
+	vol, ok := f.volumes[parts[0]]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, parts[0])
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && req.Method == http.MethodGet:
+		f.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"volume": vol,
+		})
+	case len(parts) == 1 && req.Method == http.MethodDelete:
+		delete(f.volumes, vol.ID)
+		w.WriteHeader(http.StatusNoContent)
+	case parts[1] == "snapshots" && req.Method == http.MethodPost:
+		body := &godo.SnapshotCreateRequest{}
+		if !f.readJSON(w, req, body) {
+			return
+		}
+		s := &godo.Snapshot{
+			ID:           f.newID("snap"),
+			Name:         body.Name,
+			ResourceID:   vol.ID,
+			ResourceType: "volume",
+			Regions:      []string{vol.Region.Slug},
+			MinDiskSize:  int(vol.SizeGigaBytes),
+			Created:      time.Now().UTC().Format(time.RFC3339),
+		}
+		f.snapshots[s.ID] = s
+		f.pending[s.ID] = fakeSnapshotPendingGets
+		f.writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"snapshot": s,
+		})
+	default:
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+	}
+}
+
+func (f *fakeDO) createVolume(w http.ResponseWriter, req *http.Request) {
+	body := &godo.VolumeCreateRequest{}
+	if !f.readJSON(w, req, body) {
+		return
+	}
+	for _, v := range f.volumes {
+		if v.Name == body.Name && v.Region.Slug == body.Region {
+			f.writeError(w, http.StatusConflict, body.Name)
+			return
+		}
+	}
+	if body.SnapshotID != "" {
+		s, ok := f.snapshots[body.SnapshotID]
+		if !ok {
+			f.writeError(w, http.StatusNotFound, body.SnapshotID)
+			return
+		}
+		if s.Regions[0] != body.Region ||
+			int64(s.MinDiskSize) > body.SizeGigaBytes {
+			f.writeError(w, http.StatusUnprocessableEntity, body.SnapshotID)
+			return
+		}
+	}
+	v := &godo.Volume{
+		ID:            f.newID("vol"),
+		Name:          body.Name,
+		Region:        &godo.Region{Slug: body.Region},
+		SizeGigaBytes: body.SizeGigaBytes,
+		CreatedAt:     time.Now().UTC(),
+	}
+	f.volumes[v.ID] = v
+	f.sources[v.ID] = body.SnapshotID
+	f.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"volume": v,
+	})
+}
+
+func (f *fakeDO) serveSnapshots(
+	w http.ResponseWriter, req *http.Request, parts []string) {
+
+	if len(parts) == 0 {
+		f.listSnapshots(w, req)
+		return
+	}
+
+	s, ok := f.snapshots[parts[0]]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, parts[0])
+		return
+	}
+	if f.pending[s.ID] > 0 {
+		f.pending[s.ID]--
+		f.writeError(w, http.StatusNotFound, parts[0])
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		f.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"snapshot": s,
+		})
+	case http.MethodDelete:
+		delete(f.snapshots, s.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeDO) listSnapshots(w http.ResponseWriter, req *http.Request) {
+	resourceType := req.URL.Query().Get("resource_type")
+
+	var snaps []godo.Snapshot
+	for _, k := range f.sortedSnapshotIDs() {
+		s := f.snapshots[k]
+		if resourceType == "" || s.ResourceType == resourceType {
+			snaps = append(snaps, *s)
+		}
+	}
+
+	page := 1
+	if v := req.URL.Query().Get("page"); v != "" {
+		page, _ = strconv.Atoi(v)
+	}
+	pageURL := func(p int) string {
+		return fmt.Sprintf(
+			"%s/v2/snapshots?page=%d&resource_type=%s",
+			f.url, p, resourceType)
+	}
+
+	// like the DO API, only pages before the last link to the last page
+	pages := &godo.Pages{}
+	start := (page - 1) * fakeSnapshotPageSize
+	end := start + fakeSnapshotPageSize
+	if page > 1 {
+		pages.First = pageURL(1)
+		pages.Prev = pageURL(page - 1)
+	}
+	if end < len(snaps) {
+		pages.Next = pageURL(page + 1)
+		pages.Last = pageURL(
+			(len(snaps) + fakeSnapshotPageSize - 1) / fakeSnapshotPageSize)
+	} else {
+		end = len(snaps)
+	}
+	if start > end {
+		start = end
+	}
+
+	f.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"snapshots": snaps[start:end],
+		"links":     &godo.Links{Pages: pages},
+	})
+}
+
+func (f *fakeDO) sortedVolumeIDs() []string {
+	var ids []string
+	for k := range f.volumes {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (f *fakeDO) sortedSnapshotIDs() []string {
+	var ids []string
+	for k := range f.snapshots {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (f *fakeDO) readJSON(
+	w http.ResponseWriter, req *http.Request, v interface{}) bool {
+
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func (f *fakeDO) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (f *fakeDO) writeError(w http.ResponseWriter, code int, msg string) {
+	f.writeJSON(w, code, map[string]interface{}{
+		"id":      http.StatusText(code),
+		"message": msg,
+	})
+}
+
+func newTestDriver(t *testing.T) (*driver, types.Context, *fakeDO) {
+	fake := newFakeDO()
+	srv := httptest.NewServer(fake)
+	fake.url = srv.URL
+
+	config := gofigCore.New()
+	config.Set(do.ConfigToken, "token")
+	config.Set(do.ConfigRegion, testRegion)
+	config.Set(do.ConfigStatusMaxAttempts, 5)
+	config.Set(do.ConfigStatusInitDelay, "1ms")
+	config.Set(do.ConfigStatusTimeout, "10s")
+	config.Set(do.ConfigConvertUnderscores, true)
+
+	d := &driver{name: do.Name}
+	ctx := context.Background()
+	if err := d.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.client.BaseURL = baseURL
+
+	ctx = ctx.WithValue(context.InstanceIDKey, &types.InstanceID{
+		ID:     testDropletID,
+		Driver: do.Name,
+		Fields: map[string]string{
+			do.InstanceIDFieldRegion: testRegion,
+		},
+	})
+	ctx = ctx.WithValue(context.LocalDevicesKey, &types.LocalDevices{
+		Driver:    do.Name,
+		DeviceMap: map[string]string{},
+	})
+
+	return d, ctx, fake
+}
+
+func newTestSuiteDriver(t *testing.T) *drivertest.Driver {
+	d, ctx, _ := newTestDriver(t)
+	return &drivertest.Driver{
+		StorageDriver:   d,
+		Context:         ctx,
+		VolumeSize:      10,
+		MissingVolumeID: "vol-missing",
+	}
+}
+
+func TestDriverSuite(t *testing.T) {
+	drivertest.Run(t, newTestSuiteDriver)
+}
+
+func newTestVolume(
+	t *testing.T, d *driver, ctx types.Context, name string) *types.Volume {
+
+	size := int64(10)
+	v, err := d.VolumeCreate(ctx, name, &types.VolumeCreateOpts{
+		Size: &size,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	d, ctx, fake := newTestDriver(t)
+	v := newTestVolume(t, d, ctx, "v0")
+
+	// snapshot names follow the same naming rules as volumes
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s_0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "s-0", s.Name)
+
+	snap, err := d.SnapshotInspect(ctx, s.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "s-0", snap.Name)
+
+	// droplet snapshots are not volume snapshots
+	fake.snapshots["snap-droplet"] = &godo.Snapshot{
+		ID:           "snap-droplet",
+		ResourceType: "droplet",
+	}
+	_, err = d.SnapshotInspect(ctx, "snap-droplet", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+	err = d.SnapshotRemove(ctx, "snap-droplet", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+}
+
+func TestSnapshots(t *testing.T) {
+	d, ctx, fake := newTestDriver(t)
+	v := newTestVolume(t, d, ctx, "v0")
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		s, err := d.VolumeSnapshot(ctx, v.ID, fmt.Sprintf("s%d", i), nil)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		ids = append(ids, s.ID)
+	}
+	fake.snapshots["snap-droplet"] = &godo.Snapshot{
+		ID:           "snap-droplet",
+		ResourceType: "droplet",
+	}
+
+	// all pages of volume snapshots are listed
+	snaps, err := d.Snapshots(ctx, nil)
+	assert.NoError(t, err)
+	var snapIDs []string
+	for _, s := range snaps {
+		snapIDs = append(snapIDs, s.ID)
+	}
+	sort.Strings(ids)
+	sort.Strings(snapIDs)
+	assert.Equal(t, ids, snapIDs)
+}
+
+func TestVolumeCreateFromSnapshot(t *testing.T) {
+	d, ctx, fake := newTestDriver(t)
+	v := newTestVolume(t, d, ctx, "v0")
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	nv, err := d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v_1", &types.VolumeCreateOpts{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "v-1", nv.Name)
+		assert.Equal(t, testRegion, nv.AvailabilityZone)
+		assert.Equal(t, s.ID, fake.sources[nv.ID])
+	}
+
+	// the volume must be in the snapshot's region
+	region := "sfo2"
+	_, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v3", &types.VolumeCreateOpts{AvailabilityZone: &region})
+	assert.Error(t, err)
+}
+
+func TestVolumeCopy(t *testing.T) {
+	d, ctx, fake := newTestDriver(t)
+	v := newTestVolume(t, d, ctx, "v0")
+
+	nv, err := d.VolumeCopy(ctx, v.ID, "v_1", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "v-1", nv.Name)
+	assert.Equal(t, v.AvailabilityZone, nv.AvailabilityZone)
+	assert.NotEmpty(t, fake.sources[nv.ID])
+
+	// the temporary snapshot is removed
+	assert.Empty(t, fake.snapshots)
+}
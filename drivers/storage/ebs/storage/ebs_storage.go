@@ -37,6 +37,15 @@ const (
 	waitVolumeDetach = "detach"
 
 	minSizeGiB = 1
+
+	// minPageSize and maxPageSize are the bounds of the page size that
+	// DescribeVolumes accepts
+	minPageSize = 5
+	maxPageSize = 500
+
+	// maxSnapshotPageSize is the largest page size that DescribeSnapshots
+	// accepts
+	maxSnapshotPageSize = 1000
 )
 
 type driver struct {
@@ -120,6 +129,13 @@ func writeHkey(h hash.Hash, ps *string) {
 }
 
 func (d *driver) Login(ctx types.Context) (interface{}, error) {
+	return d.login(d.mustRegion(ctx)), nil
+}
+
+// login returns the EC2 service for the region. A configured endpoint, such
+// as that of an EC2-compatible stand-in, takes precedence over the region's
+// endpoint.
+func (d *driver) login(region *string) *awsec2.EC2 {
 	sessionsL.Lock()
 	defer sessionsL.Unlock()
 
@@ -128,14 +144,13 @@ func (d *driver) Login(ctx types.Context) (interface{}, error) {
 		ckey     string
 		hkey     = md5.New()
 		akey     = d.accessKey
-		region   = d.mustRegion(ctx)
 	)
 
-	if region != nil {
+	if d.endpoint != nil {
+		endpoint = d.endpoint
+	} else if region != nil {
 		szEndpint := fmt.Sprintf("ec2.%s.amazonaws.com", *region)
 		endpoint = &szEndpint
-	} else {
-		endpoint = d.endpoint
 	}
 
 	writeHkey(hkey, region)
@@ -146,7 +161,7 @@ func (d *driver) Login(ctx types.Context) (interface{}, error) {
 	// if the session is cached then return it
 	if svc, ok := sessions[ckey]; ok {
 		log.WithField(cacheKeyC, ckey).Debug("using cached ebs service")
-		return svc, nil
+		return svc
 	}
 
 	var (
@@ -200,7 +215,7 @@ func (d *driver) Login(ctx types.Context) (interface{}, error) {
 	sessions[ckey] = svc
 	log.WithFields(fields).Info("ebs service connetion created & cached")
 
-	return svc, nil
+	return svc
 }
 
 func mustSession(ctx types.Context) *awsec2.EC2 {
@@ -244,6 +259,20 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+// Capabilities returns the capabilities the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(
+		types.DriverCapabilitySnapshots,
+		types.DriverCapabilityCopy,
+		types.DriverCapabilitySnapshotCopy,
+		types.DriverCapabilityCreateFromSnapshot,
+		types.DriverCapabilityEncryption,
+		types.DriverCapabilityIOPS,
+		types.DriverCapabilityAvailabilityZones,
+	), nil
+}
+
 // InstanceInspect returns an instance.
 func (d *driver) InstanceInspect(
 	ctx types.Context,
@@ -278,6 +307,24 @@ func (d *driver) Volumes(
 	return vols, nil
 }
 
+// VolumesPage returns a page of volumes using the EC2 API's page tokens.
+func (d *driver) VolumesPage(
+	ctx types.Context,
+	opts *types.VolumesOpts,
+	page *types.PageOpts) ([]*types.Volume, string, error) {
+
+	ec2vols, next, err := d.getVolumePage(ctx, "", "", page)
+	if err != nil {
+		return nil, "", goof.WithError("error getting volume", err)
+	}
+	vols, convErr := d.toTypesVolume(ctx, ec2vols, opts.Attachments)
+	if convErr != nil {
+		return nil, "", goof.WithError(
+			"error converting to types.Volume", convErr)
+	}
+	return vols, next, nil
+}
+
 // VolumeInspect inspects a single volume.
 func (d *driver) VolumeInspect(
 	ctx types.Context,
@@ -344,204 +391,187 @@ func (d *driver) VolumeCreateFromSnapshot(
 	ctx types.Context,
 	snapshotID, volumeName string,
 	opts *types.VolumeCreateOpts) (*types.Volume, error) {
-	// TODO Snapshots are not implemented yet
-	return nil, types.ErrNotImplemented
-	/*
-		// Initialize for logging
-		fields := map[string]interface{}{
-			"driverName": d.Name(),
-			"snapshotID": snapshotID,
-			"volumeName": volumeName,
-			"opts":       opts,
-		}
 
-		log.WithFields(fields).Debug("creating volume from snapshot")
+	fields := map[string]interface{}{
+		"provider":   d.Name(),
+		"snapshotID": snapshotID,
+		"volumeName": volumeName,
+	}
 
-		// Check if volume with same name exists
-		ec2vols, err := d.getVolume(ctx, "", volumeName)
-		if err != nil {
-			return nil, goof.WithFieldsE(fields, "Error getting volume", err)
-		}
-		volumes, convErr := d.toTypesVolume(ctx, ec2vols, false)
-		if convErr != nil {
-			return nil, goof.WithFieldsE(fields,
-				"Error converting to types.Volume", convErr)
-		}
+	// Check if volume with same name exists
+	ec2vols, err := d.getVolume(ctx, "", volumeName)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error getting volume", err)
+	}
+	if len(ec2vols) > 0 {
+		return nil, goof.WithFields(fields, "volume name already exists")
+	}
 
-		if len(volumes) > 0 {
-			return nil, goof.WithFields(fields, "volume name already exists")
-		}
+	snapshot, err := d.SnapshotInspect(ctx, snapshotID, nil)
+	if err != nil {
+		return nil, err
+	}
 
-		volume := &types.Volume{}
+	// The defaults are applied to a copy of the options so the caller's
+	// options are not modified.
+	copts := &types.VolumeCreateOpts{}
+	if opts != nil {
+		*copts = *opts
+	}
 
-		// Pass arguments into libStorage types.Volume
-		if opts.AvailabilityZone != nil {
-			volume.AvailabilityZone = *opts.AvailabilityZone
-		}
-		if opts.Type != nil {
-			volume.Type = *opts.Type
-		}
-		if opts.Size != nil {
-			volume.Size = *opts.Size
-		}
-		if opts.IOPS != nil {
-			volume.IOPS = *opts.IOPS
-		}
-		if *opts.Encrypted == false {
-			// Volume must be encrypted if snapshot is encrypted
-			snapshot, err := d.SnapshotInspect(ctx, snapshotID, nil)
-			if err != nil {
-				return &types.Volume{}, goof.WithFieldsE(fields,
-					"Error getting snapshot", err)
-			}
-			volume.Encrypted = snapshot.Encrypted
-		} else {
-			volume.Encrypted = *opts.Encrypted
-		}
+	// A volume created from an encrypted snapshot is always encrypted, and
+	// a volume that is not sized explicitly is the size of the snapshot.
+	if copts.Encrypted == nil || snapshot.Encrypted {
+		encrypted := snapshot.Encrypted
+		copts.Encrypted = &encrypted
+	}
+	if copts.Size != nil && *copts.Size < snapshot.VolumeSize {
+		return nil, goof.WithFields(fields, "volume size too small")
+	}
+	if copts.Labels == nil {
+		copts.Labels = snapshot.Labels
+	}
 
-		// Pass libStorage types.Volume to helper function which calls EC2 API
-		vol, err := d.createVolume(ctx, volumeName, snapshotID, volume)
-		if err != nil {
-			return &types.Volume{}, goof.WithFieldsE(fields,
-				"error creating volume", err)
-		}
-		// Return the volume created
-		return d.VolumeInspect(ctx, *vol.VolumeId, &types.VolumeInspectOpts{
-			Attachments: true,
-		})
-	*/
+	// Pass libStorage types.Volume to helper function which calls EC2 API
+	vol, err := d.createVolume(ctx, volumeName, snapshotID, copts)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error creating volume", err)
+	}
+	// Return the volume created
+	return d.VolumeInspect(ctx, *vol.VolumeId, &types.VolumeInspectOpts{
+		Attachments: types.VolAttReqTrue,
+	})
 }
 
-// VolumeCopy copies an existing volume.
+// VolumeCopy copies an existing volume by taking a temporary snapshot of the
+// volume and creating the new volume from that snapshot.
 func (d *driver) VolumeCopy(
 	ctx types.Context,
 	volumeID, volumeName string,
 	opts types.Store) (*types.Volume, error) {
-	// TODO Snapshots are not implemented yet
-	return nil, types.ErrNotImplemented
-	/*
-		// Creates a temp snapshot of an existing volume,
-		// and creates a volume from that snapshot.
-		var (
-			ec2vols  []*awsec2.Volume
-			err      error
-			snapshot *types.Snapshot
-			vol      *types.Volume
-		)
 
-		// Initialize for logging
-		fields := map[string]interface{}{
-			"driverName": d.Name(),
-			"volumeID":   volumeID,
-			"volumeName": volumeName,
-			"opts":       opts,
-		}
-
-		log.WithFields(fields).Debug("creating volume from snapshot")
-
-		// Check if volume with same name exists
-		ec2VolsToCheck, err := d.getVolume(ctx, "", volumeName)
-		if err != nil {
-			return nil, goof.WithFieldsE(fields, "Error getting volume", err)
-		}
-		volsToCheck, convErr := d.toTypesVolume(ctx, ec2VolsToCheck, false)
-		if convErr != nil {
-			return nil, goof.WithFieldsE(fields, "Error converting to types.Volume",
-				convErr)
-		}
+	fields := map[string]interface{}{
+		"provider":   d.Name(),
+		"volumeID":   volumeID,
+		"volumeName": volumeName,
+	}
 
-		if len(volsToCheck) > 0 {
-			return nil, goof.WithFields(fields, "volume name already exists")
-		}
+	// Check if volume with same name exists
+	ec2vols, err := d.getVolume(ctx, "", volumeName)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error getting volume", err)
+	}
+	if len(ec2vols) > 0 {
+		return nil, goof.WithFields(fields, "volume name already exists")
+	}
 
-		// Get volume to copy using volumeID
-		ec2vols, err = d.getVolume(ctx, volumeID, "")
-		if err != nil {
-			return &types.Volume{}, goof.WithFieldsE(fields,
-				"error getting volume", err)
-		}
-		volumes, convErr2 := d.toTypesVolume(ctx, ec2vols, false)
-		if convErr2 != nil {
-			return nil, goof.WithFieldsE(fields,
-				"Error converting to types.Volume", convErr2)
-		}
-		if len(volumes) > 1 {
-			return &types.Volume{},
-				goof.WithFields(fields, "multiple volumes returned")
-		} else if len(volumes) == 0 {
-			return &types.Volume{}, goof.WithFields(fields, "no volumes returned")
-		}
+	// Get volume to copy using volumeID
+	ec2vols, err = d.getVolume(ctx, volumeID, "")
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error getting volume", err)
+	}
+	vols, err := d.toTypesVolume(ctx, ec2vols, 0)
+	if err != nil {
+		return nil, goof.WithFieldsE(
+			fields, "error converting to types.Volume", err)
+	}
+	if len(vols) == 0 {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
+	ogVol := vols[0]
 
-		// Create temporary snapshot
-		snapshotName := fmt.Sprintf("temp-%s-%d", volumeID, time.Now().UnixNano())
-		fields["snapshotName"] = snapshotName
-		snapshot, err = d.VolumeSnapshot(ctx, volumeID, snapshotName, opts)
-		if err != nil {
-			return &types.Volume{}, goof.WithFieldsE(fields,
-				"error creating temporary snapshot", err)
-		}
+	// Create temporary snapshot
+	snapshotName := fmt.Sprintf("temp-%s-%d", volumeID, time.Now().UnixNano())
+	fields["snapshotName"] = snapshotName
+	snapshot, err := d.VolumeSnapshot(ctx, volumeID, snapshotName, nil)
+	if err != nil {
+		return nil, goof.WithFieldsE(
+			fields, "error creating temporary snapshot", err)
+	}
 
-		// Use temporary snapshot to create volume
-		vol, err = d.VolumeCreateFromSnapshot(ctx, snapshot.ID,
-			volumeName, &types.VolumeCreateOpts{Encrypted: &snapshot.Encrypted,
-				Opts: opts})
-		if err != nil {
-			return &types.Volume{}, goof.WithFieldsE(fields,
-				"error creating volume copy from snapshot", err)
+	// Remove temporary snapshot whether or not the copy is created
+	defer func() {
+		if err := d.SnapshotRemove(ctx, snapshot.ID, nil); err != nil {
+			ctx.WithFields(fields).WithError(err).Warn(
+				"error removing temporary snapshot")
 		}
+	}()
 
-		// Remove temporary snapshot created
-		if err = d.SnapshotRemove(ctx, snapshot.ID, opts); err != nil {
-			return &types.Volume{}, goof.WithFieldsE(fields,
-				"error removing temporary snapshot", err)
-		}
+	// Use temporary snapshot to create volume that matches the original
+	createOpts := &types.VolumeCreateOpts{
+		AvailabilityZone: &ogVol.AvailabilityZone,
+		Encrypted:        &ogVol.Encrypted,
+		Type:             &ogVol.Type,
+		Labels:           ogVol.Labels,
+		Opts:             opts,
+	}
+	if ogVol.IOPS > 0 {
+		createOpts.IOPS = &ogVol.IOPS
+	}
+	vol, err := d.VolumeCreateFromSnapshot(
+		ctx, snapshot.ID, volumeName, createOpts)
+	if err != nil {
+		return nil, goof.WithFieldsE(
+			fields, "error creating volume copy from snapshot", err)
+	}
 
-		log.Println("Created volume " + vol.ID + " from volume " + volumeID)
-		return vol, nil
-	*/
+	ctx.WithFields(fields).WithField("newVolumeID", vol.ID).Info(
+		"copied volume")
+	return vol, nil
 }
 
-// VolumeSnapshot snapshots a volume.
+// VolumeSnapshot snapshots a volume. The snapshot is tagged with the
+// volume's labels unless the request provides its own.
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
 	volumeID, snapshotName string,
 	opts types.Store) (*types.Snapshot, error) {
-	// TODO Snapshots are not implemented yet
-	return nil, types.ErrNotImplemented
-	/*
-		// Create snapshot with EC2 API call
-		csInput := &awsec2.CreateSnapshotInput{
-			VolumeId: &volumeID,
-		}
 
-		resp, err := d.ec2Instance.CreateSnapshot(csInput)
-		if err != nil {
-			return nil, goof.WithError("Error creating snapshot", err)
-		}
+	fields := map[string]interface{}{
+		"provider":     d.Name(),
+		"volumeID":     volumeID,
+		"snapshotName": snapshotName,
+	}
 
-		// Add tags to EC2 snapshot
-		if err = d.createTags(*resp.SnapshotId, snapshotName); err != nil {
-			return &types.Snapshot{}, goof.WithError(
-				"Error creating tags", err)
-		}
+	ec2vols, err := d.getVolume(ctx, volumeID, "")
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error getting volume", err)
+	}
+	if len(ec2vols) == 0 {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
 
-		log.Println("Waiting for snapshot to complete")
-		err = d.waitSnapshotComplete(ctx, *resp.SnapshotId)
-		if err != nil {
-			return &types.Snapshot{}, goof.WithError(
-				"Error waiting for snapshot creation", err)
+	labels := d.getLabels(ec2vols[0].Tags)
+	if opts != nil {
+		if v := opts.GetStringMap("labels"); v != nil {
+			labels = v
 		}
+	}
 
-		// Check if successful snapshot
-		snapshot, err := d.SnapshotInspect(ctx, *resp.SnapshotId, nil)
-		if err != nil {
-			return &types.Snapshot{}, goof.WithError(
-				"Error getting snapshot", err)
-		}
+	// Create snapshot with EC2 API call
+	csInput := &awsec2.CreateSnapshotInput{
+		VolumeId:    &volumeID,
+		Description: aws.String(snapshotName),
+	}
+	resp, err := mustSession(ctx).CreateSnapshot(csInput)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error creating snapshot", err)
+	}
 
-		log.Println("Created Snapshot: " + snapshot.ID)
-		return snapshot, nil
-	*/
+	// Add tags to EC2 snapshot
+	if err = d.createTags(
+		ctx, *resp.SnapshotId, snapshotName, labels); err != nil {
+		return nil, goof.WithFieldsE(fields, "error creating tags", err)
+	}
+
+	ctx.WithFields(fields).Debug("waiting for snapshot to complete")
+	if err = d.waitSnapshotComplete(ctx, *resp.SnapshotId); err != nil {
+		return nil, goof.WithFieldsE(
+			fields, "error waiting for snapshot creation", err)
+	}
+
+	// Check if successful snapshot
+	return d.SnapshotInspect(ctx, *resp.SnapshotId, nil)
 }
 
 // VolumeRemove removes a volume.
@@ -710,25 +740,29 @@ func (d *driver) VolumeDetach(
 	return detachedVol, nil
 }
 
+// SnapshotsPage returns a page of snapshots using the EC2 API's page tokens.
+func (d *driver) SnapshotsPage(
+	ctx types.Context,
+	opts types.Store,
+	page *types.PageOpts) ([]*types.Snapshot, string, error) {
+
+	ec2snapshots, next, err := d.getSnapshotPage(ctx, "", "", page)
+	if err != nil {
+		return nil, "", goof.WithError("error getting snapshot", err)
+	}
+	return d.toTypesSnapshot(ec2snapshots), next, nil
+}
+
 // Snapshots returns all volumes or a filtered list of snapshots.
 func (d *driver) Snapshots(
 	ctx types.Context,
 	opts types.Store) ([]*types.Snapshot, error) {
-	// TODO Snapshots are not implemented yet
-	return nil, types.ErrNotImplemented
-	/*
-		// Get all snapshots
-		ec2snapshots, err := d.getSnapshot(ctx, "", "", "")
-		if err != nil {
-			return nil, goof.WithError("error getting snapshot", err)
-		}
-		if len(ec2snapshots) == 0 {
-			return nil, goof.New("no snapshots returned")
-		}
-		// Convert to libStorage types.Snapshot
-		snapshots := d.toTypesSnapshot(ec2snapshots)
-		return snapshots, nil
-	*/
+
+	ec2snapshots, err := d.getSnapshot(ctx, "", "")
+	if err != nil {
+		return nil, goof.WithError("error getting snapshot", err)
+	}
+	return d.toTypesSnapshot(ec2snapshots), nil
 }
 
 // SnapshotInspect inspects a single snapshot.
@@ -736,99 +770,103 @@ func (d *driver) SnapshotInspect(
 	ctx types.Context,
 	snapshotID string,
 	opts types.Store) (*types.Snapshot, error) {
-	// TODO Snapshots are not implemented yet
-	return nil, types.ErrNotImplemented
-	/*
-		// Get snapshot corresponding to snapshot ID
-		ec2snapshots, err := d.getSnapshot(ctx, "", snapshotID, "")
-		if err != nil {
-			return nil, goof.WithError("error getting snapshot", err)
-		}
-		if len(ec2snapshots) == 0 {
-			return nil, goof.New("no snapshots returned")
-		}
-		// Convert to libStorage types.Snapshot
-		snapshots := d.toTypesSnapshot(ec2snapshots)
 
-		// Because getSnapshot returns an array
-		// and we only expect the 1st element to be a match, return 1st element
-		return snapshots[0], nil
-	*/
+	if snapshotID == "" {
+		return nil, errMissingSnapshotID
+	}
+
+	// Get snapshot corresponding to snapshot ID
+	ec2snapshots, err := d.getSnapshot(ctx, "", snapshotID)
+	if err != nil {
+		return nil, goof.WithError("error getting snapshot", err)
+	}
+	if len(ec2snapshots) == 0 {
+		return nil, apiUtils.NewNotFoundError(snapshotID)
+	}
+
+	// Because getSnapshot returns an array
+	// and we only expect the 1st element to be a match, return 1st element
+	return d.toTypesSnapshot(ec2snapshots)[0], nil
 }
 
-// SnapshotCopy copies an existing snapshot.
+// SnapshotCopy copies an existing snapshot. The destination ID is the region
+// to which the snapshot is copied, and the copy remains in the snapshot's
+// region if it is empty.
 func (d *driver) SnapshotCopy(
 	ctx types.Context,
 	snapshotID, snapshotName, destinationID string,
 	opts types.Store) (*types.Snapshot, error) {
-	// TODO Snapshots are not implemented yet
-	return nil, types.ErrNotImplemented
-	/*
-		// no snapshot id inputted
-		if snapshotID == "" {
-			return &types.Snapshot{}, goof.New("Missing snapshotID")
-		}
 
-		// Get snapshot to copy
-		origSnapshots, err := d.getSnapshot(ctx, "", snapshotID, "")
-		if err != nil {
-			return &types.Snapshot{},
-				goof.WithError("Error getting snapshot", err)
-		}
+	fields := map[string]interface{}{
+		"provider":      d.Name(),
+		"snapshotID":    snapshotID,
+		"snapshotName":  snapshotName,
+		"destinationID": destinationID,
+	}
 
-		if len(origSnapshots) > 1 {
-			return &types.Snapshot{},
-				goof.New("multiple snapshots returned")
-		} else if len(origSnapshots) == 0 {
-			return &types.Snapshot{}, goof.New("no snapshots returned")
-		}
+	// Get snapshot to copy
+	snapshot, err := d.SnapshotInspect(ctx, snapshotID, nil)
+	if err != nil {
+		return nil, err
+	}
 
-		// Copy snapshot with EC2 API call
-		snapshotID = *(origSnapshots[0]).SnapshotId
-		snapshotName = d.getName(origSnapshots[0].Tags)
+	srcRegion := d.mustRegion(ctx)
+	if srcRegion == nil || *srcRegion == "" {
+		return nil, goof.WithFields(fields, "missing region")
+	}
 
-		options := &awsec2.CopySnapshotInput{
-			SourceSnapshotId: &snapshotID,
-			SourceRegion:     &d.instanceDocument.Region,
-			Description:      aws.String(fmt.Sprintf("Copy of %s", snapshotID)),
-		}
-		resp := &awsec2.CopySnapshotOutput{}
+	// Copy the snapshot using the destination region's EC2 service
+	dctx := ctx
+	if destinationID != "" && destinationID != *srcRegion {
+		dctx = ctx.WithValue(context.SessionKey, d.login(&destinationID))
+	}
 
-		resp, err = d.ec2Instance.CopySnapshot(options)
-		if err != nil {
-			return nil, goof.WithError("error copying snapshot", err)
+	if snapshotName == "" {
+		snapshotName = d.getPrintableName(snapshot.Name)
+	}
+	labels := snapshot.Labels
+	if opts != nil {
+		if v := opts.GetStringMap("labels"); v != nil {
+			labels = v
 		}
+	}
+
+	csInput := &awsec2.CopySnapshotInput{
+		SourceSnapshotId: &snapshotID,
+		SourceRegion:     srcRegion,
+		Description:      aws.String(fmt.Sprintf("Copy of %s", snapshotID)),
+	}
 
-		// Add tags to copied snapshot
-		if err = d.createTags(*resp.SnapshotId, snapshotName); err != nil {
-			return &types.Snapshot{}, goof.WithError(
-				"Error creating tags", err)
+	// The copy of an encrypted snapshot must be encrypted too, and the
+	// copies are encrypted with the configured key if there is one, just
+	// as the volumes the driver creates are.
+	if snapshot.Encrypted || len(d.kmsKeyID) > 0 {
+		csInput.Encrypted = aws.Bool(true)
+		if len(d.kmsKeyID) > 0 {
+			csInput.KmsKeyId = aws.String(d.kmsKeyID)
 		}
+	}
 
-		log.WithFields(log.Fields{
-			"moduleName":      d.Name(),
-			"driverName":      d.Name(),
-			"snapshotName":    snapshotName,
-			"resp.SnapshotId": *resp.SnapshotId}).Info("waiting for snapshot to complete")
+	resp, err := mustSession(dctx).CopySnapshot(csInput)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields, "error copying snapshot", err)
+	}
 
-		// Wait for snapshot status to update
-		err = d.waitSnapshotComplete(ctx, *resp.SnapshotId)
-		if err != nil {
-			return &types.Snapshot{}, goof.WithError(
-				"Error waiting for snapshot creation", err)
-		}
+	// Add tags to copied snapshot
+	if err = d.createTags(
+		dctx, *resp.SnapshotId, snapshotName, labels); err != nil {
+		return nil, goof.WithFieldsE(fields, "error creating tags", err)
+	}
 
-		// Check if successful snapshot
-		snapshotCopy, err := d.SnapshotInspect(ctx, *resp.SnapshotId, nil)
-		if err != nil {
-			return &types.Snapshot{}, goof.WithError(
-				"Error getting snapshot copy", err)
-		}
-		destinationID = snapshotCopy.ID
+	fields["copySnapshotID"] = *resp.SnapshotId
+	ctx.WithFields(fields).Debug("waiting for snapshot copy to complete")
+	if err = d.waitSnapshotComplete(dctx, *resp.SnapshotId); err != nil {
+		return nil, goof.WithFieldsE(
+			fields, "error waiting for snapshot copy", err)
+	}
 
-		log.Println("Copied Snapshot: " + destinationID)
-		return snapshotCopy, nil
-	*/
+	// Check if successful snapshot
+	return d.SnapshotInspect(dctx, *resp.SnapshotId, nil)
 }
 
 // SnapshotRemove removes a snapshot.
@@ -836,31 +874,23 @@ func (d *driver) SnapshotRemove(
 	ctx types.Context,
 	snapshotID string,
 	opts types.Store) error {
-	// TODO Snapshots are not implemented yet
-	return types.ErrNotImplemented
-	/*
-		// Initialize for logging
-		fields := map[string]interface{}{
-			"provider":   d.Name(),
-			"snapshotID": snapshotID,
-		}
 
-		// no snapshot ID inputted
-		if snapshotID == "" {
-			return goof.New("missing snapshot id")
-		}
+	if snapshotID == "" {
+		return errMissingSnapshotID
+	}
 
-		// Delete snapshot using EC2 API call
-		dsInput := &awsec2.DeleteSnapshotInput{
-			SnapshotId: &snapshotID,
-		}
-		_, err := d.ec2Instance.DeleteSnapshot(dsInput)
-		if err != nil {
-			return goof.WithFieldsE(fields, "error deleting snapshot", err)
-		}
+	// Delete snapshot using EC2 API call
+	dsInput := &awsec2.DeleteSnapshotInput{
+		SnapshotId: &snapshotID,
+	}
+	if _, err := mustSession(ctx).DeleteSnapshot(dsInput); err != nil {
+		return goof.WithFieldsE(
+			log.Fields{
+				"provider":   d.Name(),
+				"snapshotID": snapshotID}, "error deleting snapshot", err)
+	}
 
-		return nil
-	*/
+	return nil
 }
 
 ///////////////////////////////////////////////////////////////////////
@@ -871,6 +901,17 @@ func (d *driver) getVolume(
 	ctx types.Context,
 	volumeID, volumeName string) ([]*awsec2.Volume, error) {
 
+	vols, _, err := d.getVolumePage(ctx, volumeID, volumeName, nil)
+	return vols, err
+}
+
+// getVolumePage retrieves a page of volumes. All of the volumes are retrieved
+// if the page options are nil.
+func (d *driver) getVolumePage(
+	ctx types.Context,
+	volumeID, volumeName string,
+	page *types.PageOpts) ([]*awsec2.Volume, string, error) {
+
 	// prepare filters
 	filters := []*awsec2.Filter{}
 
@@ -908,15 +949,29 @@ func (d *driver) getVolume(
 
 	if volumeID != "" {
 		dvInput.VolumeIds = []*string{&volumeID}
+	} else if page != nil {
+		// the EC2 API rejects page sizes outside of its bounds, so the page
+		// may hold more volumes than requested
+		size := page.Limit
+		switch {
+		case size < 1, size > maxPageSize:
+			size = maxPageSize
+		case size < minPageSize:
+			size = minPageSize
+		}
+		dvInput.MaxResults = aws.Int64(int64(size))
+		if page.Continue != "" {
+			dvInput.NextToken = aws.String(page.Continue)
+		}
 	}
 
 	// Retrieve filtered volumes through EC2 API call
 	resp, err := mustSession(ctx).DescribeVolumes(dvInput)
 	if err != nil {
-		return []*awsec2.Volume{}, err
+		return []*awsec2.Volume{}, "", err
 	}
 
-	return resp.Volumes, nil
+	return resp.Volumes, aws.StringValue(resp.NextToken), nil
 }
 
 var errGetLocDevs = goof.New("error getting local devices from context")
@@ -981,6 +1036,7 @@ func (d *driver) toTypesVolume(
 			Type:             *volume.VolumeType,
 			Size:             *volume.Size,
 			Attachments:      attachmentsSD,
+			Labels:           d.getLabels(volume.Tags),
 		}
 
 		// Some volume types have no IOPS, so we get nil in volume.Iops
@@ -993,17 +1049,25 @@ func (d *driver) toTypesVolume(
 }
 
 // getSnapshot searches for and returns snapshots matching criteria
-// TODO Snapshots are not implemented yet
-/*
 func (d *driver) getSnapshot(
 	ctx types.Context,
-	volumeID, snapshotID, snapshotName string) ([]*awsec2.Snapshot, error) {
-	// Prepare filters
+	volumeID, snapshotID string) ([]*awsec2.Snapshot, error) {
+
+	snapshots, _, err := d.getSnapshotPage(ctx, volumeID, snapshotID, nil)
+	return snapshots, err
+}
+
+// getSnapshotPage retrieves a page of the account's snapshots. All of the
+// snapshots are retrieved if the page options are nil. Only the snapshots
+// whose names have the configured tag's prefix are listed, but a snapshot
+// may be retrieved by its ID regardless of its tag.
+func (d *driver) getSnapshotPage(
+	ctx types.Context,
+	volumeID, snapshotID string,
+	page *types.PageOpts) ([]*awsec2.Snapshot, string, error) {
+
+	// prepare filters
 	filters := []*awsec2.Filter{}
-	if snapshotName != "" {
-		filters = append(filters, &awsec2.Filter{
-			Name: aws.String("tag:Name"), Values: []*string{&snapshotName}})
-	}
 
 	if volumeID != "" {
 		filters = append(filters, &awsec2.Filter{
@@ -1014,55 +1078,71 @@ func (d *driver) getSnapshot(
 		//using SnapshotIds in request is returning stale data
 		filters = append(filters, &awsec2.Filter{
 			Name: aws.String("snapshot-id"), Values: []*string{&snapshotID}})
+	} else if tag := d.tag(); tag != "" {
+		filters = append(filters, &awsec2.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: []*string{aws.String(tag + ebs.TagDelimiter + "*")}})
 	}
 
-	// TODO rexrayTag?
-	//	if d.ec2Tag != "" {
-	//	filters = append(filters, &ec2.Filter{
-	//		Name:   aws.String(fmt.Sprintf("tag:%s", rexrayTag)),
-	//		Values: []*string{&d.ec2Tag}})
-	//}
-
-	// Prepare input
-	dsInput := &awsec2.DescribeSnapshotsInput{}
+	// Prepare input, omitting the public snapshots of other accounts
+	dsInput := &awsec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+	}
 
 	// Apply filters if arguments are specified
 	if len(filters) > 0 {
 		dsInput.Filters = filters
 	}
 
-	// Retrieve filtered volumes through EC2 API call
-	resp, err := d.ec2Instance.DescribeSnapshots(dsInput)
+	if page != nil {
+		// the EC2 API rejects page sizes outside of its bounds, so the page
+		// may hold more snapshots than requested
+		size := page.Limit
+		switch {
+		case size < 1, size > maxSnapshotPageSize:
+			size = maxSnapshotPageSize
+		case size < minPageSize:
+			size = minPageSize
+		}
+		dsInput.MaxResults = aws.Int64(int64(size))
+		if page.Continue != "" {
+			dsInput.NextToken = aws.String(page.Continue)
+		}
+	}
+
+	// Retrieve filtered snapshots through EC2 API call
+	resp, err := mustSession(ctx).DescribeSnapshots(dsInput)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return resp.Snapshots, nil
+	return resp.Snapshots, aws.StringValue(resp.NextToken), nil
 }
 
-
 // Converts EC2 API snapshots to libStorage types.Snapshot
 func (d *driver) toTypesSnapshot(
 	ec2snapshots []*awsec2.Snapshot) []*types.Snapshot {
-	var snapshotsInt []*types.Snapshot
+
+	snapshotsSD := []*types.Snapshot{}
 	for _, snapshot := range ec2snapshots {
-		name := d.getName(snapshot.Tags)
 		snapshotSD := &types.Snapshot{
-			Name:        name,
-			VolumeID:    *snapshot.VolumeId,
-			ID:          *snapshot.SnapshotId,
-			Encrypted:   *snapshot.Encrypted,
-			VolumeSize:  *snapshot.VolumeSize,
-			StartTime:   (*snapshot.StartTime).Unix(),
-			Description: *snapshot.Description,
-			Status:      *snapshot.State,
+			Name:        d.getName(snapshot.Tags),
+			VolumeID:    aws.StringValue(snapshot.VolumeId),
+			ID:          aws.StringValue(snapshot.SnapshotId),
+			Encrypted:   aws.BoolValue(snapshot.Encrypted),
+			VolumeSize:  aws.Int64Value(snapshot.VolumeSize),
+			Description: aws.StringValue(snapshot.Description),
+			Status:      aws.StringValue(snapshot.State),
+			Labels:      d.getLabels(snapshot.Tags),
+		}
+		if snapshot.StartTime != nil {
+			snapshotSD.StartTime = snapshot.StartTime.Unix()
 		}
-		snapshotsInt = append(snapshotsInt, snapshotSD)
+		snapshotsSD = append(snapshotsSD, snapshotSD)
 	}
 
-	return snapshotsInt
+	return snapshotsSD
 }
-*/
 
 var (
 	errNoVolReturned       = goof.New("no volume returned")
@@ -1151,7 +1231,8 @@ func (d *driver) createVolume(
 	}
 
 	// Add tags to created volume
-	if err = d.createTags(ctx, *resp.VolumeId, volumeName); err != nil {
+	if err = d.createTags(
+		ctx, *resp.VolumeId, volumeName, opts.Labels); err != nil {
 		return &awsec2.Volume{}, goof.WithError(
 			"error creating tags", err)
 	}
@@ -1176,7 +1257,10 @@ func (d *driver) createVolumeEnsureAvailabilityZone(
 }
 
 // Fill in tags for volume or snapshot
-func (d *driver) createTags(ctx types.Context, id, name string) (err error) {
+func (d *driver) createTags(
+	ctx types.Context,
+	id, name string,
+	labels map[string]string) (err error) {
 	var (
 		ctInput   *awsec2.CreateTagsInput
 		inputName string
@@ -1201,6 +1285,19 @@ func (d *driver) createTags(ctx types.Context, id, name string) (err error) {
 			Value: &inputName,
 		})
 
+	// Add a tag for each of the labels
+	for k, v := range labels {
+		if k == "Name" {
+			continue
+		}
+		ctInput.Tags = append(
+			ctInput.Tags,
+			&awsec2.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(v),
+			})
+	}
+
 	// TODO rexrayTag
 	/*	if d.ec2Tag != "" {
 			initCTInput()
@@ -1281,40 +1378,61 @@ func (d *driver) waitVolumeComplete(
 	return nil
 }
 
-// Wait for snapshot action to complete
-// TODO Snapshots are not implemented yet
-/*
+var errMissingSnapshotID = goof.New("missing snapshot ID")
+
+// Wait for snapshot action to complete (creation, copy)
 func (d *driver) waitSnapshotComplete(
 	ctx types.Context, snapshotID string) error {
+	// no snapshot id inputted
 	if snapshotID == "" {
-		return goof.New("Missing snapshot ID")
+		return errMissingSnapshotID
 	}
 
-	for {
-		// update snapshot
-		snapshots, err := d.getSnapshot(ctx, "", snapshotID, "")
-		if err != nil {
-			return goof.WithError(
-				"Error getting snapshot", err)
-		}
+	f := func() (interface{}, error) {
+		duration := d.statusDelay
+		for i := 1; i <= d.maxAttempts; i++ {
+			// update snapshot
+			snapshots, err := d.getSnapshot(ctx, "", snapshotID)
+			if err != nil {
+				return nil, goof.WithFieldE("snapshotID",
+					snapshotID, "error getting snapshot", err)
+			}
 
-		// check retrieved snapshot
-		if len(snapshots) == 0 {
-			return goof.New("No snapshots found")
-		}
-		snapshot := snapshots[0]
-		if *snapshot.State == awsec2.SnapshotStateCompleted {
-			break
-		}
-		if *snapshot.State == awsec2.SnapshotStateError {
-			return goof.Newf("Snapshot state error: %s", *snapshot.StateMessage)
+			// check retrieved snapshot
+			if len(snapshots) > 0 {
+				switch *snapshots[0].State {
+				case awsec2.SnapshotStateCompleted:
+					return nil, nil
+				case awsec2.SnapshotStateError:
+					return nil, goof.WithField("stateMessage",
+						aws.StringValue(snapshots[0].StateMessage),
+						"snapshot state error")
+				}
+			}
+
+			ctx.WithField("snapshotID", snapshotID).Debug(
+				"still waiting for snapshot",
+			)
+			time.Sleep(time.Duration(duration) * time.Nanosecond)
+			duration = int64(2) * duration
 		}
-		time.Sleep(1 * time.Second)
+		return nil, goof.WithField("maxAttempts", d.maxAttempts,
+			"Status attempts exhausted")
 	}
 
+	_, ok, err := apiUtils.WaitFor(f, d.statusTimeout)
+	if !ok {
+		return goof.WithFields(goof.Fields{
+			"snapshotID":    snapshotID,
+			"statusTimeout": d.statusTimeout},
+			"Timeout occured waiting for storage action")
+	}
+	if err != nil {
+		return goof.WithFieldE("snapshotID", snapshotID,
+			"Error while waiting for storage action to finish", err)
+	}
 	return nil
 }
-*/
 
 // Retrieve volume or snapshot name
 func (d *driver) getName(tags []*awsec2.Tag) string {
@@ -1326,6 +1444,21 @@ func (d *driver) getName(tags []*awsec2.Tag) string {
 	return ""
 }
 
+// Retrieve the labels from the tags, omitting the tag used for the name
+func (d *driver) getLabels(tags []*awsec2.Tag) map[string]string {
+	var labels map[string]string
+	for _, tag := range tags {
+		if *tag.Key == "Name" {
+			continue
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[*tag.Key] = *tag.Value
+	}
+	return labels
+}
+
 // Retrieve current instance using EC2 API call
 func (d *driver) getInstance(ctx types.Context) (awsec2.Instance, error) {
 	diInput := &awsec2.DescribeInstancesInput{
@@ -0,0 +1,635 @@
+package storage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/tests/drivertest"
+	"github.com/codedellemc/libstorage/api/types"
+	apiUtils "github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/drivers/storage/ebs"
+)
+
+const (
+	testRegion     = "us-east-1"
+	testZone       = "us-east-1a"
+	testInstanceID = "i-00000001"
+)
+
+type fakeTag struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+type fakeVolume struct {
+	VolumeID         string    `xml:"volumeId"`
+	Size             int64     `xml:"size"`
+	SnapshotID       string    `xml:"snapshotId"`
+	AvailabilityZone string    `xml:"availabilityZone"`
+	Status           string    `xml:"status"`
+	VolumeType       string    `xml:"volumeType"`
+	IOPS             int64     `xml:"iops,omitempty"`
+	Encrypted        bool      `xml:"encrypted"`
+	Tags             []fakeTag `xml:"tagSet>item"`
+}
+
+type fakeSnapshot struct {
+	SnapshotID  string    `xml:"snapshotId"`
+	VolumeID    string    `xml:"volumeId"`
+	Status      string    `xml:"status"`
+	StartTime   string    `xml:"startTime"`
+	VolumeSize  int64     `xml:"volumeSize"`
+	OwnerID     string    `xml:"ownerId"`
+	Description string    `xml:"description"`
+	Encrypted   bool      `xml:"encrypted"`
+	Tags        []fakeTag `xml:"tagSet>item"`
+	region      string
+}
+
+// fakeEC2 is an EC2-compatible stand-in that implements the parts of the
+// EC2 Query API used by the driver. A volume or snapshot is pending until
+// it is described once.
+type fakeEC2 struct {
+	sync.Mutex
+	volumes   []*fakeVolume
+	snapshots []*fakeSnapshot
+	nextID    int
+	copies    []map[string]string
+}
+
+var credRegionRX = regexp.MustCompile(`Credential=[^/]+/[^/]+/([^/]+)/`)
+
+func (f *fakeEC2) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	region := testRegion
+	m := credRegionRX.FindStringSubmatch(req.Header.Get("Authorization"))
+	if len(m) > 1 {
+		region = m[1]
+	}
+
+	var body interface{}
+	switch req.Form.Get("Action") {
+	case "DescribeInstances":
+		body = &struct {
+			XMLName xml.Name `xml:"DescribeInstancesResponse"`
+			ID      string   `xml:"reservationSet>item>instancesSet>item>instanceId"`
+			Zone    string   `xml:"reservationSet>item>instancesSet>item>placement>availabilityZone"`
+		}{ID: testInstanceID, Zone: testZone}
+	case "CreateVolume":
+		v := &fakeVolume{
+			VolumeID:         f.newID("vol"),
+			SnapshotID:       req.Form.Get("SnapshotId"),
+			AvailabilityZone: req.Form.Get("AvailabilityZone"),
+			Status:           "creating",
+			VolumeType:       req.Form.Get("VolumeType"),
+			Encrypted:        req.Form.Get("Encrypted") == "true",
+		}
+		if v.VolumeType == "" {
+			v.VolumeType = "gp2"
+		}
+		v.Size, _ = strconv.ParseInt(req.Form.Get("Size"), 10, 64)
+		v.IOPS, _ = strconv.ParseInt(req.Form.Get("Iops"), 10, 64)
+		if s := f.snapshot(v.SnapshotID); s != nil {
+			if v.Size == 0 {
+				v.Size = s.VolumeSize
+			}
+			v.Encrypted = v.Encrypted || s.Encrypted
+		}
+		f.volumes = append(f.volumes, v)
+		body = &struct {
+			XMLName xml.Name `xml:"CreateVolumeResponse"`
+			*fakeVolume
+		}{fakeVolume: v}
+	case "DescribeVolumes":
+		vols := []*fakeVolume{}
+		for _, v := range f.volumes {
+			if f.match(req, v.VolumeID, "", v.AvailabilityZone, v.Tags) {
+				vols = append(vols, v)
+				if v.Status == "creating" {
+					v.Status = "available"
+				}
+			}
+		}
+		body = &struct {
+			XMLName xml.Name      `xml:"DescribeVolumesResponse"`
+			Volumes []*fakeVolume `xml:"volumeSet>item"`
+		}{Volumes: vols}
+	case "CreateSnapshot":
+		v := f.volume(req.Form.Get("VolumeId"))
+		if v == nil {
+			f.error(w, "InvalidVolume.NotFound")
+			return
+		}
+		s := f.newSnapshot(v.VolumeID, v.Size, v.Encrypted, region)
+		s.Description = req.Form.Get("Description")
+		body = &struct {
+			XMLName xml.Name `xml:"CreateSnapshotResponse"`
+			*fakeSnapshot
+		}{fakeSnapshot: s}
+	case "CopySnapshot":
+		src := f.snapshot(req.Form.Get("SourceSnapshotId"))
+		if src == nil || src.region != req.Form.Get("SourceRegion") {
+			f.error(w, "InvalidSnapshot.NotFound")
+			return
+		}
+		encrypted := req.Form.Get("Encrypted") == "true"
+		if src.Encrypted && !encrypted {
+			f.error(w, "InvalidParameterCombination")
+			return
+		}
+		f.copies = append(f.copies, map[string]string{
+			"region":       region,
+			"sourceRegion": req.Form.Get("SourceRegion"),
+			"kmsKeyId":     req.Form.Get("KmsKeyId"),
+		})
+		s := f.newSnapshot(src.VolumeID, src.VolumeSize, encrypted, region)
+		s.Description = req.Form.Get("Description")
+		body = &struct {
+			XMLName    xml.Name `xml:"CopySnapshotResponse"`
+			SnapshotID string   `xml:"snapshotId"`
+		}{SnapshotID: s.SnapshotID}
+	case "DescribeSnapshots":
+		snaps := []*fakeSnapshot{}
+		for _, s := range f.snapshots {
+			if s.region != region {
+				continue
+			}
+			if s.OwnerID != "self" && req.Form.Get("Owner.1") == "self" {
+				continue
+			}
+			if f.match(req, s.VolumeID, s.SnapshotID, "", s.Tags) {
+				snaps = append(snaps, s)
+			}
+		}
+		next := ""
+		if size, _ := strconv.Atoi(req.Form.Get("MaxResults")); size > 0 {
+			start, _ := strconv.Atoi(req.Form.Get("NextToken"))
+			snaps = snaps[start:]
+			if len(snaps) > size {
+				snaps = snaps[:size]
+				next = strconv.Itoa(start + size)
+			}
+		}
+		for _, s := range snaps {
+			if s.Status == "pending" {
+				s.Status = "completed"
+			}
+		}
+		body = &struct {
+			XMLName   xml.Name        `xml:"DescribeSnapshotsResponse"`
+			Snapshots []*fakeSnapshot `xml:"snapshotSet>item"`
+			NextToken string          `xml:"nextToken,omitempty"`
+		}{Snapshots: snaps, NextToken: next}
+	case "DeleteSnapshot":
+		id := req.Form.Get("SnapshotId")
+		for i, s := range f.snapshots {
+			if s.SnapshotID == id {
+				f.snapshots = append(f.snapshots[:i], f.snapshots[i+1:]...)
+				body = &struct {
+					XMLName xml.Name `xml:"DeleteSnapshotResponse"`
+					Return  bool     `xml:"return"`
+				}{Return: true}
+				break
+			}
+		}
+		if body == nil {
+			f.error(w, "InvalidSnapshot.NotFound")
+			return
+		}
+	case "CreateTags":
+		id := req.Form.Get("ResourceId.1")
+		var tags []fakeTag
+		for i := 1; req.Form.Get(fmt.Sprintf("Tag.%d.Key", i)) != ""; i++ {
+			tags = append(tags, fakeTag{
+				Key:   req.Form.Get(fmt.Sprintf("Tag.%d.Key", i)),
+				Value: req.Form.Get(fmt.Sprintf("Tag.%d.Value", i)),
+			})
+		}
+		if v := f.volume(id); v != nil {
+			v.Tags = append(v.Tags, tags...)
+		} else if s := f.snapshot(id); s != nil {
+			s.Tags = append(s.Tags, tags...)
+		} else {
+			f.error(w, "InvalidID")
+			return
+		}
+		body = &struct {
+			XMLName xml.Name `xml:"CreateTagsResponse"`
+			Return  bool     `xml:"return"`
+		}{Return: true}
+	default:
+		f.error(w, "InvalidAction")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	xml.NewEncoder(w).Encode(body)
+}
+
+func (f *fakeEC2) error(w http.ResponseWriter, code string) {
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(w, "<Response><Errors><Error><Code>%s</Code>"+
+		"<Message>%s</Message></Error></Errors></Response>", code, code)
+}
+
+func (f *fakeEC2) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%08d", prefix, f.nextID)
+}
+
+func (f *fakeEC2) newSnapshot(
+	volumeID string, size int64, encrypted bool, region string) *fakeSnapshot {
+
+	s := &fakeSnapshot{
+		SnapshotID: f.newID("snap"),
+		VolumeID:   volumeID,
+		Status:     "pending",
+		StartTime:  time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		VolumeSize: size,
+		OwnerID:    "self",
+		Encrypted:  encrypted,
+		region:     region,
+	}
+	f.snapshots = append(f.snapshots, s)
+	return s
+}
+
+func (f *fakeEC2) volume(id string) *fakeVolume {
+	for _, v := range f.volumes {
+		if v.VolumeID == id {
+			return v
+		}
+	}
+	return nil
+}
+
+func (f *fakeEC2) snapshot(id string) *fakeSnapshot {
+	for _, s := range f.snapshots {
+		if s.SnapshotID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// match returns a flag indicating whether a resource matches the request's
+// IDs and filters.
+func (f *fakeEC2) match(
+	req *http.Request,
+	volumeID, snapshotID, zone string,
+	tags []fakeTag) bool {
+
+	if id := req.Form.Get("VolumeId.1"); id != "" && id != volumeID {
+		return false
+	}
+
+	for i := 1; ; i++ {
+		name := req.Form.Get(fmt.Sprintf("Filter.%d.Name", i))
+		if name == "" {
+			return true
+		}
+		want := req.Form.Get(fmt.Sprintf("Filter.%d.Value.1", i))
+		var have string
+		switch {
+		case name == "volume-id":
+			have = volumeID
+		case name == "snapshot-id":
+			have = snapshotID
+		case name == "availability-zone":
+			have = zone
+		case strings.HasPrefix(name, "tag:"):
+			for _, t := range tags {
+				if t.Key == strings.TrimPrefix(name, "tag:") {
+					have = t.Value
+				}
+			}
+		}
+		if strings.HasSuffix(want, "*") {
+			if !strings.HasPrefix(have, strings.TrimSuffix(want, "*")) {
+				return false
+			}
+		} else if have != want {
+			return false
+		}
+	}
+}
+
+func newTestDriver(
+	t *testing.T, tag string) (*driver, types.Context, *fakeEC2) {
+
+	fake := &fakeEC2{}
+	srv := httptest.NewServer(fake)
+
+	config := gofigCore.New()
+	config.Set(ebs.ConfigEBSAccessKey, "access")
+	config.Set(ebs.ConfigEBSSecretKey, "secret")
+	config.Set(ebs.ConfigEBSRegion, testRegion)
+	config.Set(ebs.ConfigEBSEndpoint, srv.URL)
+	config.Set(ebs.ConfigEBSMaxRetries, 0)
+	config.Set(ebs.ConfigEBSTag, tag)
+	config.Set(ebs.ConfigStatusMaxAttempts, 5)
+	config.Set(ebs.ConfigStatusInitDelay, "1ms")
+	config.Set(ebs.ConfigStatusTimeout, "10s")
+
+	d := &driver{name: ebs.Name}
+	ctx := context.Background()
+	if err := d.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = ctx.WithValue(context.InstanceIDKey, &types.InstanceID{
+		ID:     testInstanceID,
+		Driver: ebs.Name,
+		Fields: map[string]string{
+			ebs.InstanceIDFieldRegion:           testRegion,
+			ebs.InstanceIDFieldAvailabilityZone: testZone,
+		},
+	})
+	ctx = ctx.WithValue(context.LocalDevicesKey, &types.LocalDevices{
+		Driver:    ebs.Name,
+		DeviceMap: map[string]string{},
+	})
+	sess, err := d.Login(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = ctx.WithValue(context.SessionKey, sess)
+
+	return d, ctx, fake
+}
+
+func newTestVolume(
+	t *testing.T, d *driver, ctx types.Context, name string) *types.Volume {
+
+	size := int64(8)
+	v, err := d.VolumeCreate(ctx, name, &types.VolumeCreateOpts{
+		Size:   &size,
+		Labels: map[string]string{"env": "test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, "")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "completed", s.Status)
+	assert.Equal(t, map[string]string{"env": "test"}, s.Labels)
+
+	// the request's labels take precedence over the volume's
+	s, err = d.VolumeSnapshot(
+		ctx, v.ID, "s1", apiUtils.NewStoreWithData(map[string]interface{}{
+			"labels": map[string]string{"env": "prod"},
+		}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, s.Labels)
+
+	// snapshots of other accounts are not listed
+	fake.snapshots = append(fake.snapshots, &fakeSnapshot{
+		SnapshotID: "snap-public",
+		Status:     "completed",
+		OwnerID:    "amazon",
+		region:     testRegion,
+	})
+	snaps, err := d.Snapshots(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, snaps, 2)
+}
+
+func TestSnapshotsTag(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, "tag0")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "tag0/s0", s.Name)
+
+	// a snapshot created by another service is only listed by that service
+	other := fake.newSnapshot(v.ID, 8, false, testRegion)
+	other.Tags = []fakeTag{{Key: "Name", Value: "tag1/s1"}}
+
+	snaps, err := d.Snapshots(ctx, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, snaps, 1) {
+		assert.Equal(t, s.ID, snaps[0].ID)
+	}
+
+	// but any snapshot may be inspected by its ID
+	snap, err := d.SnapshotInspect(ctx, other.SnapshotID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "tag1/s1", snap.Name)
+}
+
+func TestSnapshotsPage(t *testing.T) {
+	d, ctx, _ := newTestDriver(t, "")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	var ids []string
+	for i := 0; i < 7; i++ {
+		s, err := d.VolumeSnapshot(ctx, v.ID, fmt.Sprintf("s%d", i), nil)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		ids = append(ids, s.ID)
+	}
+
+	// the page size is raised to the smallest size the EC2 API accepts
+	snaps, next, err := d.SnapshotsPage(ctx, nil, &types.PageOpts{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, snaps, minPageSize)
+	assert.NotEmpty(t, next)
+
+	var pageIDs []string
+	for _, s := range snaps {
+		pageIDs = append(pageIDs, s.ID)
+	}
+	snaps, next, err = d.SnapshotsPage(
+		ctx, nil, &types.PageOpts{Limit: 2, Continue: next})
+	assert.NoError(t, err)
+	assert.Len(t, snaps, 2)
+	assert.Empty(t, next)
+	for _, s := range snaps {
+		pageIDs = append(pageIDs, s.ID)
+	}
+
+	sort.Strings(ids)
+	sort.Strings(pageIDs)
+	assert.Equal(t, ids, pageIDs)
+}
+
+func TestSnapshotCopy(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, "")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// a copy in the same region
+	c, err := d.SnapshotCopy(ctx, s.ID, "c0", "", nil)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, s.ID, c.ID)
+		assert.Equal(t, "c0", c.Name)
+		assert.Equal(t, s.Labels, c.Labels)
+	}
+
+	// a copy in another region, which is named after the original
+	c, err = d.SnapshotCopy(ctx, s.ID, "", "us-west-2", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "s0", c.Name)
+		assert.Equal(t, "completed", c.Status)
+	}
+	assert.Equal(t, []map[string]string{
+		{"region": testRegion, "sourceRegion": testRegion, "kmsKeyId": ""},
+		{"region": "us-west-2", "sourceRegion": testRegion, "kmsKeyId": ""},
+	}, fake.copies)
+
+	// the copy is not in the original's region
+	_, err = d.SnapshotInspect(ctx, c.ID, nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+
+	_, err = d.SnapshotCopy(ctx, "snap-missing", "c1", "", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+}
+
+func TestSnapshotCopyEncrypted(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, "")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	size, encrypted := int64(8), true
+	ev, err := d.VolumeCreate(ctx, "v1", &types.VolumeCreateOpts{
+		Size:      &size,
+		Encrypted: &encrypted,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	es, err := d.VolumeSnapshot(ctx, ev.ID, "s1", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.True(t, es.Encrypted)
+
+	// the copy of an encrypted snapshot is encrypted
+	c, err := d.SnapshotCopy(ctx, es.ID, "c0", "", nil)
+	if assert.NoError(t, err) {
+		assert.True(t, c.Encrypted)
+	}
+	c, err = d.SnapshotCopy(ctx, s.ID, "c1", "", nil)
+	if assert.NoError(t, err) {
+		assert.False(t, c.Encrypted)
+	}
+
+	// copies are encrypted with the configured key
+	d.kmsKeyID = "arn:aws:kms:us-east-1:012345678910:key/k0"
+	c, err = d.SnapshotCopy(ctx, s.ID, "c2", "", nil)
+	if assert.NoError(t, err) {
+		assert.True(t, c.Encrypted)
+	}
+	if assert.Len(t, fake.copies, 3) {
+		assert.Equal(t, d.kmsKeyID, fake.copies[2]["kmsKeyId"])
+	}
+}
+
+func TestVolumeCreateFromSnapshot(t *testing.T) {
+	d, ctx, _ := newTestDriver(t, "")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// the volume has the snapshot's labels
+	nv, err := d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v1", &types.VolumeCreateOpts{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"env": "test"}, nv.Labels)
+	}
+
+	size, iops, typ := int64(20), int64(1000), "io1"
+	nv, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v2", &types.VolumeCreateOpts{
+			Size: &size,
+			IOPS: &iops,
+			Type: &typ,
+		})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(20), nv.Size)
+		assert.Equal(t, int64(1000), nv.IOPS)
+		assert.Equal(t, "io1", nv.Type)
+	}
+
+	// the name of a volume is unique
+	_, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v1", &types.VolumeCreateOpts{})
+	assert.Error(t, err)
+}
+
+func TestVolumeCopy(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, "")
+	v := newTestVolume(t, d, ctx, "v0")
+
+	nv, err := d.VolumeCopy(ctx, v.ID, "v1", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, v.Type, nv.Type)
+	assert.Equal(t, v.AvailabilityZone, nv.AvailabilityZone)
+	assert.Equal(t, v.Labels, nv.Labels)
+
+	// the temporary snapshot is removed
+	assert.Empty(t, fake.snapshots)
+
+	_, err = d.VolumeCopy(ctx, "vol-missing", "v2", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+}
+
+func newTestSuiteDriver(t *testing.T) *drivertest.Driver {
+	d, ctx, _ := newTestDriver(t, "")
+	return &drivertest.Driver{
+		StorageDriver:   d,
+		Context:         ctx,
+		VolumeSize:      8,
+		MissingVolumeID: "vol-missing",
+	}
+}
+
+func TestDriverSuite(t *testing.T) {
+	drivertest.Run(t, newTestSuiteDriver)
+}
@@ -247,6 +247,12 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+// Capabilities returns the capabilities the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(types.DriverCapabilityEncryption), nil
+}
+
 // InstanceInspect returns an instance.
 func (d *driver) InstanceInspect(
 	ctx types.Context,
@@ -65,6 +65,10 @@ const (
 	// incoming requests that have names with underscores should be
 	// converted to dashes to satisfy GCE naming requirements
 	ConfigConvertUnderscores = Name + ".convertUnderscores"
+
+	// VolumeCreateOptsReplicaZones is the key for the volume create option
+	// that lists the two zones to which a regional disk is replicated
+	VolumeCreateOptsReplicaZones = "replicaZones"
 )
 
 func init() {
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
@@ -147,8 +148,16 @@ func (d *driver) Init(context types.Context, config gofig.Config) error {
 	return nil
 }
 
+// session is a GCE service connection along with the HTTP client it was
+// created with. The client is used for requests the compute package does
+// not yet model.
+type session struct {
+	*compute.Service
+	client *http.Client
+}
+
 var (
-	sessions  = map[string]*compute.Service{}
+	sessions  = map[string]*session{}
 	sessionsL = &sync.Mutex{}
 )
 
@@ -228,9 +237,9 @@ func (d *driver) Login(ctx types.Context) (interface{}, error) {
 
 	}
 
-	sessions[ckey] = svc
+	sessions[ckey] = &session{Service: svc, client: client}
 	ctx.Info("GCE service connection created and cached")
-	return svc, nil
+	return sessions[ckey], nil
 }
 
 // NextDeviceInfo returns the information about the driver's next available
@@ -245,6 +254,17 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+// Capabilities returns the capabilities the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(
+		types.DriverCapabilitySnapshots,
+		types.DriverCapabilityCopy,
+		types.DriverCapabilityCreateFromSnapshot,
+		types.DriverCapabilityAvailabilityZones,
+	), nil
+}
+
 // InstanceInspect returns an instance.
 func (d *driver) InstanceInspect(
 	ctx types.Context,
@@ -358,6 +378,17 @@ func (d *driver) VolumeCreate(
 	volumeName string,
 	opts *types.VolumeCreateOpts) (*types.Volume, error) {
 
+	return d.volumeCreate(ctx, volumeName, nil, opts)
+}
+
+// volumeCreate creates a new volume, optionally from a snapshot or as a
+// clone of another disk.
+func (d *driver) volumeCreate(
+	ctx types.Context,
+	volumeName string,
+	source *diskSource,
+	opts *types.VolumeCreateOpts) (*types.Volume, error) {
+
 	fields := map[string]interface{}{
 		"driverName": d.Name(),
 		"volumeName": volumeName,
@@ -420,7 +451,7 @@ func (d *driver) VolumeCreate(
 			"volume name already exists")
 	}
 
-	err = d.createVolume(ctx, &volumeName, opts)
+	err = d.createVolume(ctx, &volumeName, source, opts)
 	if err != nil {
 		return nil, goof.WithFieldsE(
 			fields, "error creating volume", err)
@@ -440,16 +471,90 @@ func (d *driver) VolumeCreateFromSnapshot(
 	snapshotID, volumeName string,
 	opts *types.VolumeCreateOpts) (*types.Volume, error) {
 
-	return nil, types.ErrNotImplemented
+	snapshot, err := d.SnapshotInspect(ctx, snapshotID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The defaults are applied to a copy of the options so the caller's
+	// options are not modified.
+	copts := &types.VolumeCreateOpts{}
+	if opts != nil {
+		*copts = *opts
+	}
+
+	if copts.Size == nil {
+		size := snapshot.VolumeSize
+		copts.Size = &size
+	} else if *copts.Size < snapshot.VolumeSize {
+		return nil, goof.WithFields(goof.Fields{
+			"size":         *copts.Size,
+			"snapshotSize": snapshot.VolumeSize,
+		}, "volume size smaller than snapshot")
+	}
+
+	// Default to the labels of the snapshot
+	if copts.Labels == nil {
+		copts.Labels = snapshot.Labels
+	}
+
+	return d.volumeCreate(ctx, volumeName,
+		&diskSource{
+			snapshot: fmt.Sprintf("global/snapshots/%s", snapshot.ID),
+		},
+		copts,
+	)
 }
 
-// VolumeCopy copies an existing volume.
+// VolumeCopy copies an existing volume by cloning its disk.
 func (d *driver) VolumeCopy(
 	ctx types.Context,
 	volumeID, volumeName string,
 	opts types.Store) (*types.Volume, error) {
 
-	return nil, types.ErrNotImplemented
+	zone, err := d.validZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if zone == nil || *zone == "" {
+		return nil, goof.New("Zone is required for VolumeCopy")
+	}
+
+	gceDisk, err := d.getDisk(ctx, zone, &volumeID)
+	if err != nil {
+		return nil, goof.WithError(
+			"Unable to get disk from GCE API", err)
+	}
+	if gceDisk == nil {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
+
+	var (
+		size     = gceDisk.SizeGb
+		diskType = utils.GetIndex(gceDisk.Type)
+		store    = apiUtils.NewStore()
+	)
+
+	// A clone of a regional disk is replicated to the same zones
+	if len(gceDisk.ReplicaZones) > 0 {
+		replicaZones := make([]string, len(gceDisk.ReplicaZones))
+		for i, z := range gceDisk.ReplicaZones {
+			replicaZones[i] = utils.GetIndex(z)
+		}
+		store.Set(gcepd.VolumeCreateOptsReplicaZones, replicaZones)
+	}
+
+	return d.volumeCreate(ctx, volumeName,
+		&diskSource{disk: gceDisk.SelfLink},
+		&types.VolumeCreateOpts{
+			AvailabilityZone: zone,
+			Size:             &size,
+			Type:             &diskType,
+			Labels:           getResourceLabels(gceDisk.Labels),
+			Opts:             store,
+		},
+	)
 }
 
 // VolumeSnapshot snapshots a volume.
@@ -458,7 +563,85 @@ func (d *driver) VolumeSnapshot(
 	volumeID, snapshotName string,
 	opts types.Store) (*types.Snapshot, error) {
 
-	return nil, types.ErrNotImplemented
+	zone, err := d.validZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if zone == nil || *zone == "" {
+		return nil, goof.New("Zone is required for VolumeSnapshot")
+	}
+
+	gceDisk, err := d.getDisk(ctx, zone, &volumeID)
+	if err != nil {
+		return nil, goof.WithError(
+			"Unable to get disk from GCE API", err)
+	}
+	if gceDisk == nil {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
+
+	snapshotName = d.convUnderscores(snapshotName)
+	fields := map[string]interface{}{
+		"volumeID":     volumeID,
+		"snapshotName": snapshotName,
+	}
+	// Snapshot names follow the same rules as disk names
+	if !utils.IsValidDiskName(&snapshotName) {
+		return nil, goof.WithFields(fields,
+			"Snapshot name does not meet GCE naming requirements")
+	}
+
+	// Snapshot names are unique across the project
+	gceSnapshot, err := d.getSnapshot(ctx, &snapshotName)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"error querying for existing snapshot", err)
+	}
+	if gceSnapshot != nil {
+		return nil, goof.WithFields(fields,
+			"snapshot name already exists")
+	}
+
+	// The snapshot receives the volume's labels unless the request
+	// provides its own
+	labels := getResourceLabels(gceDisk.Labels)
+	if opts != nil {
+		if v := opts.GetStringMap("labels"); v != nil {
+			labels = v
+		}
+	}
+
+	ctx.WithFields(fields).Debug("creating snapshot")
+
+	createSnapshot := &compute.Snapshot{Name: snapshotName}
+	var asyncOp *compute.Operation
+	if gceDisk.Region != "" {
+		asyncOp, err = mustSession(ctx).RegionDisks.CreateSnapshot(
+			*d.projectID, utils.GetIndex(gceDisk.Region), gceDisk.Name,
+			createSnapshot).Do()
+	} else {
+		asyncOp, err = mustSession(ctx).Disks.CreateSnapshot(
+			*d.projectID, utils.GetIndex(gceDisk.Zone), gceDisk.Name,
+			createSnapshot).Do()
+	}
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"Failed to initiate snapshot creation", err)
+	}
+
+	err = d.waitUntilOperationIsFinished(ctx, asyncOp)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.labelSnapshot(ctx, &snapshotName, labels)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"error labeling snapshot", err)
+	}
+
+	return d.SnapshotInspect(ctx, snapshotName, nil)
 }
 
 // VolumeRemove removes a volume.
@@ -476,15 +659,28 @@ func (d *driver) VolumeRemove(
 		return goof.New("Zone is required for VolumeRemove")
 	}
 
+	gceDisk, err := d.getDisk(ctx, zone, &volumeID)
+	if err != nil {
+		return goof.WithError("Unable to get disk from GCE API", err)
+	}
+	if gceDisk == nil {
+		return apiUtils.NewNotFoundError(volumeID)
+	}
+
 	// TODO: check if disk is still attached first
-	asyncOp, err := mustSession(ctx).Disks.Delete(
-		*d.projectID, *zone, volumeID).Do()
+	var asyncOp *compute.Operation
+	if gceDisk.Region != "" {
+		asyncOp, err = mustSession(ctx).RegionDisks.Delete(
+			*d.projectID, utils.GetIndex(gceDisk.Region), volumeID).Do()
+	} else {
+		asyncOp, err = mustSession(ctx).Disks.Delete(
+			*d.projectID, *zone, volumeID).Do()
+	}
 	if err != nil {
 		return goof.WithError("Failed to initiate disk deletion", err)
 	}
 
-	err = d.waitUntilOperationIsFinished(
-		ctx, zone, asyncOp)
+	err = d.waitUntilOperationIsFinished(ctx, asyncOp)
 	if err != nil {
 		return err
 	}
@@ -540,7 +736,7 @@ func (d *driver) VolumeAttach(
 		}
 	}
 
-	err = d.attachVolume(ctx, &instanceName, zone, &volumeID)
+	err = d.attachVolume(ctx, &instanceName, zone, gceDisk, opts.ReadOnly)
 	if err != nil {
 		return nil, "", err
 	}
@@ -609,7 +805,27 @@ func (d *driver) Snapshots(
 	ctx types.Context,
 	opts types.Store) ([]*types.Snapshot, error) {
 
-	return nil, types.ErrNotImplemented
+	snapListQ := mustSession(ctx).Snapshots.List(*d.projectID)
+	if d.tag != "" {
+		filter := d.tagFilter()
+		ctx.Debugf("query filter: %s", filter)
+		snapListQ.Filter(filter)
+	}
+
+	snapshots := []*types.Snapshot{}
+	err := snapListQ.Pages(ctx, func(snapList *compute.SnapshotList) error {
+		for _, snapshot := range snapList.Items {
+			snapshots = append(snapshots, toTypeSnapshot(snapshot))
+		}
+		return nil
+	})
+	if err != nil {
+		ctx.Errorf("Error listing snapshots: %s", err)
+		return nil, goof.WithError(
+			"Unable to get snapshots from GCE API", err)
+	}
+
+	return snapshots, nil
 }
 
 // SnapshotInspect inspects a single snapshot.
@@ -618,10 +834,20 @@ func (d *driver) SnapshotInspect(
 	snapshotID string,
 	opts types.Store) (*types.Snapshot, error) {
 
-	return nil, types.ErrNotImplemented
+	gceSnapshot, err := d.getSnapshot(ctx, &snapshotID)
+	if err != nil {
+		return nil, goof.WithError(
+			"Unable to get snapshot from GCE API", err)
+	}
+	if gceSnapshot == nil || !d.isTagged(gceSnapshot.Labels) {
+		return nil, apiUtils.NewNotFoundError(snapshotID)
+	}
+
+	return toTypeSnapshot(gceSnapshot), nil
 }
 
-// SnapshotCopy copies an existing snapshot.
+// SnapshotCopy copies an existing snapshot. GCE snapshots are global
+// resources, so there is no other location to copy them to.
 func (d *driver) SnapshotCopy(
 	ctx types.Context,
 	snapshotID, snapshotName, destinationID string,
@@ -636,7 +862,22 @@ func (d *driver) SnapshotRemove(
 	snapshotID string,
 	opts types.Store) error {
 
-	return types.ErrNotImplemented
+	gceSnapshot, err := d.getSnapshot(ctx, &snapshotID)
+	if err != nil {
+		return goof.WithError("Unable to get snapshot from GCE API", err)
+	}
+	if gceSnapshot == nil || !d.isTagged(gceSnapshot.Labels) {
+		return apiUtils.NewNotFoundError(snapshotID)
+	}
+
+	asyncOp, err := mustSession(ctx).Snapshots.Delete(
+		*d.projectID, snapshotID).Do()
+	if err != nil {
+		return goof.WithError(
+			"Failed to initiate snapshot deletion", err)
+	}
+
+	return d.waitUntilOperationIsFinished(ctx, asyncOp)
 }
 
 ///////////////////////////////////////////////////////////////////////
@@ -693,8 +934,8 @@ func getClientZone(ctx types.Context) (*string, bool) {
 
 }
 
-func mustSession(ctx types.Context) *compute.Service {
-	return context.MustSession(ctx).(*compute.Service)
+func mustSession(ctx types.Context) *session {
+	return context.MustSession(ctx).(*session)
 }
 
 func (d *driver) getDisks(
@@ -702,10 +943,13 @@ func (d *driver) getDisks(
 	zone *string) ([]*compute.Disk, error) {
 
 	diskListQ := mustSession(ctx).Disks.List(*d.projectID, *zone)
+	regionListQ := mustSession(ctx).RegionDisks.List(
+		*d.projectID, getRegion(*zone))
 	if d.tag != "" {
-		filter := fmt.Sprintf("labels.%s eq %s", tagKey, d.tag)
+		filter := d.tagFilter()
 		ctx.Debugf("query filter: %s", filter)
 		diskListQ.Filter(filter)
+		regionListQ.Filter(filter)
 	}
 
 	diskList, err := diskListQ.Do()
@@ -714,7 +958,20 @@ func (d *driver) getDisks(
 		return nil, err
 	}
 
-	return diskList.Items, nil
+	regionList, err := regionListQ.Do()
+	if err != nil {
+		ctx.Errorf("Error listing regional disks: %s", err)
+		return nil, err
+	}
+
+	disks := diskList.Items
+	for _, disk := range regionList.Items {
+		if isReplicatedTo(disk, *zone) {
+			disks = append(disks, disk)
+		}
+	}
+
+	return disks, nil
 }
 
 func (d *driver) getAggregatedDisks(
@@ -722,7 +979,7 @@ func (d *driver) getAggregatedDisks(
 
 	aggListQ := mustSession(ctx).Disks.AggregatedList(*d.projectID)
 	if d.tag != "" {
-		filter := fmt.Sprintf("labels.%s eq %s", tagKey, d.tag)
+		filter := d.tagFilter()
 		ctx.Debugf("query filter: %s", filter)
 		aggListQ.Filter(filter)
 	}
@@ -750,19 +1007,48 @@ func (d *driver) getDisk(
 	name *string) (*compute.Disk, error) {
 
 	disk, err := mustSession(ctx).Disks.Get(*d.projectID, *zone, *name).Do()
+	if err == nil {
+		return disk, nil
+	}
+	if !isNotFound(err) {
+		ctx.Errorf("Error getting disk: %s", err)
+		return nil, err
+	}
+
+	// The disk may be a regional disk that is replicated to the zone
+	disk, err = mustSession(ctx).RegionDisks.Get(
+		*d.projectID, getRegion(*zone), *name).Do()
 	if err != nil {
-		if apiE, ok := err.(*googleapi.Error); ok {
-			if apiE.Code == 404 {
-				return nil, nil
-			}
+		if isNotFound(err) {
+			return nil, nil
 		}
-		ctx.Errorf("Error getting disk: %s", err)
+		ctx.Errorf("Error getting regional disk: %s", err)
 		return nil, err
 	}
+	if !isReplicatedTo(disk, *zone) {
+		return nil, nil
+	}
 
 	return disk, nil
 }
 
+func (d *driver) getSnapshot(
+	ctx types.Context,
+	name *string) (*compute.Snapshot, error) {
+
+	snapshot, err := mustSession(ctx).Snapshots.Get(
+		*d.projectID, *name).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		ctx.Errorf("Error getting snapshot: %s", err)
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
 func (d *driver) getInstance(
 	ctx types.Context,
 	zone *string,
@@ -770,10 +1056,8 @@ func (d *driver) getInstance(
 
 	inst, err := mustSession(ctx).Instances.Get(*d.projectID, *zone, *name).Do()
 	if err != nil {
-		if apiE, ok := err.(*googleapi.Error); ok {
-			if apiE.Code == 404 {
-				return nil, nil
-			}
+		if isNotFound(err) {
+			return nil, nil
 		}
 		ctx.Errorf("Error getting instance: %s", err)
 		return nil, err
@@ -813,6 +1097,20 @@ func (d *driver) toTypeVolume(
 			Status:           disk.Status,
 			Type:             utils.GetIndex(disk.Type),
 			Size:             disk.SizeGb,
+			Labels:           getResourceLabels(disk.Labels),
+		}
+
+		// A regional disk is in its region rather than a zone
+		if len(disk.ReplicaZones) > 0 {
+			volume.AvailabilityZone = utils.GetIndex(disk.Region)
+			replicaZones := make([]string, len(disk.ReplicaZones))
+			for i, z := range disk.ReplicaZones {
+				replicaZones[i] = utils.GetIndex(z)
+			}
+			volume.Fields = map[string]string{
+				gcepd.VolumeCreateOptsReplicaZones: strings.Join(
+					replicaZones, ","),
+			}
 		}
 
 		if attachments.Requested() {
@@ -876,9 +1174,16 @@ func getAttachment(
 	return volAttachments
 }
 
+// diskSource is the snapshot or disk from which a new disk is created.
+type diskSource struct {
+	snapshot string
+	disk     string
+}
+
 func (d *driver) createVolume(
 	ctx types.Context,
 	volumeName *string,
+	source *diskSource,
 	opts *types.VolumeCreateOpts) error {
 
 	diskType := d.defaultDiskType
@@ -889,44 +1194,69 @@ func (d *driver) createVolume(
 			diskType = gcepd.DiskTypeStandard
 		}
 	}
-	diskTypeURI := fmt.Sprintf("zones/%s/diskTypes/%s",
-		*opts.AvailabilityZone, diskType)
+
+	replicaZones, err := getReplicaZones(*opts.AvailabilityZone, opts.Opts)
+	if err != nil {
+		return err
+	}
+
+	// A regional disk is created in the region of its replica zones
+	scope := fmt.Sprintf("zones/%s", *opts.AvailabilityZone)
+	if len(replicaZones) > 0 {
+		scope = fmt.Sprintf("regions/%s", getRegion(*opts.AvailabilityZone))
+	}
 
 	createDisk := &compute.Disk{
 		Name:   *volumeName,
 		SizeGb: *opts.Size,
-		Type:   diskTypeURI,
+		Type:   fmt.Sprintf("%s/diskTypes/%s", scope, diskType),
+	}
+	for _, z := range replicaZones {
+		createDisk.ReplicaZones = append(
+			createDisk.ReplicaZones, fmt.Sprintf("zones/%s", z))
 	}
 
-	asyncOp, err := mustSession(ctx).Disks.Insert(
-		*d.projectID, *opts.AvailabilityZone, createDisk).Do()
+	var sourceDisk string
+	if source != nil {
+		createDisk.SourceSnapshot = source.snapshot
+		sourceDisk = source.disk
+	}
+
+	asyncOp, err := d.insertDisk(ctx, scope, createDisk, sourceDisk)
 	if err != nil {
 		return goof.WithError("Failed to initiate disk creation", err)
 	}
 
-	err = d.waitUntilOperationIsFinished(
-		ctx, opts.AvailabilityZone, asyncOp)
+	err = d.waitUntilOperationIsFinished(ctx, asyncOp)
 	if err != nil {
 		return err
 	}
 
-	if d.tag != "" {
+	if labels := getLabels(&d.tag, opts.Labels); len(labels) > 0 {
 		/* In order to set the labels on a disk, we have to query the
 		   disk first in order to get the generated label fingerprint
 		*/
 		disk, err := d.getDisk(ctx, opts.AvailabilityZone, volumeName)
-		if err != nil {
+		if err != nil || disk == nil {
 			ctx.WithError(err).Warn(
 				"Unable to query disk for labeling")
 			return nil
 		}
-		labels := getLabels(&d.tag)
-		_, err = mustSession(ctx).Disks.SetLabels(
-			*d.projectID, *opts.AvailabilityZone, *volumeName,
-			&compute.ZoneSetLabelsRequest{
-				Labels:           labels,
-				LabelFingerprint: disk.LabelFingerprint,
-			}).Do()
+		if disk.Region != "" {
+			_, err = mustSession(ctx).RegionDisks.SetLabels(
+				*d.projectID, utils.GetIndex(disk.Region), *volumeName,
+				&compute.RegionSetLabelsRequest{
+					Labels:           labels,
+					LabelFingerprint: disk.LabelFingerprint,
+				}).Do()
+		} else {
+			_, err = mustSession(ctx).Disks.SetLabels(
+				*d.projectID, *opts.AvailabilityZone, *volumeName,
+				&compute.ZoneSetLabelsRequest{
+					Labels:           labels,
+					LabelFingerprint: disk.LabelFingerprint,
+				}).Do()
+		}
 		if err != nil {
 			ctx.WithError(err).Warn("Unable to label disk")
 		}
@@ -935,18 +1265,128 @@ func (d *driver) createVolume(
 	return nil
 }
 
+// insertDisk creates a disk in the given zone or region scope. The
+// vendored compute API predates the sourceDisk field used to clone a disk,
+// so a clone is requested directly with the session's HTTP client.
+func (d *driver) insertDisk(
+	ctx types.Context,
+	scope string,
+	disk *compute.Disk,
+	sourceDisk string) (*compute.Operation, error) {
+
+	svc := mustSession(ctx)
+
+	if sourceDisk == "" {
+		if strings.HasPrefix(scope, "regions/") {
+			return svc.RegionDisks.Insert(
+				*d.projectID, utils.GetIndex(scope), disk).Do()
+		}
+		return svc.Disks.Insert(
+			*d.projectID, utils.GetIndex(scope), disk).Do()
+	}
+
+	buf, err := json.Marshal(disk)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(buf, &body); err != nil {
+		return nil, err
+	}
+	body["sourceDisk"] = sourceDisk
+	if buf, err = json.Marshal(body); err != nil {
+		return nil, err
+	}
+
+	url := googleapi.ResolveRelative(svc.BasePath,
+		fmt.Sprintf("%s/%s/disks?alt=json", *d.projectID, scope))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", svc.UserAgent)
+
+	res, err := svc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return nil, err
+	}
+
+	op := &compute.Operation{}
+	if err := json.NewDecoder(res.Body).Decode(op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// labelSnapshot applies the driver's tag and the given labels to a
+// snapshot.
+func (d *driver) labelSnapshot(
+	ctx types.Context,
+	snapshotName *string,
+	snapshotLabels map[string]string) error {
+
+	labels := getLabels(&d.tag, snapshotLabels)
+	if len(labels) == 0 {
+		return nil
+	}
+
+	/* In order to set the labels on a snapshot, we have to query the
+	   snapshot first in order to get the generated label fingerprint
+	*/
+	snapshot, err := d.getSnapshot(ctx, snapshotName)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return apiUtils.NewNotFoundError(*snapshotName)
+	}
+
+	asyncOp, err := mustSession(ctx).Snapshots.SetLabels(
+		*d.projectID, *snapshotName,
+		&compute.GlobalSetLabelsRequest{
+			Labels:           labels,
+			LabelFingerprint: snapshot.LabelFingerprint,
+		}).Do()
+	if err != nil {
+		return err
+	}
+
+	return d.waitUntilOperationIsFinished(ctx, asyncOp)
+}
+
 func (d *driver) waitUntilOperationIsFinished(
 	ctx types.Context,
-	zone *string,
 	operation *compute.Operation) error {
 
-	opName := operation.Name
+	var (
+		opName = operation.Name
+		svc    = mustSession(ctx)
+		getOp  func(...googleapi.CallOption) (*compute.Operation, error)
+	)
+
+	// Operations are scoped to the zone, region, or project of the
+	// resource they act upon
+	switch {
+	case operation.Zone != "":
+		getOp = svc.ZoneOperations.Get(
+			*d.projectID, utils.GetIndex(operation.Zone), opName).Do
+	case operation.Region != "":
+		getOp = svc.RegionOperations.Get(
+			*d.projectID, utils.GetIndex(operation.Region), opName).Do
+	default:
+		getOp = svc.GlobalOperations.Get(*d.projectID, opName).Do
+	}
+
 	f := func() (interface{}, error) {
 		duration := d.statusDelay
 		for i := 1; i <= d.maxAttempts; i++ {
 
-			op, err := mustSession(ctx).ZoneOperations.Get(
-				*d.projectID, *zone, opName).Do()
+			op, err := getOp()
 			if err != nil {
 				return nil, err
 			}
@@ -991,13 +1431,17 @@ func (d *driver) attachVolume(
 	ctx types.Context,
 	instanceID *string,
 	zone *string,
-	volumeName *string) error {
+	gceDisk *compute.Disk,
+	readOnly bool) error {
 
 	disk := &compute.AttachedDisk{
 		AutoDelete: false,
 		Boot:       false,
-		Source:     fmt.Sprintf("zones/%s/disks/%s", *zone, *volumeName),
-		DeviceName: *volumeName,
+		Source:     gceDisk.SelfLink,
+		DeviceName: gceDisk.Name,
+	}
+	if readOnly {
+		disk.Mode = "READ_ONLY"
 	}
 
 	asyncOp, err := mustSession(ctx).Instances.AttachDisk(
@@ -1006,7 +1450,7 @@ func (d *driver) attachVolume(
 		return err
 	}
 
-	err = d.waitUntilOperationIsFinished(ctx, zone, asyncOp)
+	err = d.waitUntilOperationIsFinished(ctx, asyncOp)
 	if err != nil {
 		return err
 	}
@@ -1045,9 +1489,11 @@ func (d *driver) detachVolume(
 	var ops = make([]*compute.Operation, 0)
 	var asyncErr error
 
-	zone := utils.GetIndex(gceDisk.Zone)
-
 	for _, user := range gceDisk.Users {
+		// The zone of the instance is taken from its link since a
+		// regional disk may be attached to instances in either of its
+		// replica zones
+		zone := getLinkZone(user)
 		instanceName := utils.GetIndex(user)
 		devName, err := d.getAttachedDeviceName(ctx, &zone, &instanceName,
 			&gceDisk.SelfLink)
@@ -1066,8 +1512,7 @@ func (d *driver) detachVolume(
 
 	if len(ops) > 0 {
 		for _, op := range ops {
-			err := d.waitUntilOperationIsFinished(ctx,
-				&zone, op)
+			err := d.waitUntilOperationIsFinished(ctx, op)
 			if err != nil {
 				return err
 			}
@@ -1084,10 +1529,134 @@ func (d *driver) convUnderscores(name string) string {
 	return name
 }
 
-func getLabels(tag *string) map[string]string {
-	labels := map[string]string{
-		tagKey: *tag,
+func getLabels(tag *string, volLabels map[string]string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range volLabels {
+		labels[k] = v
+	}
+	if *tag != "" {
+		labels[tagKey] = *tag
 	}
 
 	return labels
 }
+
+// getResourceLabels returns a disk's or snapshot's labels, omitting the
+// label used to tag the resources that belong to the driver.
+func getResourceLabels(resLabels map[string]string) map[string]string {
+	var labels map[string]string
+	for k, v := range resLabels {
+		if k == tagKey {
+			continue
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[k] = v
+	}
+	return labels
+}
+
+// tagFilter returns the list filter for the resources with the driver's tag.
+func (d *driver) tagFilter() string {
+	return fmt.Sprintf("labels.%s eq %s", tagKey, d.tag)
+}
+
+// isTagged returns whether a resource with the given labels belongs to the
+// driver.
+func (d *driver) isTagged(labels map[string]string) bool {
+	return d.tag == "" || labels[tagKey] == d.tag
+}
+
+func isNotFound(err error) bool {
+	apiE, ok := err.(*googleapi.Error)
+	return ok && apiE.Code == http.StatusNotFound
+}
+
+// getRegion returns the name of the region that contains the given zone.
+func getRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// getLinkZone returns the name of the zone in a resource link such as
+// .../zones/us-central1-a/instances/myinstance.
+func getLinkZone(link string) string {
+	parts := strings.Split(link, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "zones" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// isReplicatedTo returns whether a regional disk has a replica in the
+// given zone.
+func isReplicatedTo(disk *compute.Disk, zone string) bool {
+	for _, z := range disk.ReplicaZones {
+		if utils.GetIndex(z) == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// getReplicaZones returns the zones a new disk should be replicated to, if
+// any. The zones must include the zone in which the disk is created.
+func getReplicaZones(zone string, opts types.Store) ([]string, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
+	replicaZones := opts.GetStringSlice(gcepd.VolumeCreateOptsReplicaZones)
+	if len(replicaZones) == 0 {
+		v := opts.GetString(gcepd.VolumeCreateOptsReplicaZones)
+		if v == "" {
+			return nil, nil
+		}
+		for _, z := range strings.Split(v, ",") {
+			replicaZones = append(replicaZones, strings.TrimSpace(z))
+		}
+	}
+
+	fields := goof.Fields{
+		"zone":         zone,
+		"replicaZones": replicaZones,
+	}
+	found := false
+	for _, z := range replicaZones {
+		if getRegion(z) != getRegion(zone) {
+			return nil, goof.WithFields(fields,
+				"replica zones must be in the same region")
+		}
+		if z == zone {
+			found = true
+		}
+	}
+	if !found {
+		return nil, goof.WithFields(fields,
+			"replica zones must include the volume's zone")
+	}
+
+	return replicaZones, nil
+}
+
+func toTypeSnapshot(snapshot *compute.Snapshot) *types.Snapshot {
+	s := &types.Snapshot{
+		Name:        snapshot.Name,
+		ID:          snapshot.Name,
+		Description: snapshot.Description,
+		VolumeID:    utils.GetIndex(snapshot.SourceDisk),
+		VolumeSize:  snapshot.DiskSizeGb,
+		Status:      snapshot.Status,
+		Labels:      getResourceLabels(snapshot.Labels),
+	}
+	t, err := time.Parse(time.RFC3339, snapshot.CreationTimestamp)
+	if err == nil {
+		s.StartTime = t.Unix()
+	}
+	return s
+}
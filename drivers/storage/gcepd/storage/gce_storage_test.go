@@ -0,0 +1,586 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v0.beta"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/tests/drivertest"
+	"github.com/codedellemc/libstorage/api/types"
+	apiUtils "github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/drivers/storage/gcepd"
+)
+
+const (
+	testProject  = "test-project"
+	testRegion   = "us-central1"
+	testZone     = "us-central1-a"
+	testZone2    = "us-central1-b"
+	testInstance = "instance-1"
+	testTag      = "lstest"
+
+	// fakeSnapshotPageSize is the number of snapshots the fake compute API
+	// returns per page so the tests exercise paging.
+	fakeSnapshotPageSize = 2
+)
+
+// fakeCompute is an in-memory stand-in for the GCE compute API that
+// handles the requests the driver makes for disks and snapshots.
+type fakeCompute struct {
+	sync.Mutex
+	url        string
+	opID       int
+	disks      map[string]*compute.Disk
+	snapshots  map[string]*compute.Snapshot
+	sourceDisk map[string]string
+}
+
+func newFakeCompute() *fakeCompute {
+	return &fakeCompute{
+		disks:      map[string]*compute.Disk{},
+		snapshots:  map[string]*compute.Snapshot{},
+		sourceDisk: map[string]string{},
+	}
+}
+
+func (f *fakeCompute) link(parts ...string) string {
+	return fmt.Sprintf("%s/%s/%s", f.url, testProject, strings.Join(parts, "/"))
+}
+
+func (f *fakeCompute) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != testProject {
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+		return
+	}
+	parts = parts[1:]
+
+	switch {
+	case parts[len(parts)-2] == "operations":
+		f.writeJSON(w, &compute.Operation{
+			Name:   parts[len(parts)-1],
+			Status: "DONE",
+		})
+	case parts[0] == "global" && parts[1] == "snapshots":
+		f.serveSnapshots(w, req, parts[2:])
+	case parts[2] == "disks":
+		f.serveDisks(w, req, parts[0], parts[1], parts[3:])
+	default:
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+	}
+}
+
+func (f *fakeCompute) serveDisks(
+	w http.ResponseWriter,
+	req *http.Request,
+	scopeType, scope string,
+	parts []string) {
+
+	// disks are keyed by their scope and name
+	key := func(name string) string {
+		return fmt.Sprintf("%s/%s/%s", scopeType, scope, name)
+	}
+
+	if len(parts) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			list := &compute.DiskList{}
+			for _, k := range f.sortedDiskKeys() {
+				if !strings.HasPrefix(k, key("")) {
+					continue
+				}
+				if disk := f.disks[k]; f.filter(req, disk.Labels) {
+					list.Items = append(list.Items, disk)
+				}
+			}
+			f.writeJSON(w, list)
+		case http.MethodPost:
+			f.insertDisk(w, req, scopeType, scope, key)
+		}
+		return
+	}
+
+	disk, ok := f.disks[key(parts[0])]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, parts[0])
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && req.Method == http.MethodGet:
+		f.writeJSON(w, disk)
+	case len(parts) == 1 && req.Method == http.MethodDelete:
+		delete(f.disks, key(parts[0]))
+		f.writeOperation(w, scopeType, scope)
+	case parts[1] == "setLabels":
+		body := &compute.ZoneSetLabelsRequest{}
+		if !f.readJSON(w, req, body) {
+			return
+		}
+		if body.LabelFingerprint != disk.LabelFingerprint {
+			f.writeError(w, http.StatusPreconditionFailed, "fingerprint")
+			return
+		}
+		disk.Labels = body.Labels
+		disk.LabelFingerprint = f.nextFingerprint()
+		f.writeOperation(w, scopeType, scope)
+	case parts[1] == "createSnapshot":
+		body := &compute.Snapshot{}
+		if !f.readJSON(w, req, body) {
+			return
+		}
+		if _, ok := f.snapshots[body.Name]; ok {
+			f.writeError(w, http.StatusConflict, body.Name)
+			return
+		}
+		f.snapshots[body.Name] = &compute.Snapshot{
+			Name:              body.Name,
+			SelfLink:          f.link("global", "snapshots", body.Name),
+			SourceDisk:        disk.SelfLink,
+			DiskSizeGb:        disk.SizeGb,
+			Status:            "READY",
+			CreationTimestamp: time.Now().Format(time.RFC3339),
+			LabelFingerprint:  f.nextFingerprint(),
+		}
+		f.writeOperation(w, scopeType, scope)
+	default:
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+	}
+}
+
+func (f *fakeCompute) insertDisk(
+	w http.ResponseWriter,
+	req *http.Request,
+	scopeType, scope string,
+	key func(string) string) {
+
+	body := map[string]interface{}{}
+	buf, _ := ioutil.ReadAll(req.Body)
+	if err := json.Unmarshal(buf, &body); err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	disk := &compute.Disk{}
+	json.Unmarshal(buf, disk)
+
+	if _, ok := f.disks[key(disk.Name)]; ok {
+		f.writeError(w, http.StatusConflict, disk.Name)
+		return
+	}
+
+	if disk.SourceSnapshot != "" {
+		name := disk.SourceSnapshot[strings.LastIndex(
+			disk.SourceSnapshot, "/")+1:]
+		if _, ok := f.snapshots[name]; !ok {
+			f.writeError(w, http.StatusNotFound, disk.SourceSnapshot)
+			return
+		}
+	}
+	if v, ok := body["sourceDisk"].(string); ok {
+		found := false
+		for _, d := range f.disks {
+			found = found || d.SelfLink == v
+		}
+		if !found {
+			f.writeError(w, http.StatusNotFound, v)
+			return
+		}
+		f.sourceDisk[disk.Name] = v
+	}
+
+	disk.SelfLink = f.link(scopeType, scope, "disks", disk.Name)
+	disk.Status = "READY"
+	disk.LabelFingerprint = f.nextFingerprint()
+	disk.Type = fmt.Sprintf("%s/%s", f.url, disk.Type)
+	if scopeType == "zones" {
+		disk.Zone = f.link("zones", scope)
+	} else {
+		disk.Region = f.link("regions", scope)
+		for i, z := range disk.ReplicaZones {
+			disk.ReplicaZones[i] = f.link(z)
+		}
+	}
+	f.disks[key(disk.Name)] = disk
+	f.writeOperation(w, scopeType, scope)
+}
+
+func (f *fakeCompute) serveSnapshots(
+	w http.ResponseWriter,
+	req *http.Request,
+	parts []string) {
+
+	if len(parts) == 0 {
+		names := []string{}
+		for name, s := range f.snapshots {
+			if f.filter(req, s.Labels) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		start, _ := strconv.Atoi(req.URL.Query().Get("pageToken"))
+		end := start + fakeSnapshotPageSize
+		list := &compute.SnapshotList{}
+		if end < len(names) {
+			list.NextPageToken = strconv.Itoa(end)
+		} else {
+			end = len(names)
+		}
+		for _, name := range names[start:end] {
+			list.Items = append(list.Items, f.snapshots[name])
+		}
+		f.writeJSON(w, list)
+		return
+	}
+
+	snapshot, ok := f.snapshots[parts[0]]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, parts[0])
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && req.Method == http.MethodGet:
+		f.writeJSON(w, snapshot)
+	case len(parts) == 1 && req.Method == http.MethodDelete:
+		delete(f.snapshots, parts[0])
+		f.writeOperation(w, "global", "")
+	case parts[1] == "setLabels":
+		body := &compute.GlobalSetLabelsRequest{}
+		if !f.readJSON(w, req, body) {
+			return
+		}
+		if body.LabelFingerprint != snapshot.LabelFingerprint {
+			f.writeError(w, http.StatusPreconditionFailed, "fingerprint")
+			return
+		}
+		snapshot.Labels = body.Labels
+		snapshot.LabelFingerprint = f.nextFingerprint()
+		f.writeOperation(w, "global", "")
+	default:
+		f.writeError(w, http.StatusNotFound, req.URL.Path)
+	}
+}
+
+// filter returns whether labels match the request's label filter, which
+// the driver only ever sets to an equality test of a single label.
+func (f *fakeCompute) filter(req *http.Request, labels map[string]string) bool {
+	filter := req.URL.Query().Get("filter")
+	if filter == "" {
+		return true
+	}
+	var k, v string
+	fmt.Sscanf(filter, "labels.%s eq %s", &k, &v)
+	return labels[k] == v
+}
+
+func (f *fakeCompute) sortedDiskKeys() []string {
+	keys := []string{}
+	for k := range f.disks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeCompute) nextFingerprint() string {
+	f.opID++
+	return fmt.Sprintf("fp-%d", f.opID)
+}
+
+// writeOperation responds with a pending operation scoped like the real
+// API's, so the driver has to poll the matching operations resource.
+func (f *fakeCompute) writeOperation(
+	w http.ResponseWriter, scopeType, scope string) {
+
+	f.opID++
+	op := &compute.Operation{
+		Name:   fmt.Sprintf("operation-%d", f.opID),
+		Status: "PENDING",
+	}
+	switch scopeType {
+	case "zones":
+		op.Zone = f.link("zones", scope)
+	case "regions":
+		op.Region = f.link("regions", scope)
+	}
+	f.writeJSON(w, op)
+}
+
+func (f *fakeCompute) readJSON(
+	w http.ResponseWriter, req *http.Request, v interface{}) bool {
+
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func (f *fakeCompute) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (f *fakeCompute) writeError(
+	w http.ResponseWriter, code int, message string) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func newTestDriver(
+	t *testing.T, tag string) (*driver, types.Context, *fakeCompute) {
+
+	fake := newFakeCompute()
+	srv := httptest.NewServer(fake)
+	fake.url = srv.URL
+
+	keyFile, err := ioutil.TempFile("", "gcepd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(keyFile.Name())
+	fmt.Fprintf(keyFile, `{"project_id": %q}`, testProject)
+	keyFile.Close()
+
+	config := gofigCore.New()
+	config.Set(gcepd.ConfigKeyfile, keyFile.Name())
+	config.Set(gcepd.ConfigTag, tag)
+	config.Set(gcepd.ConfigStatusMaxAttempts, 5)
+	config.Set(gcepd.ConfigStatusInitDelay, "1ms")
+	config.Set(gcepd.ConfigStatusTimeout, "10s")
+
+	d := &driver{}
+	ctx := context.Background()
+	if err := d.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+
+	svc, err := compute.New(http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.BasePath = srv.URL + "/"
+
+	ctx = ctx.WithValue(context.InstanceIDKey, &types.InstanceID{
+		ID:     testInstance,
+		Driver: gcepd.Name,
+		Fields: map[string]string{
+			gcepd.InstanceIDFieldProjectID: testProject,
+			gcepd.InstanceIDFieldZone:      testZone,
+		},
+	})
+	ctx = ctx.WithValue(context.SessionKey, &session{
+		Service: svc,
+		client:  http.DefaultClient,
+	})
+
+	return d, ctx, fake
+}
+
+func newTestVolume(
+	t *testing.T,
+	d *driver,
+	ctx types.Context,
+	name string,
+	opts types.Store) *types.Volume {
+
+	size := int64(20)
+	v, err := d.VolumeCreate(ctx, name, &types.VolumeCreateOpts{
+		Size:   &size,
+		Labels: map[string]string{"env": "test"},
+		Opts:   opts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	d, ctx, _ := newTestDriver(t, testTag)
+	v := newTestVolume(t, d, ctx, "v0", nil)
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "s0", s.ID)
+	assert.Equal(t, "READY", s.Status)
+	assert.Equal(t, map[string]string{"env": "test"}, s.Labels)
+
+	// the name of a snapshot is unique
+	_, err = d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	assert.Error(t, err)
+
+	_, err = d.VolumeSnapshot(ctx, "missing", "s1", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+
+	s, err = d.VolumeSnapshot(ctx, v.ID, "s1",
+		apiUtils.NewStoreWithData(map[string]interface{}{
+			"labels": map[string]string{"app": "db"},
+		}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, map[string]string{"app": "db"}, s.Labels)
+}
+
+func TestSnapshotsTag(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, testTag)
+	v := newTestVolume(t, d, ctx, "v0", nil)
+
+	for i := 0; i < 5; i++ {
+		_, err := d.VolumeSnapshot(ctx, v.ID, fmt.Sprintf("s%d", i), nil)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+	}
+
+	// a snapshot that belongs to another tag
+	fake.snapshots["other"] = &compute.Snapshot{
+		Name:   "other",
+		Labels: map[string]string{tagKey: "other"},
+	}
+
+	snapshots, err := d.Snapshots(ctx, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, snapshots, 5)
+	for i, s := range snapshots {
+		assert.Equal(t, fmt.Sprintf("s%d", i), s.ID)
+		assert.NotContains(t, s.Labels, tagKey)
+	}
+
+	_, err = d.SnapshotInspect(ctx, "other", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+	assert.IsType(t, &types.ErrNotFound{}, d.SnapshotRemove(ctx, "other", nil))
+}
+
+func TestVolumeCreateFromSnapshot(t *testing.T) {
+	d, ctx, _ := newTestDriver(t, testTag)
+	v := newTestVolume(t, d, ctx, "v0", nil)
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	nv, err := d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v1", &types.VolumeCreateOpts{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "v1", nv.ID)
+	assert.Equal(t, testZone, nv.AvailabilityZone)
+	assert.Equal(t, map[string]string{"env": "test"}, nv.Labels)
+}
+
+func TestVolumeCopy(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, testTag)
+	v := newTestVolume(t, d, ctx, "v0", nil)
+
+	nv, err := d.VolumeCopy(ctx, v.ID, "v1", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "v1", nv.ID)
+	assert.Equal(t, v.Type, nv.Type)
+	assert.Equal(t, v.Labels, nv.Labels)
+	assert.Equal(t,
+		fake.link("zones", testZone, "disks", v.ID), fake.sourceDisk["v1"])
+
+	_, err = d.VolumeCopy(ctx, "missing", "v2", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+}
+
+func TestRegionalVolume(t *testing.T) {
+	d, ctx, fake := newTestDriver(t, testTag)
+
+	_, err := d.VolumeCreate(ctx, "v0", &types.VolumeCreateOpts{
+		Opts: apiUtils.NewStoreWithData(map[string]interface{}{
+			gcepd.VolumeCreateOptsReplicaZones: "us-central1-b,us-central1-c",
+		}),
+	})
+	assert.Error(t, err)
+
+	v := newTestVolume(t, d, ctx, "v0",
+		apiUtils.NewStoreWithData(map[string]interface{}{
+			gcepd.VolumeCreateOptsReplicaZones: testZone + "," + testZone2,
+		}))
+	assert.Equal(t, testRegion, v.AvailabilityZone)
+	assert.Equal(t, testZone+","+testZone2,
+		v.Fields[gcepd.VolumeCreateOptsReplicaZones])
+	assert.Equal(t, map[string]string{"env": "test"}, v.Labels)
+	assert.Contains(t, fake.disks, "regions/"+testRegion+"/v0")
+
+	vols, err := d.Volumes(ctx, &types.VolumesOpts{})
+	assert.NoError(t, err)
+	assert.Len(t, vols, 1)
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, v.ID, s.VolumeID)
+
+	nv, err := d.VolumeCopy(ctx, v.ID, "v1", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, testRegion, nv.AvailabilityZone)
+	assert.Equal(t, v.Fields, nv.Fields)
+
+	assert.NoError(t, d.VolumeRemove(ctx, v.ID, &types.VolumeRemoveOpts{}))
+	assert.NotContains(t, fake.disks, "regions/"+testRegion+"/v0")
+}
+
+func TestCapabilities(t *testing.T) {
+	d, ctx, _ := newTestDriver(t, "")
+
+	caps, err := d.Capabilities(ctx)
+	assert.NoError(t, err)
+	assert.True(t, caps[types.DriverCapabilityCopy])
+
+	// snapshots are global resources and cannot be copied
+	assert.False(t, caps[types.DriverCapabilitySnapshotCopy])
+}
+
+func newTestSuiteDriver(t *testing.T) *drivertest.Driver {
+	d, ctx, _ := newTestDriver(t, testTag)
+	return &drivertest.Driver{
+		StorageDriver:   d,
+		Context:         ctx,
+		VolumeSize:      20,
+		MissingVolumeID: "missing",
+	}
+}
+
+func TestDriverSuite(t *testing.T) {
+	drivertest.Run(t, newTestSuiteDriver)
+}
@@ -63,7 +63,8 @@ func (c *client) ServiceInspect(
 
 func (c *client) Volumes(
 	ctx types.Context,
-	attachments types.VolumeAttachmentsTypes) (types.ServiceVolumeMap, error) {
+	attachments types.VolumeAttachmentsTypes,
+	filter string) (types.ServiceVolumeMap, error) {
 
 	ctx = c.requireCtx(ctx)
 
@@ -73,13 +74,14 @@ func (c *client) Volumes(
 	}
 	ctx = c.withAllInstanceIDs(ctxA)
 
-	return c.APIClient.Volumes(ctx, attachments)
+	return c.APIClient.Volumes(ctx, attachments, filter)
 }
 
 func (c *client) VolumesByService(
 	ctx types.Context,
 	service string,
-	attachments types.VolumeAttachmentsTypes) (types.VolumeMap, error) {
+	attachments types.VolumeAttachmentsTypes,
+	filter string) (types.VolumeMap, error) {
 
 	ctx = c.withInstanceID(c.requireCtx(ctx), service)
 	ctxA, err := c.withAllLocalDevices(ctx)
@@ -88,7 +90,56 @@ func (c *client) VolumesByService(
 	}
 	ctx = ctxA
 
-	return c.APIClient.VolumesByService(ctx, service, attachments)
+	return c.APIClient.VolumesByService(ctx, service, attachments, filter)
+}
+
+func (c *client) VolumesPage(
+	ctx types.Context,
+	attachments types.VolumeAttachmentsTypes,
+	filter string,
+	page *types.PageOpts) (types.ServiceVolumeMap, string, error) {
+
+	ctx = c.requireCtx(ctx)
+
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx = c.withAllInstanceIDs(ctxA)
+
+	return c.APIClient.VolumesPage(ctx, attachments, filter, page)
+}
+
+func (c *client) VolumesByServicePage(
+	ctx types.Context,
+	service string,
+	attachments types.VolumeAttachmentsTypes,
+	filter string,
+	page *types.PageOpts) (types.VolumeMap, string, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumesByServicePage(
+		ctx, service, attachments, filter, page)
+}
+
+func (c *client) VolumePages(
+	ctx types.Context,
+	attachments types.VolumeAttachmentsTypes,
+	filter string,
+	limit int) *types.VolumePageIterator {
+
+	return types.NewVolumePageIterator(
+		func(token string) (types.ServiceVolumeMap, string, error) {
+			return c.VolumesPage(
+				ctx, attachments, filter,
+				&types.PageOpts{Limit: limit, Continue: token})
+		}, "")
 }
 
 func (c *client) VolumeInspect(
@@ -269,6 +320,22 @@ func (c *client) VolumeDetachAllForService(
 	return c.APIClient.VolumeDetachAllForService(ctx, service, request)
 }
 
+func (c *client) VolumeResize(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeResizeRequest) (*types.Volume, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeResize(ctx, service, volumeID, request)
+}
+
 func (c *client) VolumeSnapshot(
 	ctx types.Context,
 	service string,
@@ -280,17 +347,47 @@ func (c *client) VolumeSnapshot(
 }
 
 func (c *client) Snapshots(
-	ctx types.Context) (types.ServiceSnapshotMap, error) {
+	ctx types.Context, filter string) (types.ServiceSnapshotMap, error) {
 
 	ctx = c.withAllInstanceIDs(c.requireCtx(ctx))
-	return c.APIClient.Snapshots(ctx)
+	return c.APIClient.Snapshots(ctx, filter)
 }
 
 func (c *client) SnapshotsByService(
-	ctx types.Context, service string) (types.SnapshotMap, error) {
+	ctx types.Context, service, filter string) (types.SnapshotMap, error) {
 
 	ctx = c.withInstanceID(c.requireCtx(ctx), service)
-	return c.APIClient.SnapshotsByService(ctx, service)
+	return c.APIClient.SnapshotsByService(ctx, service, filter)
+}
+
+func (c *client) SnapshotsPage(
+	ctx types.Context,
+	filter string,
+	page *types.PageOpts) (types.ServiceSnapshotMap, string, error) {
+
+	ctx = c.withAllInstanceIDs(c.requireCtx(ctx))
+	return c.APIClient.SnapshotsPage(ctx, filter, page)
+}
+
+func (c *client) SnapshotsByServicePage(
+	ctx types.Context,
+	service, filter string,
+	page *types.PageOpts) (types.SnapshotMap, string, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.SnapshotsByServicePage(ctx, service, filter, page)
+}
+
+func (c *client) SnapshotPages(
+	ctx types.Context,
+	filter string,
+	limit int) *types.SnapshotPageIterator {
+
+	return types.NewSnapshotPageIterator(
+		func(token string) (types.ServiceSnapshotMap, string, error) {
+			return c.SnapshotsPage(
+				ctx, filter, &types.PageOpts{Limit: limit, Continue: token})
+		}, "")
 }
 
 func (c *client) SnapshotInspect(
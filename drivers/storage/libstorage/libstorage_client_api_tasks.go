@@ -0,0 +1,197 @@
+package libstorage
+
+import (
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+)
+
+func (c *client) Tasks(ctx types.Context) (map[int]*types.Task, error) {
+	return c.APIClient.Tasks(c.requireCtx(ctx))
+}
+
+func (c *client) TaskInspect(
+	ctx types.Context, taskID int) (*types.Task, error) {
+
+	return c.APIClient.TaskInspect(c.requireCtx(ctx), taskID)
+}
+
+func (c *client) TaskCancel(
+	ctx types.Context, taskID int) (*types.Task, error) {
+
+	return c.APIClient.TaskCancel(c.requireCtx(ctx), taskID)
+}
+
+func (c *client) WaitTask(
+	ctx types.Context,
+	taskID int,
+	reply interface{}) (*types.Task, error) {
+
+	return c.APIClient.WaitTask(c.requireCtx(ctx), taskID, reply)
+}
+
+func (c *client) VolumeCreateAsync(
+	ctx types.Context,
+	service string,
+	request *types.VolumeCreateRequest) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeCreateAsync(ctx, service, request)
+}
+
+func (c *client) VolumeCreateFromSnapshotAsync(
+	ctx types.Context,
+	service, snapshotID string,
+	request *types.VolumeCreateRequest) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.VolumeCreateFromSnapshotAsync(
+		ctx, service, snapshotID, request)
+}
+
+func (c *client) VolumeCopyAsync(
+	ctx types.Context,
+	service, volumeID string,
+	request *types.VolumeCopyRequest) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.VolumeCopyAsync(ctx, service, volumeID, request)
+}
+
+func (c *client) VolumeRemoveAsync(
+	ctx types.Context,
+	service, volumeID string,
+	force bool) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.VolumeRemoveAsync(ctx, service, volumeID, force)
+}
+
+func (c *client) VolumeAttachAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeAttachRequest) (*types.Task, error) {
+
+	if c.isController() {
+		return nil, utils.NewUnsupportedForClientTypeError(
+			c.clientType, "VolumeAttachAsync")
+	}
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeAttachAsync(ctx, service, volumeID, request)
+}
+
+func (c *client) VolumeDetachAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeDetachRequest) (*types.Task, error) {
+
+	if c.isController() {
+		return nil, utils.NewUnsupportedForClientTypeError(
+			c.clientType, "VolumeDetachAsync")
+	}
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeDetachAsync(ctx, service, volumeID, request)
+}
+
+func (c *client) VolumeDetachAllAsync(
+	ctx types.Context,
+	request *types.VolumeDetachRequest) (*types.Task, error) {
+
+	if c.isController() {
+		return nil, utils.NewUnsupportedForClientTypeError(
+			c.clientType, "VolumeDetachAllAsync")
+	}
+
+	ctx = c.withAllInstanceIDs(c.requireCtx(ctx))
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeDetachAllAsync(ctx, request)
+}
+
+func (c *client) VolumeDetachAllForServiceAsync(
+	ctx types.Context,
+	service string,
+	request *types.VolumeDetachRequest) (*types.Task, error) {
+
+	if c.isController() {
+		return nil, utils.NewUnsupportedForClientTypeError(
+			c.clientType, "VolumeDetachAllForServiceAsync")
+	}
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeDetachAllForServiceAsync(ctx, service, request)
+}
+
+func (c *client) VolumeResizeAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeResizeRequest) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	ctxA, err := c.withAllLocalDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxA
+
+	return c.APIClient.VolumeResizeAsync(ctx, service, volumeID, request)
+}
+
+func (c *client) VolumeSnapshotAsync(
+	ctx types.Context,
+	service string,
+	volumeID string,
+	request *types.VolumeSnapshotRequest) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.VolumeSnapshotAsync(ctx, service, volumeID, request)
+}
+
+func (c *client) SnapshotRemoveAsync(
+	ctx types.Context,
+	service, snapshotID string) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.SnapshotRemoveAsync(ctx, service, snapshotID)
+}
+
+func (c *client) SnapshotCopyAsync(
+	ctx types.Context,
+	service, snapshotID string,
+	request *types.SnapshotCopyRequest) (*types.Task, error) {
+
+	ctx = c.withInstanceID(c.requireCtx(ctx), service)
+	return c.APIClient.SnapshotCopyAsync(ctx, service, snapshotID, request)
+}
@@ -0,0 +1,39 @@
+package libstorage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/codedellemc/libstorage/api/types"
+)
+
+var (
+	executorCallDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "libstorage_client_executor_duration_seconds",
+			Help:    "The latency of storage executor invocations.",
+			Buckets: append(prometheus.DefBuckets, 30, 60),
+		}, []string{"driver", "call"})
+
+	executorCallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "libstorage_client_executor_errors_total",
+		Help: "The number of storage executor invocations that failed.",
+	}, []string{"driver", "call"})
+)
+
+func init() {
+	prometheus.MustRegister(executorCallDuration, executorCallErrors)
+}
+
+// observeExecutor records the metrics for an executor invocation. A
+// types.ErrNotImplemented error is not counted as a failed invocation.
+func observeExecutor(driverName, call string, start time.Time, err error) {
+	driverName = strings.ToLower(driverName)
+	executorCallDuration.WithLabelValues(driverName, call).Observe(
+		time.Since(start).Seconds())
+	if err != nil && err.Error() != types.ErrNotImplemented.Error() {
+		executorCallErrors.WithLabelValues(driverName, call).Inc()
+	}
+}
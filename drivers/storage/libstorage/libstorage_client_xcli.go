@@ -1,6 +1,8 @@
 package libstorage
 
 import (
+	"os"
+	"path"
 	"strings"
 	"time"
 
@@ -11,8 +13,25 @@ import (
 	"github.com/codedellemc/libstorage/api/registry"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/api/utils/devwatch"
 )
 
+const (
+	// waitForDevicePollInterval is the interval at which the local devices
+	// are inspected when waiting for a device on a host where devices
+	// cannot be watched.
+	waitForDevicePollInterval = 500 * time.Millisecond
+
+	// waitForDeviceFallbackInterval is the interval at which the local
+	// devices are inspected when waiting for a device on a host where
+	// devices are watched, in case an event is missed.
+	waitForDeviceFallbackInterval = 5 * time.Second
+)
+
+// newDeviceWatcher returns the source of the device events that cause the
+// local devices to be inspected when waiting for a device.
+var newDeviceWatcher = devwatch.New
+
 func (c *client) Supported(
 	ctx types.Context,
 	opts types.Store) (types.LSXSupportedOp, error) {
@@ -49,7 +68,10 @@ func (c *client) Supported(
 	lsxSOp := types.LSXOpAllNoMount
 
 	if dws, ok := d.(types.StorageExecutorWithSupported); ok {
-		if ok, err := dws.Supported(ctx, opts); err != nil {
+		start := time.Now()
+		ok, err := dws.Supported(ctx, opts)
+		observeExecutor(driverName, "Supported", start, err)
+		if err != nil {
 			return 0, err
 		} else if ok {
 			if _, ok := dws.(types.StorageExecutorWithMount); ok {
@@ -107,7 +129,9 @@ func (c *client) InstanceID(
 		return nil, err
 	}
 
+	start := time.Now()
 	iid, err := d.InstanceID(ctx, opts)
+	observeExecutor(driverName, "InstanceID", start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +206,9 @@ func (c *client) NextDevice(
 		return "", err
 	}
 
+	start := time.Now()
 	nextDevice, err := d.NextDevice(ctx, opts)
+	observeExecutor(driverName, "NextDevice", start, err)
 	if err != nil {
 		if err.Error() == types.ErrNotImplemented.Error() {
 			return "", nil
@@ -226,7 +252,9 @@ func (c *client) LocalDevices(
 		return nil, err
 	}
 
+	start := time.Now()
 	ld, err := c.getLocalDevices(ctx, d, opts)
+	observeExecutor(driverName, "LocalDevices", start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -274,32 +302,123 @@ func (c *client) WaitForDevice(
 		return false, nil, err
 	}
 
-	found, ld, err := func() (bool, *types.LocalDevices, error) {
-		timeoutC := time.After(opts.Timeout)
-		tick := time.Tick(500 * time.Millisecond)
-		for {
-			select {
-			case <-timeoutC:
-				return false, nil, types.ErrTimedOut
-			case <-tick:
-				ld, err := c.getLocalDevices(ctx, d, &opts.LocalDevicesOpts)
-				if err != nil {
-					return false, nil, err
-				}
-				for k := range ld.DeviceMap {
-					if strings.ToLower(k) == opts.Token {
-						return true, ld, nil
-					}
-				}
+	// the device watcher is started before the local devices are first
+	// inspected so that a device that appears in between is not missed
+	poll := waitForDevicePollInterval
+	src, err := newDeviceWatcher()
+	if err != nil {
+		ctx.WithError(err).Debug(
+			"device watcher unavailable; polling for device")
+	} else {
+		defer src.Close()
+		poll = waitForDeviceFallbackInterval
+	}
+
+	var ld *types.LocalDevices
+	start := time.Now()
+	err = devwatch.Wait(ctx, src, opts.Timeout, poll, func() (bool, error) {
+		var err error
+		if ld, err = c.getLocalDevices(
+			ctx, d, &opts.LocalDevicesOpts); err != nil {
+			return false, err
+		}
+		for k := range ld.DeviceMap {
+			if strings.ToLower(k) == opts.Token {
+				return true, nil
 			}
 		}
-	}()
+		return false, nil
+	})
+	observeExecutor(driverName, "WaitForDevice", start, err)
 	if err != nil {
 		return false, nil, err
 	}
 
 	ctx.Debug("xli waitfordevice success")
-	return found, ld, nil
+	return true, ld, nil
+}
+
+func (c *client) WaitForDeviceRemoval(
+	ctx types.Context,
+	opts *types.WaitForDeviceRemovalOpts) (bool, *types.LocalDevices, error) {
+
+	if c.isController() {
+		return false, nil, utils.NewUnsupportedForClientTypeError(
+			c.clientType, "WaitForDeviceRemoval")
+	}
+
+	if lsxSO, _ := c.Supported(ctx, opts.Opts); !lsxSO.WaitForDeviceRemoval() {
+		return false, nil, errExecutorNotSupported
+	}
+
+	ctx = context.RequireTX(ctx.Join(c.ctx))
+
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return false, nil, goof.New("missing service name")
+	}
+
+	si, err := c.getServiceInfo(serviceName)
+	if err != nil {
+		return false, nil, err
+	}
+	driverName := si.Driver.Name
+
+	// create the executor
+	d, err := c.getExecutor(ctx, driverName)
+	if err != nil {
+		return false, nil, err
+	}
+
+	poll := waitForDevicePollInterval
+	src, err := newDeviceWatcher()
+	if err != nil {
+		ctx.WithError(err).Debug(
+			"device watcher unavailable; polling for device removal")
+	} else {
+		defer src.Close()
+		poll = waitForDeviceFallbackInterval
+	}
+
+	var ld *types.LocalDevices
+	start := time.Now()
+	err = devwatch.Wait(ctx, src, opts.Timeout, poll, func() (bool, error) {
+		var err error
+		if ld, err = c.getLocalDevices(
+			ctx, d, &opts.LocalDevicesOpts); err != nil {
+			// without the local devices only the device's path can be
+			// checked
+			if err.Error() != types.ErrNotImplemented.Error() {
+				return false, err
+			}
+			ld = &types.LocalDevices{Driver: driverName}
+		}
+		return isDeviceRemoved(opts.DeviceName, ld), nil
+	})
+	observeExecutor(driverName, "WaitForDeviceRemoval", start, err)
+	if err != nil {
+		return false, ld, err
+	}
+
+	ctx.Debug("xli waitfordeviceremoval success")
+	return true, ld, nil
+}
+
+// isDeviceRemoved returns a flag indicating whether the device no longer
+// appears in the local devices and, if the device name is a path, no longer
+// exists on the host.
+func isDeviceRemoved(deviceName string, ld *types.LocalDevices) bool {
+	for k, v := range ld.DeviceMap {
+		if strings.EqualFold(k, deviceName) || v == deviceName {
+			return false
+		}
+	}
+	if path.IsAbs(deviceName) {
+		if _, err := os.Stat(deviceName); err == nil {
+			return false
+		}
+	}
+	return true
 }
 
 // Mount mounts a device to a specified path.
@@ -341,7 +460,10 @@ func (c *client) Mount(
 		return types.ErrNotImplemented
 	}
 
-	if err := dd.Mount(ctx, deviceName, mountPoint, opts); err != nil {
+	start := time.Now()
+	err = dd.Mount(ctx, deviceName, mountPoint, opts)
+	observeExecutor(driverName, "Mount", start, err)
+	if err != nil {
 		return err
 	}
 
@@ -386,7 +508,9 @@ func (c *client) Mounts(
 		return nil, types.ErrNotImplemented
 	}
 
+	start := time.Now()
 	mounts, err := dd.Mounts(ctx, opts)
+	observeExecutor(driverName, "Mounts", start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -434,7 +558,10 @@ func (c *client) Unmount(
 		return types.ErrNotImplemented
 	}
 
-	if err := dd.Unmount(ctx, mountPoint, opts); err != nil {
+	start := time.Now()
+	err = dd.Unmount(ctx, mountPoint, opts)
+	observeExecutor(driverName, "Unmount", start, err)
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,167 @@
+package libstorage
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	gofig "github.com/akutz/gofig/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/registry"
+	"github.com/codedellemc/libstorage/api/types"
+	"github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/api/utils/devwatch"
+)
+
+const testExecutorName = "xcli-test"
+
+// testExecutor is an executor whose local devices are set by the tests.
+type testExecutor struct {
+	sync.Mutex
+	devices map[string]string
+}
+
+var testExec = &testExecutor{}
+
+func init() {
+	registry.RegisterStorageExecutor(
+		testExecutorName,
+		func() types.StorageExecutor { return testExec })
+}
+
+func (e *testExecutor) Name() string {
+	return testExecutorName
+}
+
+func (e *testExecutor) Init(ctx types.Context, config gofig.Config) error {
+	return nil
+}
+
+func (e *testExecutor) InstanceID(
+	ctx types.Context, opts types.Store) (*types.InstanceID, error) {
+	return nil, types.ErrNotImplemented
+}
+
+func (e *testExecutor) NextDevice(
+	ctx types.Context, opts types.Store) (string, error) {
+	return "", types.ErrNotImplemented
+}
+
+func (e *testExecutor) LocalDevices(
+	ctx types.Context,
+	opts *types.LocalDevicesOpts) (*types.LocalDevices, error) {
+
+	e.Lock()
+	defer e.Unlock()
+	ld := &types.LocalDevices{
+		Driver:    testExecutorName,
+		DeviceMap: map[string]string{},
+	}
+	for k, v := range e.devices {
+		ld.DeviceMap[k] = v
+	}
+	return ld, nil
+}
+
+func (e *testExecutor) setDevices(devices map[string]string) {
+	e.Lock()
+	defer e.Unlock()
+	e.devices = devices
+}
+
+// testWatcher is a device watcher whose events are sent by the tests.
+type testWatcher struct {
+	events chan *devwatch.Event
+	closed bool
+}
+
+func (w *testWatcher) Events() <-chan *devwatch.Event {
+	return w.events
+}
+
+func (w *testWatcher) Close() error {
+	w.closed = true
+	return nil
+}
+
+// useTestWatcher replaces the device watcher with one whose events are sent
+// by the test. The returned function restores the device watcher.
+func useTestWatcher() (*testWatcher, func()) {
+	w := &testWatcher{events: make(chan *devwatch.Event, 1)}
+	newDeviceWatcher = func() (devwatch.Source, error) {
+		return w, nil
+	}
+	return w, func() { newDeviceWatcher = devwatch.New }
+}
+
+func newTestXCLIClient() (*client, types.Context) {
+	ctx := context.Background().WithValue(context.ServiceKey, "xcli")
+	c := &client{
+		ctx:             context.Background(),
+		config:          gofigCore.New(),
+		clientType:      types.IntegrationClient,
+		serviceCache:    &lss{Store: utils.NewStore()},
+		supportedCache:  &lss{Store: utils.NewStore()},
+		instanceIDCache: utils.NewStore(),
+	}
+	c.serviceCache.Set("xcli", &types.ServiceInfo{
+		Name:   "xcli",
+		Driver: &types.DriverInfo{Name: testExecutorName},
+	})
+	c.supportedCache.Set(testExecutorName, types.LSXOpAllNoMount)
+	return c, ctx
+}
+
+func TestWaitForDevice(t *testing.T) {
+	w, restore := useTestWatcher()
+	defer restore()
+	testExec.setDevices(nil)
+
+	c, ctx := newTestXCLIClient()
+
+	// the device appears after the local devices are first inspected, and
+	// the watcher's event causes them to be inspected again well before the
+	// fallback poll interval elapses
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testExec.setDevices(map[string]string{"vol-000": "/dev/xvdb"})
+		w.events <- &devwatch.Event{
+			Action: devwatch.ActionAdd, Path: "/dev/xvdb"}
+	}()
+
+	start := time.Now()
+	ok, ld, err := c.WaitForDevice(ctx, &types.WaitForDeviceOpts{
+		Token:   "vol-000",
+		Timeout: waitForDeviceFallbackInterval * 2,
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	if assert.NotNil(t, ld) {
+		assert.Equal(t, "/dev/xvdb", ld.DeviceMap["vol-000"])
+	}
+	assert.True(t, time.Since(start) < waitForDeviceFallbackInterval)
+	assert.True(t, w.closed)
+}
+
+func TestWaitForDeviceTimeout(t *testing.T) {
+	w, restore := useTestWatcher()
+	defer restore()
+	testExec.setDevices(map[string]string{"vol-001": "/dev/xvdc"})
+
+	c, ctx := newTestXCLIClient()
+
+	// an event for another device does not end the wait
+	w.events <- &devwatch.Event{Action: devwatch.ActionAdd, Path: "/dev/xvdc"}
+
+	ok, ld, err := c.WaitForDevice(ctx, &types.WaitForDeviceOpts{
+		Token:   "vol-000",
+		Timeout: 200 * time.Millisecond,
+	})
+	assert.Equal(t, types.ErrTimedOut, err)
+	assert.False(t, ok)
+	assert.Nil(t, ld)
+	assert.True(t, w.closed)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/codedellemc/libstorage/api/context"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
+	apiconfig "github.com/codedellemc/libstorage/api/utils/config"
 )
 
 var (
@@ -41,8 +42,11 @@ func newDriver() types.StorageDriver {
 func (d *driver) Init(ctx types.Context, config gofig.Config) error {
 	logFields := log.Fields{}
 
-	addr := config.GetString(types.ConfigHost)
-	d.ctx = ctx.WithValue(context.HostKey, addr)
+	addrs := apiconfig.Hosts(config)
+	if len(addrs) == 0 {
+		addrs = []string{""}
+	}
+	d.ctx = ctx.WithValue(context.HostKey, strings.Join(addrs, ","))
 	d.ctx.Debug("got configured host address")
 
 	if tok := config.GetString(types.ConfigClientAuthToken); len(tok) > 0 {
@@ -62,28 +66,122 @@ func (d *driver) Init(ctx types.Context, config gofig.Config) error {
 		logFields["encodedToken"] = tok
 	}
 
-	proto, lAddr, err := gotil.ParseAddress(addr)
-	if err != nil {
-		return err
-	}
-
-	tlsConfig, err := utils.ParseTLSConfig(
-		d.ctx, config, proto, logFields, types.ConfigClient)
-	if err != nil {
-		return err
-	}
-
-	host := getHost(d.ctx, proto, lAddr, tlsConfig)
 	lsxPath := config.GetString(types.ConfigExecutorPath)
 	cliType := types.ParseClientType(config.GetString(types.ConfigClientType))
 	disableKeepAlive := config.GetBool(types.ConfigHTTPDisableKeepAlive)
+	protocol := config.GetString(types.ConfigClientProtocol)
+
+	var (
+		tlsConfig *types.TLSConfig
+		hosts     []string
+		endpoints []*apiclient.Endpoint
+	)
+
+	for i, addr := range addrs {
+		proto, lAddr, err := gotil.ParseAddress(addr)
+		if err != nil {
+			return err
+		}
+
+		epTLSConfig, err := utils.ParseTLSConfig(
+			d.ctx, config, proto, logFields, types.ConfigClient)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			tlsConfig = epTLSConfig
+		}
 
-	logFields["lAddr"] = host
+		host := getHost(d.ctx, proto, lAddr, epTLSConfig)
+		hosts = append(hosts, host)
+		endpoints = append(endpoints, &apiclient.Endpoint{
+			Host: host,
+			Transport: d.newTransport(
+				proto, lAddr, epTLSConfig, disableKeepAlive),
+			Protocol: protocol,
+		})
+	}
+
+	loadBalance := config.GetBool(types.ConfigClientLoadBalance)
+	healthCheckInterval, _ := time.ParseDuration(
+		config.GetString(types.ConfigClientHealthCheckInterval))
+	retries := config.GetInt(types.ConfigClientRetries)
+	retryDelay, _ := time.ParseDuration(
+		config.GetString(types.ConfigClientRetryDelay))
+
+	logFields["lAddr"] = strings.Join(hosts, ",")
 	logFields["lsxPath"] = lsxPath
 	logFields["clientType"] = cliType
 	logFields["disableKeepAlive"] = disableKeepAlive
+	logFields["protocol"] = protocol
+	logFields["loadBalance"] = loadBalance
+	logFields["healthCheckInterval"] = healthCheckInterval
+	logFields["retries"] = retries
+	logFields["retryDelay"] = retryDelay
+
+	apiClient := apiclient.NewWithEndpoints(endpoints, &apiclient.Options{
+		LoadBalance:         loadBalance,
+		HealthCheckInterval: healthCheckInterval,
+		Retries:             retries,
+		RetryDelay:          retryDelay,
+	})
+	logReq := config.GetBool(types.ConfigLogHTTPRequests)
+	logRes := config.GetBool(types.ConfigLogHTTPResponses)
+	apiClient.LogRequests(logReq)
+	apiClient.LogResponses(logRes)
+
+	logFields["enableInstanceIDHeaders"] = EnableInstanceIDHeaders
+	logFields["enableLocalDevicesHeaders"] = EnableLocalDevicesHeaders
+	logFields["logRequests"] = logReq
+	logFields["logResponses"] = logRes
+
+	pathConfig := context.MustPathConfig(d.ctx)
+
+	d.client = client{
+		APIClient:    apiClient,
+		ctx:          d.ctx,
+		config:       config,
+		tlsConfig:    tlsConfig,
+		pathConfig:   pathConfig,
+		clientType:   cliType,
+		serviceCache: &lss{Store: utils.NewStore()},
+	}
+
+	if d.clientType == types.IntegrationClient {
+
+		newIIDCache := utils.NewStore
+		dur, err := time.ParseDuration(
+			config.GetString(types.ConfigClientCacheInstanceID))
+		if err != nil {
+			logFields["iidCacheDuration"] = dur.String()
+			newIIDCache = func() types.Store {
+				return utils.NewTTLStore(dur, true)
+			}
+		}
+
+		d.lsxCache = &lss{Store: utils.NewStore()}
+		d.supportedCache = &lss{Store: utils.NewStore()}
+		d.instanceIDCache = newIIDCache()
+	}
+
+	d.ctx.WithFields(logFields).Info("created libStorage client")
 
-	httpTransport := &http.Transport{
+	if err := d.dial(d.ctx); err != nil {
+		return err
+	}
+
+	d.ctx.Info("successefully dialed libStorage server")
+	return nil
+}
+
+// newTransport returns the transport used to connect to the server at the
+// provided address.
+func (d *driver) newTransport(
+	proto, lAddr string,
+	tlsConfig *types.TLSConfig,
+	disableKeepAlive bool) *http.Transport {
+
+	return &http.Transport{
 		Dial: func(string, string) (net.Conn, error) {
 
 			if tlsConfig == nil {
@@ -149,53 +247,4 @@ func (d *driver) Init(ctx types.Context, config gofig.Config) error {
 		},
 		DisableKeepAlives: disableKeepAlive,
 	}
-
-	apiClient := apiclient.New(host, httpTransport)
-	logReq := config.GetBool(types.ConfigLogHTTPRequests)
-	logRes := config.GetBool(types.ConfigLogHTTPResponses)
-	apiClient.LogRequests(logReq)
-	apiClient.LogResponses(logRes)
-
-	logFields["enableInstanceIDHeaders"] = EnableInstanceIDHeaders
-	logFields["enableLocalDevicesHeaders"] = EnableLocalDevicesHeaders
-	logFields["logRequests"] = logReq
-	logFields["logResponses"] = logRes
-
-	pathConfig := context.MustPathConfig(d.ctx)
-
-	d.client = client{
-		APIClient:    apiClient,
-		ctx:          d.ctx,
-		config:       config,
-		tlsConfig:    tlsConfig,
-		pathConfig:   pathConfig,
-		clientType:   cliType,
-		serviceCache: &lss{Store: utils.NewStore()},
-	}
-
-	if d.clientType == types.IntegrationClient {
-
-		newIIDCache := utils.NewStore
-		dur, err := time.ParseDuration(
-			config.GetString(types.ConfigClientCacheInstanceID))
-		if err != nil {
-			logFields["iidCacheDuration"] = dur.String()
-			newIIDCache = func() types.Store {
-				return utils.NewTTLStore(dur, true)
-			}
-		}
-
-		d.lsxCache = &lss{Store: utils.NewStore()}
-		d.supportedCache = &lss{Store: utils.NewStore()}
-		d.instanceIDCache = newIIDCache()
-	}
-
-	d.ctx.WithFields(logFields).Info("created libStorage client")
-
-	if err := d.dial(d.ctx); err != nil {
-		return err
-	}
-
-	d.ctx.Info("successefully dialed libStorage server")
-	return nil
 }
@@ -75,7 +75,13 @@ func (d *driver) Volumes(
 		return nil, goof.New("missing service name")
 	}
 
-	objMap, err := d.client.VolumesByService(ctx, serviceName, opts.Attachments)
+	var filter string
+	if opts.Opts != nil {
+		filter, _ = opts.Opts.Get("filter").(string)
+	}
+
+	objMap, err := d.client.VolumesByService(
+		ctx, serviceName, opts.Attachments, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +94,38 @@ func (d *driver) Volumes(
 	return objs, nil
 }
 
+// VolumesPage returns a page of the remote service's volumes. The page
+// tokens are the remote server's continuation tokens.
+func (d *driver) VolumesPage(
+	ctx types.Context,
+	opts *types.VolumesOpts,
+	page *types.PageOpts) ([]*types.Volume, string, error) {
+
+	ctx = d.requireCtx(ctx)
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return nil, "", goof.New("missing service name")
+	}
+
+	var filter string
+	if opts.Opts != nil {
+		filter, _ = opts.Opts.Get("filter").(string)
+	}
+
+	objMap, next, err := d.client.VolumesByServicePage(
+		ctx, serviceName, opts.Attachments, filter, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	objs := []*types.Volume{}
+	for _, o := range objMap {
+		objs = append(objs, o)
+	}
+
+	return utils.SortVolumeByID(objs), next, nil
+}
+
 func (d *driver) VolumeInspect(
 	ctx types.Context,
 	volumeID string,
@@ -128,6 +166,13 @@ func (d *driver) VolumeCreate(
 		return nil, goof.New("missing service name")
 	}
 
+	if opts.Encrypted != nil && *opts.Encrypted {
+		if err := d.requireCapabilities(
+			serviceName, types.DriverCapabilityEncryption); err != nil {
+			return nil, err
+		}
+	}
+
 	req := &types.VolumeCreateRequest{
 		Name:             name,
 		AvailabilityZone: opts.AvailabilityZone,
@@ -136,6 +181,7 @@ func (d *driver) VolumeCreate(
 		IOPS:             opts.IOPS,
 		Size:             opts.Size,
 		Type:             opts.Type,
+		Labels:           opts.Labels,
 		Opts:             opts.Opts.Map(),
 	}
 
@@ -153,12 +199,18 @@ func (d *driver) VolumeCreateFromSnapshot(
 		return nil, goof.New("missing service name")
 	}
 
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilityCreateFromSnapshot); err != nil {
+		return nil, err
+	}
+
 	req := &types.VolumeCreateRequest{
 		Name:             volumeName,
 		AvailabilityZone: opts.AvailabilityZone,
 		IOPS:             opts.IOPS,
 		Size:             opts.Size,
 		Type:             opts.Type,
+		Labels:           opts.Labels,
 		Opts:             opts.Opts.Map(),
 	}
 
@@ -176,6 +228,11 @@ func (d *driver) VolumeCopy(
 		return nil, goof.New("missing service name")
 	}
 
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilityCopy); err != nil {
+		return nil, err
+	}
+
 	req := &types.VolumeCopyRequest{
 		VolumeName: volumeName,
 		Opts:       opts.Map(),
@@ -195,8 +252,14 @@ func (d *driver) VolumeSnapshot(
 		return nil, goof.New("missing service name")
 	}
 
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilitySnapshots); err != nil {
+		return nil, err
+	}
+
 	req := &types.VolumeSnapshotRequest{
 		SnapshotName: snapshotName,
+		Labels:       opts.GetStringMap("labels"),
 		Opts:         opts.Map(),
 	}
 
@@ -246,6 +309,7 @@ func (d *driver) VolumeAttach(
 	req := &types.VolumeAttachRequest{
 		NextDeviceName: nextDevicePtr,
 		Force:          opts.Force,
+		ReadOnly:       opts.ReadOnly,
 		Opts:           opts.Opts.Map(),
 	}
 
@@ -276,6 +340,31 @@ func (d *driver) VolumeDetach(
 	return d.client.VolumeDetach(ctx, serviceName, volumeID, req)
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	ctx = d.requireCtx(ctx)
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return nil, goof.New("missing service name")
+	}
+
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilityResize); err != nil {
+		return nil, err
+	}
+
+	req := &types.VolumeResizeRequest{
+		Size:  opts.Size,
+		Force: opts.Force,
+		Opts:  opts.Opts.Map(),
+	}
+
+	return d.client.VolumeResize(ctx, serviceName, volumeID, req)
+}
+
 func (d *driver) Snapshots(
 	ctx types.Context,
 	opts types.Store) ([]*types.Snapshot, error) {
@@ -286,7 +375,17 @@ func (d *driver) Snapshots(
 		return nil, goof.New("missing service name")
 	}
 
-	objMap, err := d.client.SnapshotsByService(ctx, serviceName)
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilitySnapshots); err != nil {
+		return nil, err
+	}
+
+	var filter string
+	if opts != nil {
+		filter, _ = opts.Get("filter").(string)
+	}
+
+	objMap, err := d.client.SnapshotsByService(ctx, serviceName, filter)
 
 	if err != nil {
 		return nil, err
@@ -300,6 +399,43 @@ func (d *driver) Snapshots(
 	return objs, nil
 }
 
+// SnapshotsPage returns a page of the remote service's snapshots. The page
+// tokens are the remote server's continuation tokens.
+func (d *driver) SnapshotsPage(
+	ctx types.Context,
+	opts types.Store,
+	page *types.PageOpts) ([]*types.Snapshot, string, error) {
+
+	ctx = d.requireCtx(ctx)
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return nil, "", goof.New("missing service name")
+	}
+
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilitySnapshots); err != nil {
+		return nil, "", err
+	}
+
+	var filter string
+	if opts != nil {
+		filter, _ = opts.Get("filter").(string)
+	}
+
+	objMap, next, err := d.client.SnapshotsByServicePage(
+		ctx, serviceName, filter, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	objs := []*types.Snapshot{}
+	for _, o := range objMap {
+		objs = append(objs, o)
+	}
+
+	return utils.SortSnapshotByID(objs), next, nil
+}
+
 func (d *driver) SnapshotInspect(
 	ctx types.Context,
 	snapshotID string,
@@ -311,6 +447,11 @@ func (d *driver) SnapshotInspect(
 		return nil, goof.New("missing service name")
 	}
 
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilitySnapshots); err != nil {
+		return nil, err
+	}
+
 	return d.client.SnapshotInspect(ctx, serviceName, snapshotID)
 }
 
@@ -325,6 +466,13 @@ func (d *driver) SnapshotCopy(
 		return nil, goof.New("missing service name")
 	}
 
+	if err := d.requireCapabilities(
+		serviceName,
+		types.DriverCapabilitySnapshots,
+		types.DriverCapabilitySnapshotCopy); err != nil {
+		return nil, err
+	}
+
 	req := &types.SnapshotCopyRequest{
 		SnapshotName:  snapshotName,
 		DestinationID: destinationID,
@@ -345,9 +493,54 @@ func (d *driver) SnapshotRemove(
 		return goof.New("missing service name")
 	}
 
+	if err := d.requireCapabilities(
+		serviceName, types.DriverCapabilitySnapshots); err != nil {
+		return err
+	}
+
 	return d.client.SnapshotRemove(ctx, serviceName, snapshotID)
 }
 
+// Capabilities returns the capabilities the remote service advertises.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+
+	serviceName, ok := context.ServiceName(ctx)
+	if !ok {
+		return nil, goof.New("missing service name")
+	}
+
+	si, err := d.getServiceInfo(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return si.Driver.Capabilities, nil
+}
+
+// requireCapabilities returns an ErrUnsupportedCapability error without
+// sending a request if the remote service advertises that it does not
+// support one of the capabilities.
+func (d *driver) requireCapabilities(
+	serviceName string, caps ...types.DriverCapability) error {
+
+	si, err := d.getServiceInfo(serviceName)
+	if err != nil {
+		return nil
+	}
+	if c := si.Driver.Capabilities.Unsupported(caps...); c != "" {
+		return utils.NewUnsupportedCapabilityErr(serviceName, c)
+	}
+	return nil
+}
+
+func (d *driver) assertStorageDriverWithResize() types.StorageDriverWithResize {
+	return d
+}
+
+func (d *driver) assertStorageDriverWithCapabilities() types.StorageDriverWithCapabilities {
+	return d
+}
+
 func (d *driver) assertProvidesAPIClient() types.ProvidesAPIClient {
 	return d
 }
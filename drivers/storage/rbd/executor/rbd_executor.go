@@ -21,6 +21,7 @@ import (
 type driver struct {
 	config     gofig.Config
 	doModprobe bool
+	cmds       *utils.Commands
 }
 
 func init() {
@@ -34,6 +35,10 @@ func newdriver() types.StorageExecutor {
 func (d *driver) Init(context types.Context, config gofig.Config) error {
 	d.config = config
 	d.doModprobe = config.GetBool(rbd.ConfigTestModule)
+	d.cmds = &utils.Commands{
+		Rados: config.GetString(rbd.ConfigRadosCmd),
+		RBD:   config.GetString(rbd.ConfigCmd),
+	}
 	return nil
 }
 
@@ -49,7 +54,7 @@ func (d *driver) Supported(
 		return false, nil
 	}
 
-	if !gotil.FileExistsInPath("rbd") {
+	if !gotil.FileExistsInPath(d.cmds.RBD) {
 		return false, nil
 	}
 
@@ -80,7 +85,7 @@ func (d *driver) LocalDevices(
 	ctx types.Context,
 	opts *types.LocalDevicesOpts) (*types.LocalDevices, error) {
 
-	devMap, err := utils.GetMappedRBDs(ctx)
+	devMap, err := d.cmds.GetMappedRBDs(ctx)
 	if err != nil {
 		return nil, err
 	}
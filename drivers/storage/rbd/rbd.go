@@ -14,6 +14,17 @@ const (
 
 	// ConfigTestModule is the config key for testing kernel module presence
 	ConfigTestModule = Name + ".testModule"
+
+	// ConfigCmd is the config key for the path of the rbd command
+	ConfigCmd = Name + ".cmd"
+
+	// ConfigRadosCmd is the config key for the path of the rados command
+	ConfigRadosCmd = Name + ".radosCmd"
+
+	// VolumeCreateOptsFlatten is the key for the volume create and copy
+	// option that flattens a new clone so that it no longer depends on the
+	// snapshot it was cloned from
+	VolumeCreateOptsFlatten = "flatten"
 )
 
 func init() {
@@ -24,5 +35,7 @@ func registerConfig() {
 	r := gofigCore.NewRegistration("RBD")
 	r.Key(gofig.String, "", "rbd", "", ConfigDefaultPool)
 	r.Key(gofig.Bool, "", true, "", ConfigTestModule)
+	r.Key(gofig.String, "", "rbd", "", ConfigCmd)
+	r.Key(gofig.String, "", "rados", "", ConfigRadosCmd)
 	gofigCore.Register(r)
 }
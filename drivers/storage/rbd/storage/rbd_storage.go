@@ -1,7 +1,11 @@
 package storage
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	gofig "github.com/akutz/gofig/types"
 	"github.com/akutz/goof"
@@ -24,10 +28,13 @@ const (
 var (
 	featureLayering   = "layering"
 	defaultObjectSize = "4M"
+
+	validNameRE = regexp.MustCompile(`^` + validNameRX + `$`)
 )
 
 type driver struct {
 	config gofig.Config
+	cmds   *utils.Commands
 }
 
 func init() {
@@ -45,6 +52,10 @@ func (d *driver) Name() string {
 // Init initializes the driver.
 func (d *driver) Init(ctx types.Context, config gofig.Config) error {
 	d.config = config
+	d.cmds = &utils.Commands{
+		Rados: config.GetString(rbd.ConfigRadosCmd),
+		RBD:   config.GetString(rbd.ConfigCmd),
+	}
 	ctx.Info("storage driver initialized")
 	return nil
 }
@@ -53,6 +64,17 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Block, nil
 }
 
+// Capabilities returns the capabilities the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(
+		types.DriverCapabilitySnapshots,
+		types.DriverCapabilityCopy,
+		types.DriverCapabilityCreateFromSnapshot,
+		types.DriverCapabilityResize,
+	), nil
+}
+
 func (d *driver) NextDeviceInfo(
 	ctx types.Context) (*types.NextDeviceInfo, error) {
 	return nil, nil
@@ -73,7 +95,7 @@ func (d *driver) Volumes(
 	opts *types.VolumesOpts) ([]*types.Volume, error) {
 
 	// Get all Volumes in all pools
-	pools, err := utils.GetRadosPools(ctx)
+	pools, err := d.cmds.GetRadosPools(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +103,7 @@ func (d *driver) Volumes(
 	var volumes []*types.Volume
 
 	for _, pool := range pools {
-		images, err := utils.GetRBDImages(ctx, pool)
+		images, err := d.cmds.GetRBDImages(ctx, pool)
 		if err != nil {
 			return nil, err
 		}
@@ -108,7 +130,7 @@ func (d *driver) VolumeInspect(
 		return nil, err
 	}
 
-	info, err := utils.GetRBDInfo(ctx, pool, image)
+	info, err := d.cmds.GetRBDInfo(ctx, pool, image)
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +189,7 @@ func (d *driver) VolumeCreate(ctx types.Context, volumeName string,
 		return nil, err
 	}
 
-	info, err := utils.GetRBDInfo(ctx, pool, imageName)
+	info, err := d.cmds.GetRBDInfo(ctx, pool, imageName)
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +214,7 @@ func (d *driver) VolumeCreate(ctx types.Context, volumeName string,
 		return nil, goof.WithFields(fields, "volume size too small")
 	}
 
-	err = utils.RBDCreate(
+	err = d.cmds.RBDCreate(
 		ctx,
 		pool,
 		imageName,
@@ -217,21 +239,147 @@ func (d *driver) VolumeCreateFromSnapshot(
 	ctx types.Context,
 	snapshotID, volumeName string,
 	opts *types.VolumeCreateOpts) (*types.Volume, error) {
-	return nil, types.ErrNotImplemented
+
+	fields := map[string]interface{}{
+		"driverName": d.Name(),
+		"snapshotID": snapshotID,
+		"volumeName": volumeName,
+	}
+
+	ctx.WithFields(fields).Debug("creating volume from snapshot")
+
+	pool, imageName, snapName, err := d.parseSnapshotID(&snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := d.SnapshotInspect(ctx, snapshotID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The clone is the size of the snapshot unless it should be larger
+	var size *int64
+	if opts.Size != nil {
+		fields["opts.Size"] = *opts.Size
+		if *opts.Size < snapshot.VolumeSize {
+			fields["snapshotSize"] = snapshot.VolumeSize
+			return nil, goof.WithFields(fields,
+				"volume size smaller than snapshot")
+		}
+		if *opts.Size > snapshot.VolumeSize {
+			size = opts.Size
+		}
+	}
+
+	return d.cloneVolume(
+		ctx, pool, imageName, snapName, volumeName, size,
+		isFlatten(opts.Opts))
 }
 
 func (d *driver) VolumeCopy(
 	ctx types.Context,
 	volumeID, volumeName string,
 	opts types.Store) (*types.Volume, error) {
-	return nil, types.ErrNotImplemented
+
+	fields := map[string]interface{}{
+		"driverName": d.Name(),
+		"volumeID":   volumeID,
+		"volumeName": volumeName,
+	}
+
+	ctx.WithFields(fields).Debug("copying volume")
+
+	pool, imageName, err := d.parseVolumeID(&volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := d.cmds.GetRBDInfo(ctx, pool, imageName)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
+
+	// The copy is cloned from a snapshot taken for that purpose
+	snapName := fmt.Sprintf("copy-%d", time.Now().UnixNano())
+	fields["snapName"] = snapName
+	err = d.cmds.RBDSnapCreate(ctx, pool, imageName, &snapName)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"Failed to create snapshot to copy volume", err)
+	}
+
+	flatten := isFlatten(opts)
+	vol, err := d.cloneVolume(
+		ctx, pool, imageName, &snapName, volumeName, nil, flatten)
+
+	// The snapshot is only kept if the copy still depends on it. A kept
+	// snapshot is protected, so the volume cannot be removed until the copy
+	// is removed or flattened and the snapshot is removed.
+	if err != nil || flatten {
+		rmErr := d.removeSnapshot(ctx, pool, imageName, &snapName)
+		if rmErr != nil {
+			ctx.WithFields(fields).WithError(rmErr).Warn(
+				"Unable to remove snapshot taken to copy volume")
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return vol, nil
 }
 
 func (d *driver) VolumeSnapshot(
 	ctx types.Context,
 	volumeID, snapshotName string,
 	opts types.Store) (*types.Snapshot, error) {
-	return nil, types.ErrNotImplemented
+
+	fields := map[string]interface{}{
+		"driverName":   d.Name(),
+		"volumeID":     volumeID,
+		"snapshotName": snapshotName,
+	}
+
+	ctx.WithFields(fields).Debug("creating snapshot")
+
+	pool, imageName, err := d.parseVolumeID(&volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validNameRE.MatchString(snapshotName) {
+		return nil, goof.WithFields(fields,
+			"Invalid character(s) found in snapshot name")
+	}
+
+	info, err := d.cmds.GetRBDInfo(ctx, pool, imageName)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
+
+	snapshotID := utils.GetSnapshotID(pool, imageName, &snapshotName)
+	_, err = d.SnapshotInspect(ctx, *snapshotID, nil)
+	if err == nil {
+		return nil, goof.WithFields(fields, "Snapshot already exists")
+	}
+	if _, ok := err.(*types.ErrNotFound); !ok {
+		return nil, err
+	}
+
+	err = d.cmds.RBDSnapCreate(ctx, pool, imageName, &snapshotName)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"Failed to create new snapshot", err)
+	}
+
+	return d.SnapshotInspect(ctx, *snapshotID, nil)
 }
 
 func (d *driver) VolumeRemove(
@@ -251,7 +399,7 @@ func (d *driver) VolumeRemove(
 		return goof.WithError("Unable to set image name", err)
 	}
 
-	err = utils.RBDRemove(ctx, pool, imageName)
+	err = d.cmds.RBDRemove(ctx, pool, imageName)
 	if err != nil {
 		return goof.WithError("Error while deleting RBD image", err)
 	}
@@ -260,6 +408,50 @@ func (d *driver) VolumeRemove(
 	return nil
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	fields := map[string]interface{}{
+		"driverName": d.Name(),
+		"volumeID":   volumeID,
+		"newSize":    opts.Size,
+	}
+
+	ctx.WithFields(fields).Debug("resizing volume")
+
+	pool, imageName, err := d.parseVolumeID(&volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := d.cmds.GetRBDInfo(ctx, pool, imageName)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, apiUtils.NewNotFoundError(volumeID)
+	}
+
+	fields["size"] = info.Size / bytesPerGiB
+	if opts.Size < info.Size/bytesPerGiB {
+		return nil, goof.WithFields(fields, "cannot shrink volume")
+	}
+
+	err = d.cmds.RBDResize(ctx, pool, imageName, &opts.Size)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"Failed to resize volume", err)
+	}
+
+	return d.VolumeInspect(ctx, volumeID,
+		&types.VolumeInspectOpts{
+			Attachments: types.VolAttNone,
+		},
+	)
+}
+
 func (d *driver) VolumeAttach(
 	ctx types.Context,
 	volumeID string,
@@ -295,7 +487,7 @@ func (d *driver) VolumeAttach(
 		}
 	}
 
-	_, err = utils.RBDMap(ctx, pool, imageName)
+	_, err = d.cmds.RBDMap(ctx, pool, imageName)
 	if err != nil {
 		return nil, "", err
 	}
@@ -325,7 +517,7 @@ func (d *driver) VolumeDetach(
 	ctx.WithFields(fields).Debug("detaching volume")
 
 	// Can't rely on local devices header, so get local attachments
-	localAttachMap, err := utils.GetMappedRBDs(ctx)
+	localAttachMap, err := d.cmds.GetMappedRBDs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +527,7 @@ func (d *driver) VolumeDetach(
 		return nil, goof.New("Volume not attached")
 	}
 
-	err = utils.RBDUnmap(ctx, &dev)
+	err = d.cmds.RBDUnmap(ctx, &dev)
 	if err != nil {
 		return nil, goof.WithError("Unable to detach volume", err)
 	}
@@ -347,26 +539,129 @@ func (d *driver) VolumeDetach(
 	)
 }
 
+// VolumeDetachAll unmaps the RBD image from the local host, or every RBD
+// image mapped to the local host if the volume ID is empty. It is not an error
+// if the image is not mapped.
 func (d *driver) VolumeDetachAll(
 	ctx types.Context,
 	volumeID string,
-	opts types.Store) error {
-	return types.ErrNotImplemented
+	opts *types.VolumeDetachOpts) error {
+
+	localAttachMap, err := d.cmds.GetMappedRBDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var volumeIDs []string
+	if volumeID != "" {
+		if _, found := localAttachMap[volumeID]; found {
+			volumeIDs = append(volumeIDs, volumeID)
+		}
+	} else {
+		for id := range localAttachMap {
+			volumeIDs = append(volumeIDs, id)
+		}
+		sort.Strings(volumeIDs)
+	}
+
+	// Attempt to unmap every image, even if one of them fails
+	var unmapErr error
+	for _, id := range volumeIDs {
+		dev := localAttachMap[id]
+		ctx.WithFields(map[string]interface{}{
+			"driverName": d.Name(),
+			"volumeID":   id,
+			"device":     dev,
+		}).Debug("detaching volume")
+		if err := d.cmds.RBDUnmap(ctx, &dev); err != nil {
+			unmapErr = goof.WithFieldE(
+				"volumeID", id, "Unable to detach volume", err)
+		}
+	}
+
+	return unmapErr
 }
 
 func (d *driver) Snapshots(
 	ctx types.Context,
 	opts types.Store) ([]*types.Snapshot, error) {
-	return nil, types.ErrNotImplemented
+
+	// Get all snapshots in all pools
+	pools, err := d.cmds.GetRadosPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*types.Snapshot
+
+	for _, pool := range pools {
+		snaps, err := d.cmds.GetRBDImageSnaps(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snap := range snaps {
+			snapshots = append(snapshots, &types.Snapshot{
+				ID: *utils.GetSnapshotID(
+					&snap.Pool, &snap.Name, &snap.Snapshot),
+				Name:       snap.Snapshot,
+				VolumeID:   *utils.GetVolumeID(&snap.Pool, &snap.Name),
+				VolumeSize: int64(snap.Size / bytesPerGiB),
+			})
+		}
+	}
+
+	return snapshots, nil
 }
 
 func (d *driver) SnapshotInspect(
 	ctx types.Context,
 	snapshotID string,
 	opts types.Store) (*types.Snapshot, error) {
-	return nil, types.ErrNotImplemented
+
+	pool, imageName, snapName, err := d.parseSnapshotID(&snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := d.cmds.GetRBDInfo(ctx, pool, imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	// no volume, so no snapshot
+	if info == nil {
+		return nil, apiUtils.NewNotFoundError(snapshotID)
+	}
+
+	snaps, err := d.cmds.GetRBDSnaps(ctx, pool, imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snap := range snaps {
+		if snap.Name != *snapName {
+			continue
+		}
+		snapshot := &types.Snapshot{
+			ID:         *utils.GetSnapshotID(pool, imageName, snapName),
+			Name:       snap.Name,
+			VolumeID:   *utils.GetVolumeID(pool, imageName),
+			VolumeSize: int64(snap.Size / bytesPerGiB),
+		}
+		// rbd reports the time the snapshot was taken in ctime format
+		if t, err := time.ParseInLocation(
+			time.ANSIC, snap.Timestamp, time.Local); err == nil {
+			snapshot.StartTime = t.Unix()
+		}
+		return snapshot, nil
+	}
+
+	return nil, apiUtils.NewNotFoundError(snapshotID)
 }
 
+// SnapshotCopy is not supported since RBD snapshots can only be cloned to
+// new images.
 func (d *driver) SnapshotCopy(
 	ctx types.Context,
 	snapshotID, snapshotName, destinationID string,
@@ -378,7 +673,122 @@ func (d *driver) SnapshotRemove(
 	ctx types.Context,
 	snapshotID string,
 	opts types.Store) error {
-	return types.ErrNotImplemented
+
+	fields := map[string]interface{}{
+		"driverName": d.Name(),
+		"snapshotID": snapshotID,
+	}
+
+	ctx.WithFields(fields).Debug("deleting snapshot")
+
+	pool, imageName, snapName, err := d.parseSnapshotID(&snapshotID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.SnapshotInspect(ctx, snapshotID, opts); err != nil {
+		return err
+	}
+
+	err = d.removeSnapshot(ctx, pool, imageName, snapName)
+	if err != nil {
+		return goof.WithFieldsE(fields,
+			"Error while deleting RBD snapshot", err)
+	}
+	ctx.WithFields(fields).Debug("removed snapshot")
+
+	return nil
+}
+
+// cloneVolume creates a new volume from a snapshot, growing it to the given
+// size if one is provided. A flattened clone no longer depends on the
+// snapshot.
+func (d *driver) cloneVolume(
+	ctx types.Context,
+	pool, imageName, snapName *string,
+	volumeName string,
+	size *int64,
+	flatten bool) (*types.Volume, error) {
+
+	fields := map[string]interface{}{
+		"driverName": d.Name(),
+		"snapshotID": *utils.GetSnapshotID(pool, imageName, snapName),
+		"volumeName": volumeName,
+		"flatten":    flatten,
+	}
+
+	destPool, destImage, err := d.parseVolumeID(&volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := d.cmds.GetRBDInfo(ctx, destPool, destImage)
+	if err != nil {
+		return nil, err
+	}
+
+	// volume already exists
+	if info != nil {
+		return nil, goof.New("Volume already exists")
+	}
+
+	// Only a protected snapshot may be cloned
+	err = d.cmds.RBDSnapProtect(ctx, pool, imageName, snapName)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"Failed to protect snapshot", err)
+	}
+
+	features := []*string{&featureLayering}
+
+	err = d.cmds.RBDClone(
+		ctx, pool, imageName, snapName, destPool, destImage, features)
+	if err != nil {
+		return nil, goof.WithFieldsE(fields,
+			"Failed to clone snapshot", err)
+	}
+
+	if size != nil {
+		fields["size"] = *size
+		err = d.cmds.RBDResize(ctx, destPool, destImage, size)
+		if err != nil {
+			return nil, goof.WithFieldsE(fields,
+				"Failed to resize new volume", err)
+		}
+	}
+
+	if flatten {
+		err = d.cmds.RBDFlatten(ctx, destPool, destImage)
+		if err != nil {
+			return nil, goof.WithFieldsE(fields,
+				"Failed to flatten new volume", err)
+		}
+	}
+
+	volumeID := utils.GetVolumeID(destPool, destImage)
+	return d.VolumeInspect(ctx, *volumeID,
+		&types.VolumeInspectOpts{
+			Attachments: types.VolAttNone,
+		},
+	)
+}
+
+// removeSnapshot unprotects and removes a snapshot. A snapshot cannot be
+// unprotected while it has clones that have not been flattened.
+func (d *driver) removeSnapshot(
+	ctx types.Context,
+	pool, imageName, snapName *string) error {
+
+	err := d.cmds.RBDSnapUnprotect(ctx, pool, imageName, snapName)
+	if err != nil {
+		return err
+	}
+	return d.cmds.RBDSnapRemove(ctx, pool, imageName, snapName)
+}
+
+// isFlatten returns whether the options request that a clone be flattened.
+func isFlatten(opts types.Store) bool {
+	return opts != nil && opts.GetBool(rbd.VolumeCreateOptsFlatten)
 }
 
 func (d *driver) defaultPool() string {
@@ -398,7 +808,7 @@ func (d *driver) toTypeVolumes(
 	// rely on that being present unless getAttachments.Devices is set
 	if getAttachments.Requested() {
 		var err error
-		localAttachMap, err = utils.GetMappedRBDs(ctx)
+		localAttachMap, err = d.cmds.GetMappedRBDs(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -438,7 +848,7 @@ func (d *driver) toTypeVolumes(
 			} else {
 				//Check if RBD has watchers to infer attachment
 				//to a different host
-				b, err := utils.RBDHasWatchers(
+				b, err := d.cmds.RBDHasWatchers(
 					ctx, &image.Pool, &image.Name,
 				)
 				if err != nil {
@@ -479,3 +889,29 @@ func (d *driver) parseVolumeID(name *string) (*string, *string, error) {
 	pool := d.defaultPool()
 	return &pool, name, nil
 }
+
+// parseSnapshotID returns the pool, image, and snapshot names of a snapshot
+// ID formatted as <pool>.<image>@<snapshot> or <image>@<snapshot>.
+func (d *driver) parseSnapshotID(
+	snapshotID *string) (*string, *string, *string, error) {
+
+	i := strings.LastIndex(*snapshotID, "@")
+	if i < 0 {
+		return nil, nil, nil, goof.WithField(
+			"snapshotID", *snapshotID, "Invalid snapshot ID")
+	}
+
+	volumeID := (*snapshotID)[:i]
+	snapName := (*snapshotID)[i+1:]
+	if !validNameRE.MatchString(snapName) {
+		return nil, nil, nil, goof.New(
+			"Invalid character(s) found in snapshot name")
+	}
+
+	pool, imageName, err := d.parseVolumeID(&volumeID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return pool, imageName, &snapName, nil
+}
@@ -0,0 +1,637 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	gofigCore "github.com/akutz/gofig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/codedellemc/libstorage/api/context"
+	"github.com/codedellemc/libstorage/api/tests/drivertest"
+	"github.com/codedellemc/libstorage/api/types"
+	apiUtils "github.com/codedellemc/libstorage/api/utils"
+	"github.com/codedellemc/libstorage/drivers/storage/rbd"
+	"github.com/codedellemc/libstorage/drivers/storage/rbd/utils"
+)
+
+const (
+	// fakeStateEnv is the environment variable that holds the path of the
+	// file in which the stand-in rados and rbd commands keep their state
+	fakeStateEnv = "LIBSTORAGE_RBD_FAKE_STATE"
+
+	// fakeBinEnv is the environment variable that holds the path of the
+	// test binary the stand-in commands in testdata run
+	fakeBinEnv = "LIBSTORAGE_RBD_FAKE_BIN"
+
+	testInstanceID = "ceph-client-1"
+)
+
+type fakeSnap struct {
+	ID        int64
+	Name      string
+	Size      int64
+	Protected bool
+	Timestamp string
+}
+
+type fakeImage struct {
+	Size   int64
+	Snaps  []*fakeSnap
+	Parent *utils.RBDParent
+}
+
+func (i *fakeImage) snap(name string) *fakeSnap {
+	for _, s := range i.Snaps {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// fakeCeph is the state of the cluster the stand-in commands act upon.
+type fakeCeph struct {
+	Pools  map[string]map[string]*fakeImage
+	Mapped map[string]utils.RBDParent
+	NextID int64
+}
+
+func (f *fakeCeph) children(pool, image, snap string) []string {
+	var children []string
+	for p, images := range f.Pools {
+		for name, i := range images {
+			if i.Parent != nil && *i.Parent == (utils.RBDParent{
+				Pool: pool, Image: image, Snapshot: snap}) {
+				children = append(children, p+"/"+name)
+			}
+		}
+	}
+	return children
+}
+
+func readFakeCeph(t *testing.T, path string) *fakeCeph {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeCeph{}
+	if err := json.Unmarshal(buf, f); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func writeFakeCeph(path string, f *fakeCeph) error {
+	buf, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf, 0644)
+}
+
+// TestHelperProcess is not a real test. It is run as the rados and rbd
+// commands by the stand-ins for them in testdata.
+func TestHelperProcess(t *testing.T) {
+	path := os.Getenv(fakeStateEnv)
+	if path == "" {
+		return
+	}
+
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+
+	f := readFakeCeph(t, path)
+	out, code, msg := f.run(args[1], args[2:])
+	if err := writeFakeCeph(path, f); err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 0 {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", args[1], msg)
+		os.Exit(code)
+	}
+	fmt.Fprint(os.Stdout, out)
+	os.Exit(0)
+}
+
+var fakeValueOpts = map[string]bool{
+	"-p":              true,
+	"--pool":          true,
+	"--format":        true,
+	"--object-size":   true,
+	"--size":          true,
+	"--image-feature": true,
+	"--image":         true,
+	"--snap":          true,
+	"--dest-pool":     true,
+	"--dest":          true,
+}
+
+func (f *fakeCeph) run(prog string, args []string) (string, int, string) {
+	var (
+		pos  []string
+		opts = map[string]string{}
+	)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case fakeValueOpts[args[i]]:
+			opts[args[i]] = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "-"):
+			opts[args[i]] = "true"
+		default:
+			pos = append(pos, args[i])
+		}
+	}
+
+	if prog == "rados" {
+		var pools []string
+		for p := range f.Pools {
+			pools = append(pools, p)
+		}
+		sort.Strings(pools)
+		return strings.Join(pools, "\n") + "\n", 0, ""
+	}
+
+	pool := opts["--pool"]
+	if pool == "" {
+		pool = opts["-p"]
+	}
+	images := f.Pools[pool]
+
+	imageName := opts["--image"]
+	if imageName == "" && len(pos) > 1 {
+		imageName = pos[len(pos)-1]
+	}
+	image := images[imageName]
+
+	size := func() int64 {
+		v := strings.TrimSuffix(opts["--size"], "G")
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n * bytesPerGiB
+	}
+	toJSON := func(v interface{}) string {
+		buf, _ := json.Marshal(v)
+		return string(buf)
+	}
+
+	cmd := strings.Join(pos[:1], "")
+	if cmd == "snap" {
+		cmd += " " + pos[1]
+	}
+
+	switch cmd {
+	case "showmapped":
+		mapped := map[string]interface{}{}
+		for dev, m := range f.Mapped {
+			mapped[strings.TrimPrefix(dev, "/dev/rbd")] = map[string]string{
+				"pool":   m.Pool,
+				"name":   m.Image,
+				"snap":   "-",
+				"device": dev,
+			}
+		}
+		return toJSON(mapped), 0, ""
+	case "unmap":
+		if _, ok := f.Mapped[pos[1]]; !ok {
+			return "", 22, "not mapped"
+		}
+		delete(f.Mapped, pos[1])
+		return "", 0, ""
+	case "ls":
+		var names []string
+		for name := range images {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		list := []map[string]interface{}{}
+		for _, name := range names {
+			list = append(list, map[string]interface{}{
+				"image": name, "size": images[name].Size, "format": 2,
+			})
+			for _, s := range images[name].Snaps {
+				list = append(list, map[string]interface{}{
+					"image": name, "snapshot": s.Name,
+					"size": s.Size, "format": 2,
+					"protected": strconv.FormatBool(s.Protected),
+				})
+			}
+		}
+		return toJSON(list), 0, ""
+	case "create":
+		if image != nil {
+			return "", 17, "image already exists"
+		}
+		images[imageName] = &fakeImage{Size: size()}
+		return "", 0, ""
+	}
+
+	if image == nil {
+		return "", 2, "No such file or directory"
+	}
+
+	switch cmd {
+	case "info":
+		return toJSON(map[string]interface{}{
+			"name":     imageName,
+			"size":     image.Size,
+			"order":    rbdDefaultOrder,
+			"format":   2,
+			"features": []string{featureLayering},
+			"parent":   image.Parent,
+		}), 0, ""
+	case "rm":
+		if len(image.Snaps) > 0 {
+			return "", 39, "image has snapshots"
+		}
+		delete(images, imageName)
+		return "", 0, ""
+	case "map":
+		for i := 0; ; i++ {
+			dev := fmt.Sprintf("/dev/rbd%d", i)
+			if _, ok := f.Mapped[dev]; !ok {
+				f.Mapped[dev] = utils.RBDParent{Pool: pool, Image: imageName}
+				return dev + "\n", 0, ""
+			}
+		}
+	case "status":
+		watchers := []interface{}{}
+		for _, m := range f.Mapped {
+			if m.Pool == pool && m.Image == imageName {
+				watchers = append(watchers, map[string]string{})
+			}
+		}
+		return toJSON(map[string]interface{}{"watchers": watchers}), 0, ""
+	case "resize":
+		if size() < image.Size && opts["--allow-shrink"] == "" {
+			return "", 22, "shrinking requires --allow-shrink"
+		}
+		image.Size = size()
+		return "", 0, ""
+	case "flatten":
+		image.Parent = nil
+		return "", 0, ""
+	case "snap ls":
+		return toJSON(image.Snaps), 0, ""
+	case "snap create":
+		if image.snap(opts["--snap"]) != nil {
+			return "", 17, "snapshot already exists"
+		}
+		f.NextID++
+		image.Snaps = append(image.Snaps, &fakeSnap{
+			ID:        f.NextID,
+			Name:      opts["--snap"],
+			Size:      image.Size,
+			Timestamp: time.Now().Format(time.ANSIC),
+		})
+		return "", 0, ""
+	}
+
+	snap := image.snap(opts["--snap"])
+	if snap == nil {
+		return "", 2, "No such file or directory"
+	}
+
+	switch cmd {
+	case "snap protect":
+		if snap.Protected {
+			return "", 16, "snapshot is already protected"
+		}
+		snap.Protected = true
+	case "snap unprotect":
+		if !snap.Protected {
+			return "", 22, "snapshot is already unprotected"
+		}
+		if len(f.children(pool, imageName, snap.Name)) > 0 {
+			return "", 16, "snapshot has children"
+		}
+		snap.Protected = false
+	case "snap rm":
+		if snap.Protected {
+			return "", 16, "snapshot is protected"
+		}
+		for i, s := range image.Snaps {
+			if s == snap {
+				image.Snaps = append(image.Snaps[:i], image.Snaps[i+1:]...)
+				break
+			}
+		}
+	case "clone":
+		if !snap.Protected {
+			return "", 22, "parent snapshot must be protected"
+		}
+		dest := f.Pools[opts["--dest-pool"]]
+		if _, ok := dest[opts["--dest"]]; ok {
+			return "", 17, "image already exists"
+		}
+		dest[opts["--dest"]] = &fakeImage{
+			Size: snap.Size,
+			Parent: &utils.RBDParent{
+				Pool: pool, Image: imageName, Snapshot: snap.Name,
+			},
+		}
+	default:
+		return "", 22, "unknown command " + cmd
+	}
+	return "", 0, ""
+}
+
+func newTestDriver(t *testing.T) (*driver, types.Context, string) {
+	state, err := ioutil.TempFile("", "rbd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Close()
+	path := state.Name()
+
+	err = writeFakeCeph(path, &fakeCeph{
+		Pools: map[string]map[string]*fakeImage{
+			"rbd":   map[string]*fakeImage{},
+			"other": map[string]*fakeImage{},
+		},
+		Mapped: map[string]utils.RBDParent{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Run the commands as this test binary's TestHelperProcess
+	os.Setenv(fakeStateEnv, path)
+	os.Setenv(fakeBinEnv, os.Args[0])
+
+	config := gofigCore.New()
+	config.Set(rbd.ConfigDefaultPool, "rbd")
+	for key, name := range map[string]string{
+		rbd.ConfigCmd:      "rbd",
+		rbd.ConfigRadosCmd: "rados",
+	} {
+		cmd, err := filepath.Abs(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.Set(key, cmd)
+	}
+
+	d := &driver{}
+	ctx := context.Background()
+	if err := d.Init(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	ctx = ctx.WithValue(context.InstanceIDKey, &types.InstanceID{
+		ID:     testInstanceID,
+		Driver: rbd.Name,
+	})
+
+	return d, ctx, path
+}
+
+func newTestSuiteDriver(t *testing.T) *drivertest.Driver {
+	d, ctx, path := newTestDriver(t)
+	return &drivertest.Driver{
+		StorageDriver:   d,
+		Context:         ctx,
+		VolumeSize:      2,
+		MissingVolumeID: "rbd.missing",
+		Close:           func() { os.Remove(path) },
+	}
+}
+
+func TestDriverSuite(t *testing.T) {
+	drivertest.Run(t, newTestSuiteDriver)
+}
+
+func newTestVolume(
+	t *testing.T,
+	d *driver,
+	ctx types.Context,
+	name string,
+	size int64) *types.Volume {
+
+	v, err := d.VolumeCreate(ctx, name, &types.VolumeCreateOpts{
+		Size: &size,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVolumeSnapshot(t *testing.T) {
+	d, ctx, path := newTestDriver(t)
+	defer os.Remove(path)
+	v := newTestVolume(t, d, ctx, "v0", 2)
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "rbd.v0@s0", s.ID)
+
+	// the snapshot ID may omit the default pool
+	s2, err := d.SnapshotInspect(ctx, "v0@s0", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, s, s2)
+
+	_, err = d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	assert.Error(t, err)
+
+	_, err = d.VolumeSnapshot(ctx, v.ID, "s@1", nil)
+	assert.Error(t, err)
+
+	_, err = d.SnapshotInspect(ctx, "rbd.v0@missing", nil)
+	assert.IsType(t, &types.ErrNotFound{}, err)
+}
+
+func TestSnapshots(t *testing.T) {
+	d, ctx, path := newTestDriver(t)
+	defer os.Remove(path)
+	v0 := newTestVolume(t, d, ctx, "v0", 2)
+	v1 := newTestVolume(t, d, ctx, "other.v1", 1)
+
+	for _, s := range []struct{ volumeID, name string }{
+		{v0.ID, "s0"}, {v0.ID, "s1"}, {v1.ID, "s0"},
+	} {
+		_, err := d.VolumeSnapshot(ctx, s.volumeID, s.name, nil)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+	}
+
+	snapshots, err := d.Snapshots(ctx, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	ids := []string{}
+	for _, s := range snapshots {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{"other.v1@s0", "rbd.v0@s0", "rbd.v0@s1"}, ids)
+	assert.Equal(t, int64(1), snapshots[0].VolumeSize)
+	assert.Equal(t, v1.ID, snapshots[0].VolumeID)
+
+	// the snapshots are not mistaken for volumes
+	vols, err := d.Volumes(ctx, &types.VolumesOpts{})
+	assert.NoError(t, err)
+	assert.Len(t, vols, 2)
+
+	// a volume ID is not a snapshot ID
+	assert.Error(t, d.SnapshotRemove(ctx, "rbd.v0", nil))
+}
+
+func TestVolumeCreateFromSnapshot(t *testing.T) {
+	d, ctx, path := newTestDriver(t)
+	defer os.Remove(path)
+	v := newTestVolume(t, d, ctx, "v0", 2)
+
+	s, err := d.VolumeSnapshot(ctx, v.ID, "s0", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	nv, err := d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "other.v1", &types.VolumeCreateOpts{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "other.v1", nv.ID)
+	assert.Equal(t, int64(2), nv.Size)
+	assert.Equal(t, &utils.RBDParent{
+		Pool: "rbd", Image: "v0", Snapshot: "s0",
+	}, readFakeCeph(t, path).Pools["other"]["v1"].Parent)
+
+	// the snapshot cannot be removed while it has a clone
+	assert.Error(t, d.SnapshotRemove(ctx, s.ID, nil))
+
+	size := int64(4)
+	nv, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v2", &types.VolumeCreateOpts{
+			Size: &size,
+			Opts: apiUtils.NewStoreWithData(map[string]interface{}{
+				rbd.VolumeCreateOptsFlatten: true,
+			}),
+		})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "rbd.v2", nv.ID)
+	assert.Equal(t, int64(4), nv.Size)
+	assert.Nil(t, readFakeCeph(t, path).Pools["rbd"]["v2"].Parent)
+
+	_, err = d.VolumeCreateFromSnapshot(
+		ctx, s.ID, "v2", &types.VolumeCreateOpts{})
+	assert.Error(t, err)
+}
+
+func TestVolumeCopy(t *testing.T) {
+	d, ctx, path := newTestDriver(t)
+	defer os.Remove(path)
+	v := newTestVolume(t, d, ctx, "v0", 2)
+
+	nv, err := d.VolumeCopy(ctx, v.ID, "v1", nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "rbd.v1", nv.ID)
+	assert.Equal(t, v.Size, nv.Size)
+
+	// the clone depends on the snapshot taken to copy the volume
+	f := readFakeCeph(t, path)
+	if assert.Len(t, f.Pools["rbd"]["v0"].Snaps, 1) {
+		snap := f.Pools["rbd"]["v0"].Snaps[0]
+		assert.True(t, snap.Protected)
+		assert.Equal(t, &utils.RBDParent{
+			Pool: "rbd", Image: "v0", Snapshot: snap.Name,
+		}, f.Pools["rbd"]["v1"].Parent)
+	}
+
+	nv, err = d.VolumeCopy(ctx, v.ID, "v2",
+		apiUtils.NewStoreWithData(map[string]interface{}{
+			rbd.VolumeCreateOptsFlatten: true,
+		}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "rbd.v2", nv.ID)
+
+	// the snapshot taken for a flattened copy is removed
+	f = readFakeCeph(t, path)
+	assert.Nil(t, f.Pools["rbd"]["v2"].Parent)
+	assert.Len(t, f.Pools["rbd"]["v0"].Snaps, 1)
+
+	// as is the snapshot taken for a copy that fails
+	_, err = d.VolumeCopy(ctx, v.ID, "v2", nil)
+	assert.Error(t, err)
+	assert.Len(t, readFakeCeph(t, path).Pools["rbd"]["v0"].Snaps, 1)
+}
+
+func TestVolumeResize(t *testing.T) {
+	d, ctx, path := newTestDriver(t)
+	defer os.Remove(path)
+	v := newTestVolume(t, d, ctx, "v0", 2)
+
+	nv, err := d.VolumeResize(ctx, v.ID, &types.VolumeResizeOpts{Size: 5})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, int64(5), nv.Size)
+
+	_, err = d.VolumeResize(ctx, v.ID, &types.VolumeResizeOpts{Size: 1})
+	assert.Error(t, err)
+
+	_, err = d.VolumeResize(
+		ctx, "rbd.missing", &types.VolumeResizeOpts{Size: 5})
+	assert.IsType(t, &types.ErrNotFound{}, err)
+}
+
+func TestVolumeDetachAll(t *testing.T) {
+	d, ctx, path := newTestDriver(t)
+	defer os.Remove(path)
+
+	for _, name := range []string{"v0", "v1", "other.v2"} {
+		v := newTestVolume(t, d, ctx, name, 1)
+		_, _, err := d.VolumeAttach(ctx, v.ID, &types.VolumeAttachOpts{})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+	}
+
+	mapped, err := d.cmds.GetMappedRBDs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, mapped, 3)
+
+	// only the specified volume is detached
+	assert.NoError(t, d.VolumeDetachAll(ctx, "rbd.v0", nil))
+
+	mapped, err = d.cmds.GetMappedRBDs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, mapped, 2)
+	assert.NotContains(t, mapped, "rbd.v0")
+
+	// a volume that is not attached is not an error
+	assert.NoError(t, d.VolumeDetachAll(ctx, "rbd.v0", nil))
+
+	// all of the volumes are detached if the volume ID is empty
+	assert.NoError(t, d.VolumeDetachAll(ctx, "", nil))
+
+	mapped, err = d.cmds.GetMappedRBDs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, mapped, 0)
+
+	vols, err := d.Volumes(
+		ctx, &types.VolumesOpts{Attachments: types.VolAttReq})
+	assert.NoError(t, err)
+	for _, v := range vols {
+		assert.Equal(t, types.VolumeAvailable, v.AttachmentState)
+	}
+}
@@ -18,15 +18,29 @@ import (
 )
 
 const (
-	radosCmd  = "rados"
-	rbdCmd    = "rbd"
 	formatOpt = "--format"
 	jsonArg   = "json"
 	poolOpt   = "--pool"
+	imageOpt  = "--image"
+	snapOpt   = "--snap"
+
+	// exit codes the rbd command returns when an operation is refused
+	// because the snapshot is already in the requested state
+	errnoEBUSY  = 16
+	errnoEINVAL = 22
 
 	bytesPerGiB = 1024 * 1024 * 1024
 )
 
+//Commands runs the rados and rbd commands
+type Commands struct {
+	//Rados is the path of the rados command
+	Rados string
+
+	//RBD is the path of the rbd command
+	RBD string
+}
+
 type rbdMappedEntry struct {
 	Device string `json:"device"`
 	Name   string `json:"name"`
@@ -40,25 +54,45 @@ type RBDImage struct {
 	Size   int64  `json:"size"`
 	Format uint   `json:"format"`
 	Pool   string
+
+	// Snapshot is the name of the snapshot when the entry describes a
+	// snapshot of the image rather than the image itself
+	Snapshot string `json:"snapshot,omitempty"`
+}
+
+//RBDSnap holds details about a snapshot of an RBD image
+type RBDSnap struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+//RBDParent holds details about the snapshot an RBD image was cloned from
+type RBDParent struct {
+	Pool     string `json:"pool"`
+	Image    string `json:"image"`
+	Snapshot string `json:"snapshot"`
 }
 
 //RBDInfo holds low-level details about an RBD image
 type RBDInfo struct {
-	Name            string   `json:"name"`
-	Size            int64    `json:"size"`
-	Objects         int64    `json:"objects"`
-	Order           int64    `json:"order"`
-	ObjectSize      int64    `json:"object_size"`
-	BlockNamePrefix string   `json:"block_name_prefix"`
-	Format          int64    `json:"format"`
-	Features        []string `json:"features"`
+	Name            string     `json:"name"`
+	Size            int64      `json:"size"`
+	Objects         int64      `json:"objects"`
+	Order           int64      `json:"order"`
+	ObjectSize      int64      `json:"object_size"`
+	BlockNamePrefix string     `json:"block_name_prefix"`
+	Format          int64      `json:"format"`
+	Features        []string   `json:"features"`
+	Parent          *RBDParent `json:"parent,omitempty"`
 	Pool            string
 }
 
 //GetRadosPools returns a slice containing all the pool names
-func GetRadosPools(ctx types.Context) ([]*string, error) {
+func (c *Commands) GetRadosPools(ctx types.Context) ([]*string, error) {
 
-	cmd := exec.Command(radosCmd, "lspools")
+	cmd := exec.Command(c.Rados, "lspools")
 	out, _, err := RunCommand(ctx, cmd)
 	if err != nil {
 		return nil, goof.WithError("unable to get pools", err)
@@ -77,11 +111,53 @@ func GetRadosPools(ctx types.Context) ([]*string, error) {
 }
 
 //GetRBDImages returns a slice of RBD image info
-func GetRBDImages(
+func (c *Commands) GetRBDImages(
+	ctx types.Context,
+	pool *string) ([]*RBDImage, error) {
+
+	rbdList, err := c.listRBDImages(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []*RBDImage
+	for _, info := range rbdList {
+		if info.Snapshot == "" {
+			images = append(images, info)
+		}
+	}
+
+	return images, nil
+}
+
+//GetRBDImageSnaps returns a slice of the snapshots of all the RBD images in
+//the pool. The Snapshot field of each entry is the name of the snapshot.
+func (c *Commands) GetRBDImageSnaps(
 	ctx types.Context,
 	pool *string) ([]*RBDImage, error) {
 
-	cmd := exec.Command(rbdCmd, "ls", "-p", *pool, "-l", formatOpt, jsonArg)
+	rbdList, err := c.listRBDImages(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var snaps []*RBDImage
+	for _, info := range rbdList {
+		if info.Snapshot != "" {
+			snaps = append(snaps, info)
+		}
+	}
+
+	return snaps, nil
+}
+
+// listRBDImages returns the long listing of a pool, which includes an entry
+// for every image and every snapshot of an image
+func (c *Commands) listRBDImages(
+	ctx types.Context,
+	pool *string) ([]*RBDImage, error) {
+
+	cmd := exec.Command(c.RBD, "ls", "-p", *pool, "-l", formatOpt, jsonArg)
 	out, _, err := RunCommand(ctx, cmd)
 	if err != nil {
 		return nil, goof.WithError("unable to get rbd images", err)
@@ -103,7 +179,7 @@ func GetRBDImages(
 }
 
 //GetRBDInfo gets low-level details about an RBD image
-func GetRBDInfo(
+func (c *Commands) GetRBDInfo(
 	ctx types.Context,
 	pool *string,
 	name *string) (*RBDInfo, error) {
@@ -111,7 +187,7 @@ func GetRBDInfo(
 	ignoreCode := 2
 
 	cmd := exec.Command(
-		rbdCmd, "info", "-p", *pool, *name, formatOpt, jsonArg)
+		c.RBD, "info", "-p", *pool, *name, formatOpt, jsonArg)
 	out, status, err := RunCommand(ctx, cmd, ignoreCode)
 	if err != nil {
 		if status == ignoreCode {
@@ -141,11 +217,174 @@ func GetVolumeID(pool, image *string) *string {
 	return &volumeID
 }
 
+//GetSnapshotID returns an RBD snapshot formatted as
+//<pool>.<imageName>@<snapName>
+func GetSnapshotID(pool, image, snap *string) *string {
+
+	snapshotID := fmt.Sprintf("%s@%s", *GetVolumeID(pool, image), *snap)
+	return &snapshotID
+}
+
+//GetRBDSnaps returns a slice of the snapshots of an RBD image
+func (c *Commands) GetRBDSnaps(
+	ctx types.Context,
+	pool *string,
+	image *string) ([]*RBDSnap, error) {
+
+	cmd := exec.Command(
+		c.RBD, "snap", "ls", poolOpt, *pool, *image, formatOpt, jsonArg,
+	)
+	out, _, err := RunCommand(ctx, cmd)
+	if err != nil {
+		return nil, goof.WithError("unable to get rbd snapshots", err)
+	}
+
+	var snaps []*RBDSnap
+
+	err = json.Unmarshal(out, &snaps)
+	if err != nil {
+		return nil, goof.WithError(
+			"unable to parse rbd snap ls", err)
+	}
+
+	return snaps, nil
+}
+
+//RBDSnapCreate creates a snapshot of an RBD image
+func (c *Commands) RBDSnapCreate(
+	ctx types.Context,
+	pool, image, snap *string) error {
+
+	cmd := exec.Command(
+		c.RBD, "snap", "create", poolOpt, *pool,
+		imageOpt, *image, snapOpt, *snap,
+	)
+	_, _, err := RunCommand(ctx, cmd)
+	if err != nil {
+		return goof.WithError("unable to create rbd snapshot", err)
+	}
+
+	return nil
+}
+
+//RBDSnapRemove deletes a snapshot of an RBD image
+func (c *Commands) RBDSnapRemove(
+	ctx types.Context,
+	pool, image, snap *string) error {
+
+	cmd := exec.Command(
+		c.RBD, "snap", "rm", poolOpt, *pool, "--no-progress",
+		imageOpt, *image, snapOpt, *snap,
+	)
+	_, _, err := RunCommand(ctx, cmd)
+	if err != nil {
+		return goof.WithError("unable to delete rbd snapshot", err)
+	}
+
+	return nil
+}
+
+//RBDSnapProtect protects a snapshot of an RBD image so that it may be
+//cloned. Protecting a snapshot that is already protected is not an error.
+func (c *Commands) RBDSnapProtect(
+	ctx types.Context,
+	pool, image, snap *string) error {
+
+	cmd := exec.Command(
+		c.RBD, "snap", "protect", poolOpt, *pool,
+		imageOpt, *image, snapOpt, *snap,
+	)
+	_, status, err := RunCommand(ctx, cmd, errnoEBUSY)
+	if err != nil && status != errnoEBUSY {
+		return goof.WithError("unable to protect rbd snapshot", err)
+	}
+
+	return nil
+}
+
+//RBDSnapUnprotect unprotects a snapshot of an RBD image so that it may be
+//removed. Unprotecting a snapshot that is not protected is not an error.
+func (c *Commands) RBDSnapUnprotect(
+	ctx types.Context,
+	pool, image, snap *string) error {
+
+	cmd := exec.Command(
+		c.RBD, "snap", "unprotect", poolOpt, *pool,
+		imageOpt, *image, snapOpt, *snap,
+	)
+	_, status, err := RunCommand(ctx, cmd, errnoEINVAL)
+	if err != nil && status != errnoEINVAL {
+		return goof.WithError("unable to unprotect rbd snapshot", err)
+	}
+
+	return nil
+}
+
+//RBDClone creates a new RBD image from a protected snapshot
+func (c *Commands) RBDClone(
+	ctx types.Context,
+	pool, image, snap *string,
+	destPool, destImage *string,
+	features []*string) error {
+
+	cmd := exec.Command(
+		c.RBD, "clone", poolOpt, *pool, imageOpt, *image, snapOpt, *snap,
+		"--dest-pool", *destPool, "--dest", *destImage,
+	)
+
+	for _, feature := range features {
+		cmd.Args = append(cmd.Args, "--image-feature")
+		cmd.Args = append(cmd.Args, *feature)
+	}
+
+	_, _, err := RunCommand(ctx, cmd)
+	if err != nil {
+		return goof.WithError("unable to clone rbd snapshot", err)
+	}
+
+	return nil
+}
+
+//RBDFlatten copies the data an RBD image shares with its parent snapshot
+//into the image, so the image no longer depends on the snapshot
+func (c *Commands) RBDFlatten(
+	ctx types.Context,
+	pool, image *string) error {
+
+	cmd := exec.Command(c.RBD, "flatten", poolOpt, *pool, "--no-progress",
+		*image,
+	)
+	_, _, err := RunCommand(ctx, cmd)
+	if err != nil {
+		return goof.WithError("unable to flatten rbd", err)
+	}
+
+	return nil
+}
+
+//RBDResize changes the size of an RBD image
+func (c *Commands) RBDResize(
+	ctx types.Context,
+	pool, image *string,
+	sizeGB *int64) error {
+
+	cmd := exec.Command(c.RBD, "resize", poolOpt, *pool, "--no-progress",
+		"--size", strconv.FormatInt(*sizeGB, 10)+"G",
+		*image,
+	)
+	_, _, err := RunCommand(ctx, cmd)
+	if err != nil {
+		return goof.WithError("unable to resize rbd", err)
+	}
+
+	return nil
+}
+
 //GetMappedRBDs returns a map of RBDs currently mapped to the *local* host
-func GetMappedRBDs(ctx types.Context) (map[string]string, error) {
+func (c *Commands) GetMappedRBDs(ctx types.Context) (map[string]string, error) {
 
 	cmd := exec.Command(
-		rbdCmd, "showmapped", formatOpt, jsonArg)
+		c.RBD, "showmapped", formatOpt, jsonArg)
 	out, _, err := RunCommand(ctx, cmd)
 	if err != nil {
 		return nil, goof.WithError("unable to get rbd map", err)
@@ -169,7 +408,7 @@ func GetMappedRBDs(ctx types.Context) (map[string]string, error) {
 }
 
 //RBDCreate creates a new RBD volume on the cluster
-func RBDCreate(
+func (c *Commands) RBDCreate(
 	ctx types.Context,
 	pool *string,
 	image *string,
@@ -178,7 +417,7 @@ func RBDCreate(
 	features []*string) error {
 
 	cmd := exec.Command(
-		rbdCmd, "create", poolOpt, *pool,
+		c.RBD, "create", poolOpt, *pool,
 		"--object-size", *objectSize,
 		"--size", strconv.FormatInt(*sizeGB, 10)+"G",
 	)
@@ -198,12 +437,12 @@ func RBDCreate(
 }
 
 //RBDRemove deletes the RBD volume on the cluster
-func RBDRemove(
+func (c *Commands) RBDRemove(
 	ctx types.Context,
 	pool *string,
 	image *string) error {
 
-	cmd := exec.Command(rbdCmd, "rm", poolOpt, *pool, "--no-progress",
+	cmd := exec.Command(c.RBD, "rm", poolOpt, *pool, "--no-progress",
 		*image,
 	)
 	_, _, err := RunCommand(ctx, cmd)
@@ -214,11 +453,11 @@ func RBDRemove(
 }
 
 //RBDMap attaches the given RBD image to the *local* host
-func RBDMap(
+func (c *Commands) RBDMap(
 	ctx types.Context,
 	pool, image *string) (string, error) {
 
-	cmd := exec.Command(rbdCmd, "map", poolOpt, *pool, *image)
+	cmd := exec.Command(c.RBD, "map", poolOpt, *pool, *image)
 	out, _, err := RunCommand(ctx, cmd)
 	if err != nil {
 		return "", goof.WithError("unable to map rbd", err)
@@ -228,9 +467,9 @@ func RBDMap(
 }
 
 //RBDUnmap detaches the given RBD device from the *local* host
-func RBDUnmap(ctx types.Context, device *string) error {
+func (c *Commands) RBDUnmap(ctx types.Context, device *string) error {
 
-	cmd := exec.Command(rbdCmd, "unmap", *device)
+	cmd := exec.Command(c.RBD, "unmap", *device)
 	_, _, err := RunCommand(ctx, cmd)
 	if err != nil {
 		return goof.WithError("unable to unmap rbd", err)
@@ -240,12 +479,12 @@ func RBDUnmap(ctx types.Context, device *string) error {
 }
 
 //GetRBDStatus returns a map of RBD status info
-func GetRBDStatus(
+func (c *Commands) GetRBDStatus(
 	ctx types.Context,
 	pool, image *string) (map[string]interface{}, error) {
 
 	cmd := exec.Command(
-		rbdCmd, "status", poolOpt, *pool, *image, formatOpt, jsonArg,
+		c.RBD, "status", poolOpt, *pool, *image, formatOpt, jsonArg,
 	)
 	out, _, err := RunCommand(ctx, cmd)
 	if err != nil {
@@ -264,12 +503,12 @@ func GetRBDStatus(
 }
 
 //RBDHasWatchers returns true if RBD image has watchers
-func RBDHasWatchers(
+func (c *Commands) RBDHasWatchers(
 	ctx types.Context,
 	pool *string,
 	image *string) (bool, error) {
 
-	m, err := GetRBDStatus(ctx, pool, image)
+	m, err := c.GetRBDStatus(ctx, pool, image)
 	if err != nil {
 		return false, err
 	}
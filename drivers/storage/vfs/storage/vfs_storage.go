@@ -94,6 +94,21 @@ func (d *driver) Type(ctx types.Context) (types.StorageType, error) {
 	return types.Object, nil
 }
 
+// Capabilities returns the capabilities the driver supports.
+func (d *driver) Capabilities(
+	ctx types.Context) (types.DriverCapabilities, error) {
+	return types.NewDriverCapabilities(
+		types.DriverCapabilitySnapshots,
+		types.DriverCapabilityCopy,
+		types.DriverCapabilitySnapshotCopy,
+		types.DriverCapabilityCreateFromSnapshot,
+		types.DriverCapabilityResize,
+		types.DriverCapabilityEncryption,
+		types.DriverCapabilityIOPS,
+		types.DriverCapabilityAvailabilityZones,
+	), nil
+}
+
 func (d *driver) NextDeviceInfo(
 	ctx types.Context) (*types.NextDeviceInfo, error) {
 	return &types.NextDeviceInfo{
@@ -233,6 +248,9 @@ func (d *driver) VolumeCreate(
 		if opts.Type != nil {
 			fields["type"] = *opts.Type
 		}
+		if opts.Labels != nil {
+			fields["labels"] = opts.Labels
+		}
 		//if opts.Opts != nil {
 		//	fields["opts"] = opts.Opts
 		//}
@@ -261,6 +279,7 @@ func (d *driver) VolumeCreate(
 	if opts.Encrypted != nil {
 		v.Encrypted = *opts.Encrypted
 	}
+	v.Labels = opts.Labels
 	if customFields := opts.Opts.GetStore("opts"); customFields != nil {
 		for _, k := range customFields.Keys() {
 			v.Fields[k] = customFields.GetString(k)
@@ -298,12 +317,17 @@ func (d *driver) VolumeCreateFromSnapshot(
 		ID:               d.newVolumeID(),
 		Name:             volumeName,
 		Fields:           ogVol.Fields,
+		Labels:           snap.Labels,
 		AvailabilityZone: ogVol.AvailabilityZone,
 		IOPS:             ogVol.IOPS,
 		Size:             ogVol.Size,
 		Type:             ogVol.Type,
 	}
 
+	if opts.Labels != nil {
+		v.Labels = opts.Labels
+	}
+
 	if opts.AvailabilityZone != nil {
 		v.AvailabilityZone = *opts.AvailabilityZone
 	}
@@ -351,6 +375,7 @@ func (d *driver) VolumeCopy(
 		IOPS:             ogVol.IOPS,
 		Size:             ogVol.Size,
 		Type:             ogVol.Type,
+		Labels:           ogVol.Labels,
 		Fields:           ogVol.Fields,
 	}
 
@@ -389,9 +414,14 @@ func (d *driver) VolumeSnapshot(
 		Name:       snapshotName,
 		Status:     "online",
 		StartTime:  time.Now().Unix(),
+		Labels:     v.Labels,
 		Fields:     v.Fields,
 	}
 
+	if labels := opts.GetStringMap("labels"); labels != nil {
+		s.Labels = labels
+	}
+
 	if customFields := opts.GetStore("opts"); customFields != nil {
 		for _, k := range customFields.Keys() {
 			s.Fields[k] = customFields.GetString(k)
@@ -423,6 +453,34 @@ func (d *driver) VolumeRemove(
 	return nil
 }
 
+func (d *driver) VolumeResize(
+	ctx types.Context,
+	volumeID string,
+	opts *types.VolumeResizeOpts) (*types.Volume, error) {
+
+	context.MustSession(ctx)
+
+	v, err := d.getVolumeByID(volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Size < v.Size {
+		return nil, goof.WithFields(goof.Fields{
+			"volumeID": volumeID,
+			"size":     v.Size,
+			"newSize":  opts.Size,
+		}, "cannot shrink volume")
+	}
+
+	v.Size = opts.Size
+	if err := d.writeVolume(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
 func (d *driver) VolumeAttach(
 	ctx types.Context,
 	volumeID string,
@@ -558,6 +616,7 @@ func (d *driver) SnapshotCopy(
 		Name:       snapshotName,
 		Status:     "online",
 		StartTime:  time.Now().Unix(),
+		Labels:     ogSnap.Labels,
 		Fields:     ogSnap.Fields,
 	}
 
@@ -85,6 +85,10 @@ func init() {
 
 			rk(gofig.Bool, false, "", types.ConfigExecutorNoDownload)
 			rk(gofig.Bool, false, "", types.ConfigIgVolOpsMountPreempt)
+			rk(gofig.String, "", "", types.ConfigIgVolOpsMountOptions)
+			rk(gofig.Bool, false, "", types.ConfigIgVolOpsMountReadOnly)
+			rk(gofig.String, "", "", types.ConfigIgVolOpsMountLabel)
+			rk(gofig.String, "", "", types.ConfigIgVolOpsMountFSLabel)
 			rk(gofig.Int, 0, "", types.ConfigIgVolOpsMountRetryCount)
 			rk(gofig.String, "5s", "", types.ConfigIgVolOpsMountRetryWait)
 			rk(gofig.Bool, false, "", types.ConfigIgVolOpsCreateDisable)
@@ -94,11 +98,34 @@ func init() {
 			rk(gofig.Bool, true, "", types.ConfigIgVolOpsPathCacheEnabled)
 			rk(gofig.Bool, true, "", types.ConfigIgVolOpsPathCacheAsync)
 			rk(gofig.String, "30m", "", types.ConfigClientCacheInstanceID)
+			rk(gofig.Bool, false, "", types.ConfigClientLoadBalance)
+			rk(gofig.String, "10s", "", types.ConfigClientHealthCheckInterval)
+			rk(gofig.Int, 3, "", types.ConfigClientRetries)
+			rk(gofig.String, "1s", "", types.ConfigClientRetryDelay)
+			rk(gofig.String, "http", "", types.ConfigClientProtocol)
 			rk(gofig.String, "30s", "", types.ConfigDeviceAttachTimeout)
+			rk(gofig.String, "30s", "", types.ConfigDeviceDetachTimeout)
 			rk(gofig.Int, 0, "", types.ConfigDeviceScanType)
 			rk(gofig.Bool, false, "", types.ConfigEmbedded)
 			rk(gofig.String, "1m", "", types.ConfigServerTasksExeTimeout)
 			rk(gofig.String, "0s", "", types.ConfigServerTasksLogTimeout)
+			rk(gofig.String, "1h", "", types.ConfigServerTasksTTL)
+			rk(gofig.String, "mem", "", types.ConfigServerTasksStoreType)
+			rk(gofig.String, "", "", types.ConfigServerTasksStoreFile)
+			rk(gofig.Bool, false, "", types.ConfigServerVolumeCacheEnabled)
+			rk(gofig.String, "1m", "",
+				types.ConfigServerVolumeCacheRefreshInterval)
+			rk(gofig.String, "10m", "", types.ConfigServerIdempotencyTTL)
+			rk(gofig.String, "mem", "", types.ConfigServerLocksType)
+			rk(gofig.String, "1m", "", types.ConfigServerLocksTimeout)
+			rk(gofig.String, "", "", types.ConfigServerLocksDir)
+			rk(gofig.String, "", "", types.ConfigServerEventsSinks)
+			rk(gofig.String, "", "", types.ConfigServerEventsAuditFile)
+			rk(gofig.String, "", "", types.ConfigServerEventsWebhookURL)
+			rk(gofig.String, "10s", "", types.ConfigServerEventsWebhookTimeout)
+			rk(gofig.Int, 3, "", types.ConfigServerEventsWebhookRetries)
+			rk(gofig.String, "1s", "",
+				types.ConfigServerEventsWebhookRetryDelay)
 			rk(gofig.Bool, false, "", types.ConfigServerParseRequestOpts)
 
 			// tls config
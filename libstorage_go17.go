@@ -11,6 +11,7 @@ import (
 	"github.com/codedellemc/libstorage/api/server"
 	"github.com/codedellemc/libstorage/api/types"
 	"github.com/codedellemc/libstorage/api/utils"
+	apiconfig "github.com/codedellemc/libstorage/api/utils/config"
 	"github.com/codedellemc/libstorage/client"
 )
 
@@ -36,7 +37,7 @@ func New(
 		return nil, nil, nil, err
 	}
 
-	if h := config.GetString(types.ConfigHost); h == "" {
+	if len(apiconfig.Hosts(config)) == 0 {
 		config.Set(types.ConfigHost, s.Addrs()[0])
 	}
 
@@ -25,12 +25,32 @@ TEST_DRIVERS_BINS := $(foreach d,\
 TEST_DRIVERS_COVR := ./drivers/storage/vfs/tests/vfs.test.out
 
 # a list of the framework packages to test
-TEST_FRAMEWORK_PKGS :=  ./api/context \
+TEST_FRAMEWORK_PKGS :=  ./api/client \
+  ./api/context \
+  ./api/rpc \
+  ./api/server \
   ./api/server/auth \
+  ./api/server/eventsink \
+  ./api/server/handlers \
+  ./api/server/taskstore \
+  ./api/server/lockmgr \
+  ./api/server/openapi \
+  ./api/server/router/metrics \
+  ./api/server/services \
   ./api/types \
+  ./api/utils/devwatch \
   ./api/utils/filters \
+  ./api/utils/labels \
+  ./api/utils/paging \
+  ./api/utils/ratelimit \
   ./api/utils/schema \
-  ./api/utils
+  ./api/utils \
+  ./drivers/storage/dobs/storage \
+  ./drivers/storage/ebs/storage \
+  ./drivers/storage/gcepd/storage \
+  ./drivers/storage/rbd/storage \
+  ./drivers/storage/libstorage \
+  ./drivers/os/linux
 
 # a list of the framework packages' test binaries
 TEST_FRAMEWORK_BINS := $(foreach p,\